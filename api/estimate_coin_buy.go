@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"github.com/MinterTeam/minter-go-node/core/commissions"
+	"github.com/MinterTeam/minter-go-node/core/state/coins"
 	"github.com/MinterTeam/minter-go-node/core/transaction"
 	"github.com/MinterTeam/minter-go-node/core/types"
 	"github.com/MinterTeam/minter-go-node/formula"
@@ -40,6 +41,12 @@ func EstimateCoinBuy(coinToSell, coinToBuy string, valueToBuy *big.Int, height i
 		return nil, rpctypes.RPCError{Code: 400, Message: "\"From\" coin equals to \"to\" coin"}
 	}
 
+	for _, coin := range []*coins.Model{coinFrom, coinTo} {
+		if errResp := transaction.CheckCoinHasReserve(coin); errResp != nil {
+			return nil, rpctypes.RPCError{Code: 400, Message: errResp.Log}
+		}
+	}
+
 	commissionInBaseCoin := big.NewInt(commissions.ConvertTx)
 	commissionInBaseCoin.Mul(commissionInBaseCoin, transaction.CommissionMultiplier)
 	commission := big.NewInt(0).Set(commissionInBaseCoin)
@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"github.com/MinterTeam/minter-go-node/core/commissions"
+	"github.com/MinterTeam/minter-go-node/core/state/coins"
 	"github.com/MinterTeam/minter-go-node/core/transaction"
 	"github.com/MinterTeam/minter-go-node/core/types"
 	"github.com/MinterTeam/minter-go-node/formula"
@@ -40,6 +41,12 @@ func EstimateCoinSell(coinToSell, coinToBuy string, valueToSell *big.Int, height
 		return nil, rpctypes.RPCError{Code: 400, Message: "\"From\" coin equals to \"to\" coin"}
 	}
 
+	for _, coin := range []*coins.Model{coinFrom, coinTo} {
+		if errResp := transaction.CheckCoinHasReserve(coin); errResp != nil {
+			return nil, rpctypes.RPCError{Code: 400, Message: errResp.Log}
+		}
+	}
+
 	var result *big.Int
 
 	commissionInBaseCoin := big.NewInt(commissions.ConvertTx)
@@ -2,6 +2,7 @@ package api
 
 import (
 	"github.com/MinterTeam/minter-go-node/core/commissions"
+	"github.com/MinterTeam/minter-go-node/core/state/coins"
 	"github.com/MinterTeam/minter-go-node/core/transaction"
 	"github.com/MinterTeam/minter-go-node/core/types"
 	"github.com/MinterTeam/minter-go-node/formula"
@@ -39,6 +40,12 @@ func EstimateCoinSellAll(coinToSell, coinToBuy string, valueToSell *big.Int, hei
 		return nil, rpctypes.RPCError{Code: 400, Message: "\"From\" coin equals to \"to\" coin"}
 	}
 
+	for _, coin := range []*coins.Model{coinFrom, coinTo} {
+		if errResp := transaction.CheckCoinHasReserve(coin); errResp != nil {
+			return nil, rpctypes.RPCError{Code: 400, Message: errResp.Log}
+		}
+	}
+
 	commissionInBaseCoin := big.NewInt(commissions.ConvertTx)
 	commissionInBaseCoin.Mul(commissionInBaseCoin, transaction.CommissionMultiplier)
 	commission := big.NewInt(0).Set(commissionInBaseCoin)
@@ -31,6 +31,13 @@ func EstimateTxCommission(tx []byte, height int) (*TxCommissionResponse, error)
 
 	if !decodedTx.GasCoin.IsBaseCoin() {
 		coin := cState.Coins().GetCoin(decodedTx.GasCoin)
+		if coin == nil {
+			return nil, rpctypes.RPCError{Code: 404, Message: "Gas coin not exists"}
+		}
+
+		if errResp := transaction.CheckCoinHasReserve(coin); errResp != nil {
+			return nil, rpctypes.RPCError{Code: 400, Message: errResp.Log}
+		}
 
 		if coin.Reserve().Cmp(commissionInBaseCoin) < 0 {
 			return nil, rpctypes.RPCError{Code: 400, Message: fmt.Sprintf("Coin reserve balance is not sufficient for transaction. Has: %s, required %s",
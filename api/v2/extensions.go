@@ -0,0 +1,663 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/api/v2/service"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	gw "github.com/MinterTeam/node-grpc-gateway/api_pb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+// registerExtensions registers handlers for API v2 responses which can't be described by node-grpc-gateway messages.
+// Proto definitions of API v2 live in the external node-grpc-gateway module, so methods and fields missing there are
+// served only over HTTP by these handlers and have no gRPC counterparts.
+// Handlers registered later in gateway mux take precedence, so they override generated ones.
+func registerExtensions(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) error {
+	err := gwmux.HandlePath(http.MethodGet, "/candidate/{public_key}", candidateHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+
+	err = gwmux.HandlePath(http.MethodGet, "/check/{check}", checkHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+
+	err = gwmux.HandlePath(http.MethodGet, "/allowances/{address}", allowancesHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+
+	err = gwmux.HandlePath(http.MethodGet, "/address/{address}", addressHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+
+	err = gwmux.HandlePath(http.MethodGet, "/name/{name}", nameHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+
+	err = gwmux.HandlePath(http.MethodGet, "/delegations/{address}", delegationsHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+
+	err = gwmux.HandlePath(http.MethodGet, "/coins", coinsHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+
+	err = gwmux.HandlePath(http.MethodGet, "/coin_holders/{coin_id}", coinHoldersHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+	err = gwmux.HandlePath(http.MethodGet, "/estimate_route", estimateRouteHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+	err = gwmux.HandlePath(http.MethodGet, "/candidate_yield/{public_key}", candidateYieldHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+	err = gwmux.HandlePath(http.MethodGet, "/simulate_delegation/{public_key}", simulateDelegationHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+	err = gwmux.HandlePath(http.MethodGet, "/stream", streamHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+	err = gwmux.HandlePath(http.MethodGet, "/coin_price_history/{coin_id}", coinPriceHistoryHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+	err = gwmux.HandlePath(http.MethodGet, "/supply", supplyHandler(gwmux, marshaler, srv))
+	if err != nil {
+		return err
+	}
+	return gwmux.HandlePath(http.MethodGet, "/trace_transaction/{hash}", traceTransactionHandler(gwmux, marshaler, srv))
+}
+
+// candidateHandler serves Candidate response extended with pending_commission field
+func candidateHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		req := &gw.CandidateRequest{PublicKey: pathParams["public_key"]}
+		if err := runtime.PopulateQueryParameters(req, r.URL.Query(), utilities.NewDoubleArray(nil)); err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		candidate, err := srv.Candidate(ctx, req)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		pendingCommission, err := srv.PendingCommission(req.PublicKey, req.Height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeExtendedResponse(ctx, gwmux, marshaler, w, r, candidate, map[string]interface{}{
+			"pending_commission": pendingCommission,
+		})
+	}
+}
+
+// checkHandler serves decoded check with its status
+func checkHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		height, err := heightQueryParameter(r)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		response, err := srv.Check(ctx, pathParams["check"], height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// allowancesHandler serves allowances granted by an address
+func allowancesHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		height, err := heightQueryParameter(r)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		response, err := srv.Allowances(ctx, pathParams["address"], height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// addressHandler serves Address response for an address or a name, extended with name of the address
+func addressHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		req := &gw.AddressRequest{}
+		if err := runtime.PopulateQueryParameters(req, r.URL.Query(), utilities.NewDoubleArray(nil)); err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		address, err := srv.ResolveAddress(pathParams["address"], req.Height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+		req.Address = address
+
+		response, err := srv.Address(ctx, req)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		name, err := srv.AddressName(req.Address, req.Height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeExtendedResponse(ctx, gwmux, marshaler, w, r, response, map[string]interface{}{
+			"address": req.Address,
+			"name":    name,
+		})
+	}
+}
+
+// nameHandler serves address and public key which the name resolves to
+func nameHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		height, err := heightQueryParameter(r)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		response, err := srv.Name(pathParams["name"], height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// delegationsHandler serves stakes of an address or a name
+func delegationsHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		height, err := heightQueryParameter(r)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		address, err := srv.ResolveAddress(pathParams["address"], height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		response, err := srv.Delegations(ctx, address, height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// coinsHandler serves page of coins list with search and sort options
+func coinsHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		query := r.URL.Query()
+		req := &service.CoinsRequest{
+			Search: query.Get("search"),
+			SortBy: query.Get("sort_by"),
+			Desc:   query.Get("order") == "desc",
+		}
+
+		var err error
+		if req.Offset, err = intQueryParameter(r, "offset"); err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+		if req.Limit, err = intQueryParameter(r, "limit"); err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		response, err := srv.Coins(req)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// coinHoldersHandler serves top holders of a coin at the last committed height, it has no height parameter
+func coinHoldersHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		coinID, err := strconv.ParseUint(pathParams["coin_id"], 10, 32)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		limit, err := intQueryParameter(r, "limit")
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		response, err := srv.CoinHolders(coinID, limit)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// estimateRouteHandler serves the best route of selling a coin through the given intermediate coins
+func estimateRouteHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		query := r.URL.Query()
+		req := &service.EstimateRouteRequest{ValueToSell: query.Get("value_to_sell")}
+
+		var err error
+		if req.Height, err = heightQueryParameter(r); err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+		if req.MaxDepth, err = intQueryParameter(r, "max_depth"); err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		for name, coin := range map[string]*types.CoinID{
+			"coin_id_to_sell":    &req.CoinToSell,
+			"coin_id_to_buy":     &req.CoinToBuy,
+			"coin_id_commission": &req.GasCoin,
+		} {
+			if *coin, err = coinIDQueryValue(query.Get(name)); err != nil {
+				httpError(ctx, gwmux, marshaler, w, r, err)
+				return
+			}
+		}
+
+		for _, value := range query["coins"] {
+			coin, err := coinIDQueryValue(value)
+			if err != nil {
+				httpError(ctx, gwmux, marshaler, w, r, err)
+				return
+			}
+			req.Coins = append(req.Coins, coin)
+		}
+
+		response, err := srv.EstimateRoute(ctx, req)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// candidateYieldHandler serves projected and realized APR of candidate's delegators
+func candidateYieldHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		height, err := heightQueryParameter(r)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		window, err := intQueryParameter(r, "window")
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+		if window < 0 {
+			httpError(ctx, gwmux, marshaler, w, r, status.Error(codes.InvalidArgument, "window should be positive"))
+			return
+		}
+
+		response, err := srv.CandidateYield(ctx, pathParams["public_key"], uint64(window), height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// simulateDelegationHandler serves result of simulated delegation of an address or a name to candidate
+func simulateDelegationHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		query := r.URL.Query()
+
+		height, err := heightQueryParameter(r)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		coin, err := coinIDQueryValue(query.Get("coin_id"))
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		address, err := srv.ResolveAddress(query.Get("address"), height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		response, err := srv.SimulateDelegation(ctx, pathParams["public_key"], address, uint64(coin), query.Get("value"), height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// streamHandler serves stream of blocks, transactions and events as newline delimited JSON messages.
+// Connected over websocket, every message is sent as a separate websocket message.
+func streamHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		query := r.URL.Query()
+		filter := &service.StreamFilter{}
+
+		for _, value := range query["address"] {
+			address, err := srv.ResolveAddress(value, 0)
+			if err != nil {
+				httpError(ctx, gwmux, marshaler, w, r, err)
+				return
+			}
+			filter.Addresses = append(filter.Addresses, types.HexToAddress(address))
+		}
+
+		for _, value := range query["public_key"] {
+			if !strings.HasPrefix(value, "Mp") || len(value) != 2+2*len(types.Pubkey{}) {
+				httpError(ctx, gwmux, marshaler, w, r, status.Error(codes.InvalidArgument, "invalid public_key"))
+				return
+			}
+			filter.PubKeys = append(filter.PubKeys, types.HexToPubkey(value))
+		}
+
+		for _, value := range query["coin_id"] {
+			coin, err := coinIDQueryValue(value)
+			if err != nil {
+				httpError(ctx, gwmux, marshaler, w, r, err)
+				return
+			}
+			filter.Coins = append(filter.Coins, coin)
+		}
+
+		for _, value := range query["tx_type"] {
+			txType, err := strconv.ParseUint(value, 0, 8)
+			if err != nil {
+				httpError(ctx, gwmux, marshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+				return
+			}
+			filter.TxTypes = append(filter.TxTypes, transaction.TxType(txType))
+		}
+
+		var cursor *service.StreamCursor
+		if query.Get("height") != "" {
+			height, err := heightQueryParameter(r)
+			if err != nil {
+				httpError(ctx, gwmux, marshaler, w, r, err)
+				return
+			}
+
+			index, err := intQueryParameter(r, "index")
+			if err != nil || index < 0 {
+				httpError(ctx, gwmux, marshaler, w, r, status.Error(codes.InvalidArgument, "invalid index"))
+				return
+			}
+
+			cursor = &service.StreamCursor{Height: height, Index: uint64(index)}
+		}
+
+		started := false
+		err := srv.Stream(ctx, filter, cursor, func(message *service.StreamMessage) error {
+			buf, err := json.Marshal(message)
+			if err != nil {
+				return err
+			}
+
+			if !started {
+				w.Header().Set("Content-Type", marshaler.ContentType(message))
+				started = true
+			}
+
+			if _, err := w.Write(append(buf, '\n')); err != nil {
+				return err
+			}
+			if flusher, ok := w.(http.Flusher); ok {
+				flusher.Flush()
+			}
+
+			return nil
+		})
+		if err != nil && !started {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+		if err != nil {
+			grpclog.Infof("Stream closed: %v", err)
+		}
+	}
+}
+
+// coinPriceHistoryHandler serves price history of a coin as points or OHLC candles
+func coinPriceHistoryHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		coinID, err := strconv.ParseUint(pathParams["coin_id"], 10, 32)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		query := r.URL.Query()
+		req := &service.CoinPriceHistoryRequest{
+			Coin:    coinID,
+			Candles: query.Get("mode") == "ohlc",
+		}
+
+		for name, value := range map[string]*uint64{"from": &req.From, "to": &req.To, "step": &req.Step} {
+			if query.Get(name) == "" {
+				continue
+			}
+
+			if *value, err = strconv.ParseUint(query.Get(name), 10, 64); err != nil {
+				httpError(ctx, gwmux, marshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+				return
+			}
+		}
+
+		response, err := srv.CoinPriceHistory(ctx, req)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// supplyHandler serves supply of base coin and staking statistics
+func supplyHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		height, err := heightQueryParameter(r)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		response, err := srv.Supply(ctx, height)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
+
+// coinIDQueryValue parses coin id from request query value, base coin if it is not set
+func coinIDQueryValue(value string) (types.CoinID, error) {
+	if value == "" {
+		return types.GetBaseCoinID(), nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return types.CoinID(id), nil
+}
+
+// heightQueryParameter returns height from request query, 0 if it is not set
+func heightQueryParameter(r *http.Request) (uint64, error) {
+	value := r.URL.Query().Get("height")
+	if value == "" {
+		return 0, nil
+	}
+
+	height, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return height, nil
+}
+
+// intQueryParameter returns integer parameter from request query, 0 if it is not set
+func intQueryParameter(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return result, nil
+}
+
+// writeExtendedResponse writes gateway message with additional fields
+func writeExtendedResponse(ctx context.Context, gwmux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, msg interface{}, fields map[string]interface{}) {
+	buf, err := marshaler.Marshal(msg)
+	if err != nil {
+		httpError(ctx, gwmux, marshaler, w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{}
+	if err := json.Unmarshal(buf, &response); err != nil {
+		httpError(ctx, gwmux, marshaler, w, r, err)
+		return
+	}
+	for k, v := range fields {
+		response[k] = v
+	}
+
+	writeResponse(ctx, gwmux, marshaler, w, r, response)
+}
+
+// writeResponse writes JSON encoded response which is not a gateway message
+func writeResponse(ctx context.Context, gwmux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, response interface{}) {
+	buf, err := json.Marshal(response)
+	if err != nil {
+		httpError(ctx, gwmux, marshaler, w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", marshaler.ContentType(response))
+	if _, err := w.Write(buf); err != nil {
+		grpclog.Infof("Failed to write response: %v", err)
+	}
+}
+
+// traceTransactionHandler serves trace of committed transaction re-executed at its height
+func traceTransactionHandler(gwmux *runtime.ServeMux, marshaler runtime.Marshaler, srv *service.Service) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+
+		response, err := srv.TraceTransaction(ctx, pathParams["hash"])
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		writeResponse(ctx, gwmux, marshaler, w, r, response)
+	}
+}
@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"encoding/hex"
-	"fmt"
 	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/state/coins"
 	"github.com/MinterTeam/minter-go-node/core/types"
@@ -74,28 +73,7 @@ func (s *Service) Address(ctx context.Context, req *pb.AddressRequest) (*pb.Addr
 		if timeoutStatus := s.checkTimeout(ctx, "Delegated"); timeoutStatus != nil {
 			return nil, timeoutStatus.Err()
 		}
-		var userDelegatedStakesGroupByCoin = map[types.CoinID]*stakeUser{}
-		allCandidates := cState.Candidates().GetCandidates()
-		for i, candidate := range allCandidates {
-			userStakes := userStakes(candidate.PubKey, address, cState)
-
-			if timeoutStatus := s.checkTimeout(ctx, fmt.Sprintf("userStakes of %s [%d]", candidate.PubKey, i)); timeoutStatus != nil {
-				return nil, timeoutStatus.Err()
-			}
-
-			for coin, userStake := range userStakes {
-				stake, ok := userDelegatedStakesGroupByCoin[coin]
-				if !ok {
-					stake = &stakeUser{
-						Value:    big.NewInt(0),
-						BipValue: big.NewInt(0),
-					}
-				}
-				stake.Value.Add(stake.Value, userStake.Value)
-				stake.BipValue.Add(stake.BipValue, userStake.BipValue)
-				userDelegatedStakesGroupByCoin[coin] = stake
-			}
-		}
+		userDelegatedStakesGroupByCoin := userStakes(address, cState)
 
 		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
 			return nil, timeoutStatus.Err()
@@ -156,19 +134,26 @@ func customCoinBipBalance(coinToSell types.CoinID, valueToSell *big.Int, coins c
 	return formula.CalculateSaleReturn(coinFrom.Volume(), coinFrom.Reserve(), coinFrom.Crr(), valueToSell)
 }
 
-func userStakes(c types.Pubkey, address types.Address, state *state.CheckState) map[types.CoinID]*stakeUser {
+// userStakes returns stakes of address in all candidates grouped by coin
+func userStakes(address types.Address, state *state.CheckState) map[types.CoinID]*stakeUser {
 	var userStakes = map[types.CoinID]*stakeUser{}
 
-	stakes := state.Candidates().GetStakes(c)
-
-	for _, stake := range stakes {
-		if stake.Owner != address {
+	for _, delegation := range state.Candidates().GetDelegations(address) {
+		stake := state.Candidates().GetStakeOfAddress(delegation.PubKey, address, delegation.Coin)
+		if stake == nil {
 			continue
 		}
-		userStakes[stake.Coin] = &stakeUser{
-			Value:    stake.Value,
-			BipValue: stake.BipValue,
+
+		userStake, ok := userStakes[delegation.Coin]
+		if !ok {
+			userStake = &stakeUser{
+				Value:    big.NewInt(0),
+				BipValue: big.NewInt(0),
+			}
+			userStakes[delegation.Coin] = userStake
 		}
+		userStake.Value.Add(userStake.Value, stake.Value)
+		userStake.BipValue.Add(userStake.BipValue, stake.BipValue)
 	}
 
 	return userStakes
@@ -71,26 +71,7 @@ func (s *Service) Addresses(ctx context.Context, req *pb.AddressesRequest) (*pb.
 			if timeoutStatus := s.checkTimeout(ctx, "Delegated"); timeoutStatus != nil {
 				return nil, timeoutStatus.Err()
 			}
-			var userDelegatedStakesGroupByCoin = map[types.CoinID]*stakeUser{}
-			allCandidates := cState.Candidates().GetCandidates()
-			for i, candidate := range allCandidates {
-				userStakes := userStakes(candidate.PubKey, address, cState)
-				if timeoutStatus := s.checkTimeout(ctx, fmt.Sprintf("userStakes of %s [%d]", candidate.PubKey, i)); timeoutStatus != nil {
-					return nil, timeoutStatus.Err()
-				}
-				for coin, userStake := range userStakes {
-					stake, ok := userDelegatedStakesGroupByCoin[coin]
-					if !ok {
-						stake = &stakeUser{
-							Value:    big.NewInt(0),
-							BipValue: big.NewInt(0),
-						}
-					}
-					stake.Value.Add(stake.Value, userStake.Value)
-					stake.BipValue.Add(stake.BipValue, userStake.BipValue)
-					userDelegatedStakesGroupByCoin[coin] = stake
-				}
-			}
+			userDelegatedStakesGroupByCoin := userStakes(address, cState)
 
 			if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
 				return nil, timeoutStatus.Err()
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AllowancesResponse is a list of allowances granted by an address
+type AllowancesResponse struct {
+	Allowances []*Allowance `json:"allowances"`
+}
+
+// Allowance is an amount of coins which spender is allowed to transfer from owner's account
+type Allowance struct {
+	Spender      string    `json:"spender"`
+	Coin         CheckCoin `json:"coin"`
+	Value        string    `json:"value"`
+	Available    string    `json:"available"`
+	ExpireHeight string    `json:"expire_height"`
+	Period       string    `json:"period"`
+	PeriodLimit  string    `json:"period_limit"`
+	PeriodSpent  string    `json:"period_spent"`
+}
+
+// Allowances returns allowances granted by an address which are not expired at given height.
+// Available is an amount which can be transferred in the next block.
+func (s *Service) Allowances(ctx context.Context, address string, height uint64) (*AllowancesResponse, error) {
+	if !strings.HasPrefix(strings.Title(address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, err := hex.DecodeString(address[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	owner := types.BytesToAddress(decodeString)
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	// allowance is spent in the next block at the earliest
+	nextHeight := height + 1
+
+	response := &AllowancesResponse{Allowances: make([]*Allowance, 0)}
+
+	model := cState.Allowances().GetByOwner(owner)
+	if model == nil {
+		return response, nil
+	}
+
+	for _, item := range model.List {
+		if item.IsExpired(nextHeight) {
+			continue
+		}
+
+		response.Allowances = append(response.Allowances, &Allowance{
+			Spender:      item.Spender.String(),
+			Coin:         checkCoin(cState, item.Coin),
+			Value:        item.Value.String(),
+			Available:    item.Available(nextHeight).String(),
+			ExpireHeight: strconv.FormatUint(item.ExpireHeight, 10),
+			Period:       strconv.FormatUint(item.Period, 10),
+			PeriodLimit:  item.PeriodLimit.String(),
+			PeriodSpent:  item.SpentInPeriod(nextHeight).String(),
+		})
+	}
+
+	return response, nil
+}
@@ -224,10 +224,21 @@ func blockProposer(block *core_types.ResultBlock, totalValidators []*tmTypes.Val
 }
 
 func (s *Service) blockTransaction(block *core_types.ResultBlock, blockResults *core_types.ResultBlockResults, coins coins.RCoins) ([]*pb.BlockResponse_Transaction, error) {
+	txs, _, err := s.blockTransactionWithData(block, blockResults, coins)
+	return txs, err
+}
+
+// blockTransactionWithData returns transactions of block along with their decoded data. Transactions which can't be
+// decoded are skipped.
+func (s *Service) blockTransactionWithData(block *core_types.ResultBlock, blockResults *core_types.ResultBlockResults, coins coins.RCoins) ([]*pb.BlockResponse_Transaction, []transaction.Data, error) {
 	txs := make([]*pb.BlockResponse_Transaction, 0, len(block.Block.Data.Txs))
+	txsData := make([]transaction.Data, 0, len(block.Block.Data.Txs))
 
 	for i, rawTx := range block.Block.Data.Txs {
-		tx, _ := transaction.TxDecoder.DecodeFromBytes(rawTx)
+		tx, err := transaction.TxDecoder.DecodeFromBytes(rawTx)
+		if err != nil {
+			continue
+		}
 		sender, _ := tx.Sender()
 
 		tags := make(map[string]string)
@@ -237,7 +248,7 @@ func (s *Service) blockTransaction(block *core_types.ResultBlock, blockResults *
 
 		data, err := encode(tx.GetDecodedData(), coins)
 		if err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+			return nil, nil, status.Error(codes.Internal, err.Error())
 		}
 
 		txs = append(txs, &pb.BlockResponse_Transaction{
@@ -259,8 +270,9 @@ func (s *Service) blockTransaction(block *core_types.ResultBlock, blockResults *
 			Code: uint64(blockResults.TxsResults[i].Code),
 			Log:  blockResults.TxsResults[i].Log,
 		})
+		txsData = append(txsData, tx.GetDecodedData())
 	}
-	return txs, nil
+	return txs, txsData, nil
 }
 
 func getBlockProposer(block *core_types.ResultBlock, vals []*tmTypes.Validator) *types.Pubkey {
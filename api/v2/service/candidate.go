@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"math/big"
+	"strconv"
 	"strings"
 )
 
@@ -51,6 +52,56 @@ func (s *Service) Candidate(ctx context.Context, req *pb.CandidateRequest) (*pb.
 	return result, nil
 }
 
+// CandidatePendingCommission is a scheduled change of candidate's commission
+type CandidatePendingCommission struct {
+	Commission string `json:"commission"`
+	Height     string `json:"height"`
+}
+
+// PendingCommission returns scheduled commission change of candidate by provided public_key, nil if there is none.
+// CandidateResponse of node-grpc-gateway has no field for it, so it is added to Candidate response by API v2 HTTP handler.
+func (s *Service) PendingCommission(publicKey string, height uint64) (*CandidatePendingCommission, error) {
+	if !strings.HasPrefix(publicKey, "Mp") {
+		return nil, status.Error(codes.InvalidArgument, "invalid public_key")
+	}
+
+	decodeString, err := hex.DecodeString(publicKey[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pubkey := types.BytesToPubkey(decodeString)
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if height != 0 {
+		cState.Lock()
+		cState.Candidates().LoadCandidates()
+		cState.Unlock()
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	candidate := cState.Candidates().GetCandidate(pubkey)
+	if candidate == nil {
+		return nil, status.Error(codes.NotFound, "Candidate not found")
+	}
+
+	commission, applyHeight, ok := candidate.PendingCommission()
+	if !ok {
+		return nil, nil
+	}
+
+	return &CandidatePendingCommission{
+		Commission: strconv.Itoa(int(commission)),
+		Height:     strconv.FormatUint(applyHeight, 10),
+	}, nil
+}
+
 func makeResponseCandidate(state *state.CheckState, c *candidates.Candidate, includeStakes, NotShowStakes bool) *pb.CandidateResponse {
 	candidate := &pb.CandidateResponse{
 		RewardAddress:  c.RewardAddress.String(),
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/core/dao"
+	"github.com/MinterTeam/minter-go-node/core/developers"
+	eventsdb "github.com/MinterTeam/minter-go-node/core/events"
+	"github.com/MinterTeam/minter-go-node/core/rewards"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/formula"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultYieldWindow = 17280
+	maxYieldWindow     = 120960
+)
+
+// CandidateYieldResponse is projected and realized annual yield of candidate's delegators in percents
+type CandidateYieldResponse struct {
+	PublicKey  string `json:"public_key"`
+	Height     string `json:"height"`
+	Validator  bool   `json:"validator"`
+	Commission string `json:"commission"`
+	TotalStake string `json:"total_stake"`
+
+	// StakeShare is a share of candidate's stake in total stake of validators
+	StakeShare string `json:"stake_share"`
+	// MissedBlocksRatio is a share of blocks missed by validator within the last missed blocks window
+	MissedBlocksRatio string `json:"missed_blocks_ratio"`
+	BlockReward       string `json:"block_reward"`
+	ProjectedApr      string `json:"projected_apr"`
+
+	WindowFrom  string `json:"window_from"`
+	WindowTo    string `json:"window_to"`
+	Rewards     string `json:"rewards"`
+	Slashed     string `json:"slashed"`
+	RealizedApr string `json:"realized_apr"`
+}
+
+// CandidateYield returns annual percentage rate which candidate gives to its delegators.
+// Projected APR is based on block reward at the next block, candidate's share of validators stake, its commission,
+// DAO and developers commissions and ratio of blocks missed by validator. Commissions of transactions are not taken
+// into account. Realized APR is based on delegators' rewards and slashes stored in events within window of blocks
+// ending at the given height, relative to the current total stake of candidate.
+func (s *Service) CandidateYield(ctx context.Context, publicKey string, window uint64, height uint64) (*CandidateYieldResponse, error) {
+	if !strings.HasPrefix(publicKey, "Mp") {
+		return nil, status.Error(codes.InvalidArgument, "invalid public_key")
+	}
+
+	decodeString, err := hex.DecodeString(publicKey[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pubkey := types.BytesToPubkey(decodeString)
+
+	if window == 0 {
+		window = defaultYieldWindow
+	}
+	if window > maxYieldWindow {
+		return nil, status.Errorf(codes.InvalidArgument, "window should be no more than %d blocks", maxYieldWindow)
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if height != 0 {
+		cState.Lock()
+		cState.Candidates().LoadCandidates()
+		cState.Validators().LoadValidators()
+		cState.Unlock()
+	} else {
+		height = s.blockchain.Height()
+	}
+
+	cState.RLock()
+	candidate := cState.Candidates().GetCandidate(pubkey)
+	if candidate == nil {
+		cState.RUnlock()
+		return nil, status.Error(codes.NotFound, "Candidate not found")
+	}
+
+	totalStake := cState.Candidates().GetTotalStake(pubkey)
+	blockReward := rewards.GetRewardForBlock(height + 1)
+
+	response := &CandidateYieldResponse{
+		PublicKey:         pubkey.String(),
+		Height:            strconv.FormatUint(height, 10),
+		Commission:        strconv.Itoa(int(candidate.Commission)),
+		TotalStake:        totalStake.String(),
+		StakeShare:        "0",
+		MissedBlocksRatio: "0",
+		BlockReward:       blockReward.String(),
+		ProjectedApr:      "0",
+		RealizedApr:       "0",
+	}
+
+	if validator := cState.Validators().GetByPublicKey(pubkey); validator != nil {
+		response.Validator = true
+
+		validatorsStake := big.NewInt(0)
+		for _, val := range cState.Validators().GetValidators() {
+			validatorsStake.Add(validatorsStake, val.GetTotalBipStake())
+		}
+
+		missedBlocksRatio := big.NewFloat(0)
+		if size := validator.AbsentTimes.Size(); size != 0 {
+			missedBlocksRatio.Quo(big.NewFloat(float64(validator.CountAbsentTimes())), big.NewFloat(float64(size)))
+		}
+		response.MissedBlocksRatio = missedBlocksRatio.Text('f', 4)
+
+		if validatorsStake.Sign() == 1 && totalStake.Sign() == 1 {
+			stakeShare := new(big.Float).Quo(new(big.Float).SetInt(validator.GetTotalBipStake()), new(big.Float).SetInt(validatorsStake))
+			response.StakeShare = stakeShare.Text('f', 6)
+
+			yearlyReward := new(big.Float).SetInt(big.NewInt(0).Mul(blockReward, big.NewInt(types.BlocksPerYear)))
+			yearlyReward.Mul(yearlyReward, stakeShare)
+			yearlyReward.Mul(yearlyReward, new(big.Float).Sub(big.NewFloat(1), missedBlocksRatio))
+			yearlyReward.Mul(yearlyReward, delegatorsRewardRatio(candidate.Commission))
+
+			response.ProjectedApr = percents(yearlyReward, new(big.Float).SetInt(totalStake))
+		}
+	}
+	cState.RUnlock()
+
+	from := uint64(1)
+	if height > window {
+		from = height - window + 1
+	}
+	response.WindowFrom = strconv.FormatUint(from, 10)
+	response.WindowTo = strconv.FormatUint(height, 10)
+
+	delegatorsRewards, slashes, err := s.candidateRewards(ctx, pubkey, from, height)
+	if err != nil {
+		return nil, err
+	}
+
+	slashed := big.NewInt(0)
+	cState.RLock()
+	for coin, amount := range slashes {
+		slashed.Add(slashed, bipValue(cState, coin, amount))
+	}
+	cState.RUnlock()
+	response.Rewards = delegatorsRewards.String()
+	response.Slashed = slashed.String()
+
+	if totalStake.Sign() == 1 {
+		income := new(big.Float).SetInt(big.NewInt(0).Sub(delegatorsRewards, slashed))
+		income.Mul(income, big.NewFloat(float64(types.BlocksPerYear)/float64(height-from+1)))
+
+		response.RealizedApr = percents(income, new(big.Float).SetInt(totalStake))
+	}
+
+	return response, nil
+}
+
+// candidateRewards returns sum of delegators' rewards of candidate in bip and sums of slashes of its stakes by coins
+// within blocks [from, to]. Events are read without locking the state.
+func (s *Service) candidateRewards(ctx context.Context, pubkey types.Pubkey, from, to uint64) (*big.Int, map[types.CoinID]*big.Int, error) {
+	delegatorsRewards, slashes := big.NewInt(0), map[types.CoinID]*big.Int{}
+
+	for height := from; height <= to; height++ {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, nil, timeoutStatus.Err()
+		}
+
+		for _, event := range s.blockchain.GetEventsDB().LoadEvents(uint32(height)) {
+			switch e := event.(type) {
+			case *eventsdb.RewardEvent:
+				if e.ValidatorPubKey != pubkey || e.Role != eventsdb.RoleDelegator.String() {
+					continue
+				}
+
+				amount, _ := big.NewInt(0).SetString(e.Amount, 10)
+				delegatorsRewards.Add(delegatorsRewards, amount)
+			case *eventsdb.SlashEvent:
+				if e.ValidatorPubKey != pubkey {
+					continue
+				}
+
+				amount, _ := big.NewInt(0).SetString(e.Amount, 10)
+				coin := types.CoinID(e.Coin)
+				if slashes[coin] == nil {
+					slashes[coin] = big.NewInt(0)
+				}
+				slashes[coin].Add(slashes[coin], amount)
+			}
+		}
+	}
+
+	return delegatorsRewards, slashes, nil
+}
+
+// bipValue returns value of coins in bip, 0 if the coin has no reserve or does not exist at the state
+func bipValue(cState *state.CheckState, id types.CoinID, value *big.Int) *big.Int {
+	if id.IsBaseCoin() {
+		return value
+	}
+
+	coin := cState.Coins().GetCoin(id)
+	if coin == nil || coin.IsToken() || coin.Volume().Cmp(value) == -1 {
+		return big.NewInt(0)
+	}
+
+	return formula.CalculateSaleReturn(coin.Volume(), coin.Reserve(), coin.Crr(), value)
+}
+
+// delegatorsRewardRatio returns share of validator's reward which is paid to delegators
+func delegatorsRewardRatio(commission uint32) *big.Float {
+	ratio := big.NewFloat(float64(100 - dao.Commission - developers.Commission))
+	ratio.Mul(ratio, big.NewFloat(float64(100-int(commission))))
+
+	return ratio.Quo(ratio, big.NewFloat(10000))
+}
+
+func percents(value, total *big.Float) string {
+	result := new(big.Float).Quo(value, total)
+	return result.Mul(result, big.NewFloat(100)).Text('f', 2)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/core/check"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Check statuses
+const (
+	CheckStatusRedeemable = "redeemable"
+	CheckStatusRevoked    = "revoked"
+	CheckStatusUsed       = "used"
+	CheckStatusExpired    = "expired"
+)
+
+// CheckResponse is a decoded check with its status
+type CheckResponse struct {
+	Hash     string    `json:"hash"`
+	Issuer   string    `json:"issuer"`
+	Nonce    string    `json:"nonce"`
+	ChainId  string    `json:"chain_id"`
+	DueBlock string    `json:"due_block"`
+	Coin     CheckCoin `json:"coin"`
+	Value    string    `json:"value"`
+	GasCoin  CheckCoin `json:"gas_coin"`
+	Status   string    `json:"status"`
+
+	// fields of partial check
+	RedemptionLimit string `json:"redemption_limit,omitempty"`
+	Remainder       string `json:"remainder,omitempty"`
+}
+
+// CheckCoin is a coin of CheckResponse
+type CheckCoin struct {
+	Id     string `json:"id"`
+	Symbol string `json:"symbol"`
+}
+
+// Check returns decoded check and tells whether it is redeemable, revoked, used or expired.
+// Both ordinary and partial checks are supported.
+func (s *Service) Check(ctx context.Context, rawCheck string, height uint64) (*CheckResponse, error) {
+	decodeString, err := hex.DecodeString(strings.TrimPrefix(rawCheck, "Mc"))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var response *CheckResponse
+	var hash types.Hash
+	var issuer types.Address
+	var dueBlock uint64
+	var coin, gasCoin types.CoinID
+	var partialCheck *check.PartialCheck
+
+	if decodedCheck, err := check.DecodeFromBytes(decodeString); err == nil {
+		issuer, err = decodedCheck.Sender()
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		hash = decodedCheck.Hash()
+		dueBlock, coin, gasCoin = decodedCheck.DueBlock, decodedCheck.Coin, decodedCheck.GasCoin
+		response = &CheckResponse{
+			Hash:     hex.EncodeToString(hash[:]),
+			Nonce:    hex.EncodeToString(decodedCheck.Nonce),
+			ChainId:  strconv.Itoa(int(decodedCheck.ChainID)),
+			DueBlock: strconv.FormatUint(decodedCheck.DueBlock, 10),
+			Value:    decodedCheck.Value.String(),
+		}
+	} else {
+		partialCheck, err = check.DecodePartialFromBytes(decodeString)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		issuer, err = partialCheck.Sender()
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		hash = partialCheck.Hash()
+		dueBlock, coin, gasCoin = partialCheck.DueBlock, partialCheck.Coin, partialCheck.GasCoin
+		response = &CheckResponse{
+			Hash:            hex.EncodeToString(hash[:]),
+			Nonce:           hex.EncodeToString(partialCheck.Nonce),
+			ChainId:         strconv.Itoa(int(partialCheck.ChainID)),
+			DueBlock:        strconv.FormatUint(partialCheck.DueBlock, 10),
+			Value:           partialCheck.Value.String(),
+			RedemptionLimit: partialCheck.RedemptionLimit.String(),
+		}
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	isUsed := cState.Checks().IsCheckHashUsed(hash)
+	if partialCheck != nil {
+		remainder := cState.Checks().GetPartialCheckRemainder(hash)
+		if remainder == nil {
+			remainder = partialCheck.Value
+		}
+		response.Remainder = remainder.String()
+		isUsed = isUsed || remainder.Sign() != 1
+	}
+
+	// check is redeemed in the next block at the earliest
+	response.Status = CheckStatusRedeemable
+	switch {
+	case cState.Checks().IsCheckHashRevoked(hash):
+		response.Status = CheckStatusRevoked
+	case isUsed:
+		response.Status = CheckStatusUsed
+	case dueBlock < height+1:
+		response.Status = CheckStatusExpired
+	}
+
+	response.Issuer = issuer.String()
+	response.Coin = checkCoin(cState, coin)
+	response.GasCoin = checkCoin(cState, gasCoin)
+
+	return response, nil
+}
+
+func checkCoin(cState *state.CheckState, id types.CoinID) CheckCoin {
+	coin := CheckCoin{Id: id.String()}
+	if model := cState.Coins().GetCoin(id); model != nil {
+		coin.Symbol = model.GetFullSymbol()
+	}
+
+	return coin
+}
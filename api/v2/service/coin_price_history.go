@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/coinsindex"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sources of CoinPriceHistoryResponse
+const (
+	PriceSourceIndex = "index"
+	PriceSourceState = "state"
+)
+
+const (
+	maxPricePoints = 1000
+
+	// maxPriceStateBlocks limits amount of historical states read when coins index can't serve the range
+	maxPriceStateBlocks = 1000
+)
+
+// CoinPriceHistoryRequest is a range of blocks [From, To] sampled every Step blocks.
+// If Candles is set, the range is aggregated into OHLC candles of Step blocks.
+type CoinPriceHistoryRequest struct {
+	Coin    uint64
+	From    uint64
+	To      uint64
+	Step    uint64
+	Candles bool
+}
+
+// CoinPriceHistoryResponse is a price history of a coin. Prices are spot prices of one coin in pip.
+type CoinPriceHistoryResponse struct {
+	Coin    CheckCoin          `json:"coin"`
+	Source  string             `json:"source"`
+	Points  []*CoinPricePoint  `json:"points,omitempty"`
+	Candles []*CoinPriceCandle `json:"candles,omitempty"`
+}
+
+// CoinPricePoint is a reserve, volume and spot price of a coin at height
+type CoinPricePoint struct {
+	Height  string `json:"height"`
+	Crr     string `json:"crr"`
+	Volume  string `json:"volume"`
+	Reserve string `json:"reserve"`
+	Price   string `json:"price"`
+}
+
+// CoinPriceCandle is OHLC aggregation of spot price within blocks [From, To]. Volume and Reserve are values at To.
+type CoinPriceCandle struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Open    string `json:"open"`
+	High    string `json:"high"`
+	Low     string `json:"low"`
+	Close   string `json:"close"`
+	Volume  string `json:"volume"`
+	Reserve string `json:"reserve"`
+}
+
+// CoinPriceHistory returns reserve, volume and spot price of a coin over a range of blocks, or OHLC candles of its spot price.
+// History is taken from coins index if it covers the range, otherwise it is read from historical states, which is limited to short ranges.
+func (s *Service) CoinPriceHistory(ctx context.Context, req *CoinPriceHistoryRequest) (*CoinPriceHistoryResponse, error) {
+	if req.Step == 0 {
+		req.Step = 1
+	}
+
+	if req.From == 0 || req.To < req.From {
+		return nil, status.Error(codes.InvalidArgument, "from should be positive and not greater than to")
+	}
+
+	if (req.To-req.From)/req.Step+1 > maxPricePoints {
+		return nil, status.Errorf(codes.InvalidArgument, "range should contain no more than %d steps", maxPricePoints)
+	}
+
+	if lastHeight := s.blockchain.LastCommittedHeight(); req.To > lastHeight {
+		return nil, status.Errorf(codes.NotFound, "wanted to load target %d but only found up to %d", req.To, lastHeight)
+	}
+
+	coin := types.CoinID(req.Coin)
+	if coin.IsBaseCoin() {
+		return nil, status.Error(codes.InvalidArgument, "base coin has no price")
+	}
+
+	cState := s.blockchain.CurrentState()
+	cState.RLock()
+	model := cState.Coins().GetCoin(coin)
+	if model == nil {
+		cState.RUnlock()
+		return nil, s.createError(status.New(codes.NotFound, "Coin not exists"), transaction.EncodeError(code.NewCoinNotExists("", coin.String())))
+	}
+	if errResp := transaction.CheckCoinHasReserve(model); errResp != nil {
+		cState.RUnlock()
+		return nil, s.createError(status.New(codes.FailedPrecondition, errResp.Log), errResp.Info)
+	}
+	response := &CoinPriceHistoryResponse{Coin: checkCoin(cState, coin)}
+	cState.RUnlock()
+
+	var history *priceHistory
+	if index := s.blockchain.CoinsIndex(); index != nil && index.HistoryFrom() != 0 && index.HistoryFrom() <= req.From && req.To <= index.Height() {
+		start, changes, err := index.PriceHistory(coin, req.From, req.To)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		response.Source = PriceSourceIndex
+		history = &priceHistory{start: start, changes: changes}
+	} else {
+		step := req.Step
+		if req.Candles {
+			// every block of a candle is needed to find its high and low
+			step = 1
+		}
+
+		if (req.To-req.From)/step+1 > maxPriceStateBlocks {
+			return nil, status.Errorf(codes.FailedPrecondition, "no more than %d blocks can be read from states, enable coins index for longer ranges", maxPriceStateBlocks)
+		}
+
+		var err error
+		history, err = s.statePriceHistory(ctx, coin, req.From, req.To, step)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Source = PriceSourceState
+	}
+
+	if !req.Candles {
+		response.Points = make([]*CoinPricePoint, 0)
+		for height := req.From; height <= req.To; height += req.Step {
+			point := history.at(height)
+			if point == nil {
+				continue
+			}
+
+			response.Points = append(response.Points, &CoinPricePoint{
+				Height:  strconv.FormatUint(height, 10),
+				Crr:     strconv.Itoa(int(point.Crr)),
+				Volume:  point.Volume.String(),
+				Reserve: point.Reserve.String(),
+				Price:   spotPrice(point).String(),
+			})
+		}
+
+		return response, nil
+	}
+
+	response.Candles = make([]*CoinPriceCandle, 0)
+	for from := req.From; from <= req.To; from += req.Step {
+		to := from + req.Step - 1
+		if to > req.To {
+			to = req.To
+		}
+
+		points := history.within(from, to)
+		if open := history.at(from); open != nil {
+			points = append([]coinsindex.PricePoint{*open}, points...)
+		}
+		if len(points) == 0 {
+			continue
+		}
+
+		open, closing := spotPrice(&points[0]), spotPrice(&points[len(points)-1])
+		high, low := big.NewInt(0).Set(open), big.NewInt(0).Set(open)
+		for i := range points {
+			price := spotPrice(&points[i])
+			if price.Cmp(high) == 1 {
+				high = price
+			}
+			if price.Cmp(low) == -1 {
+				low = price
+			}
+		}
+
+		response.Candles = append(response.Candles, &CoinPriceCandle{
+			From:    strconv.FormatUint(from, 10),
+			To:      strconv.FormatUint(to, 10),
+			Open:    open.String(),
+			High:    high.String(),
+			Low:     low.String(),
+			Close:   closing.String(),
+			Volume:  points[len(points)-1].Volume.String(),
+			Reserve: points[len(points)-1].Reserve.String(),
+		})
+	}
+
+	return response, nil
+}
+
+// statePriceHistory reads volume and reserve of a coin from historical states at every step within [from, to]
+func (s *Service) statePriceHistory(ctx context.Context, coin types.CoinID, from, to, step uint64) (*priceHistory, error) {
+	history := &priceHistory{}
+	for height := from; height <= to; height += step {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+
+		cState, err := s.blockchain.GetStateForHeight(height)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		cState.RLock()
+		if model := cState.Coins().GetCoin(coin); model != nil {
+			history.changes = append(history.changes, coinsindex.PricePoint{
+				Height:  height,
+				Crr:     model.Crr(),
+				Volume:  model.Volume(),
+				Reserve: model.Reserve(),
+			})
+		}
+		cState.RUnlock()
+	}
+
+	return history, nil
+}
+
+// priceHistory is volume and reserve of a coin at the start of a range, nil if the coin didn't exist,
+// and their changes within the range ordered by height
+type priceHistory struct {
+	start   *coinsindex.PricePoint
+	changes []coinsindex.PricePoint
+}
+
+// at returns the point actual at height, nil if there is none
+func (h *priceHistory) at(height uint64) *coinsindex.PricePoint {
+	i := sort.Search(len(h.changes), func(i int) bool {
+		return h.changes[i].Height > height
+	})
+	if i == 0 {
+		return h.start
+	}
+
+	return &h.changes[i-1]
+}
+
+// within returns changes within (from, to]
+func (h *priceHistory) within(from, to uint64) []coinsindex.PricePoint {
+	var points []coinsindex.PricePoint
+	for _, point := range h.changes {
+		if point.Height > from && point.Height <= to {
+			points = append(points, point)
+		}
+	}
+
+	return points
+}
+
+// spotPrice returns price of one coin in pip at which the next infinitely small amount of it is sold or bought
+func spotPrice(point *coinsindex.PricePoint) *big.Int {
+	if point.Volume.Sign() == 0 || point.Crr == 0 {
+		return big.NewInt(0)
+	}
+
+	price := big.NewInt(0).Mul(point.Reserve, big.NewInt(100))
+	price.Mul(price, helpers.BipToPip(big.NewInt(1)))
+	return price.Div(price, big.NewInt(0).Mul(point.Volume, big.NewInt(int64(point.Crr))))
+}
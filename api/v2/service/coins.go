@@ -0,0 +1,181 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/core/coinsindex"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sort options of Coins
+const (
+	CoinsSortByID      = "id"
+	CoinsSortByReserve = "reserve"
+	CoinsSortByVolume  = "volume"
+	CoinsSortByCrr     = "crr"
+)
+
+const maxCoinsLimit = 1000
+
+// CoinsRequest is a filter, sort and page options of Coins
+type CoinsRequest struct {
+	Search string
+	SortBy string
+	Desc   bool
+	Offset int
+	Limit  int
+}
+
+// CoinsResponse is a page of coins list
+type CoinsResponse struct {
+	Total string      `json:"total"`
+	Coins []*CoinItem `json:"coins"`
+}
+
+// CoinItem is a coin of CoinsResponse
+type CoinItem struct {
+	Id      string `json:"id"`
+	Symbol  string `json:"symbol"`
+	Crr     string `json:"crr"`
+	Volume  string `json:"volume"`
+	Reserve string `json:"reserve"`
+}
+
+// CoinHoldersResponse is a list of top holders of a coin at Height, the last height indexed by node
+type CoinHoldersResponse struct {
+	Height   string        `json:"height"`
+	Coin     CheckCoin     `json:"coin"`
+	Balances []*CoinHolder `json:"balances"`
+	Stakes   []*CoinHolder `json:"stakes"`
+}
+
+// CoinHolder is an address with its balance or total stake of a coin
+type CoinHolder struct {
+	Address string `json:"address"`
+	Value   string `json:"value"`
+}
+
+// Coins returns page of coins which symbols contain search string, sorted by given option.
+// Coins are taken from node-side coins index.
+func (s *Service) Coins(req *CoinsRequest) (*CoinsResponse, error) {
+	index := s.blockchain.CoinsIndex()
+	if index == nil {
+		return nil, status.Error(codes.Unavailable, "coins index is disabled")
+	}
+
+	if req.Offset < 0 || req.Limit < 0 || req.Limit > maxCoinsLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "offset should be positive and limit should be in range [0, %d]", maxCoinsLimit)
+	}
+
+	less, err := coinsSortFunc(req.SortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	coins, err := index.Coins()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	search := strings.ToUpper(req.Search)
+	filtered := make([]coinsindex.Coin, 0, len(coins))
+	for _, coin := range coins {
+		if strings.Contains(coin.Symbol, search) {
+			filtered = append(filtered, coin)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if req.Desc {
+			return less(filtered[j], filtered[i])
+		}
+		return less(filtered[i], filtered[j])
+	})
+
+	response := &CoinsResponse{Total: strconv.Itoa(len(filtered)), Coins: make([]*CoinItem, 0)}
+	if req.Offset >= len(filtered) {
+		return response, nil
+	}
+
+	filtered = filtered[req.Offset:]
+	if req.Limit != 0 && len(filtered) > req.Limit {
+		filtered = filtered[:req.Limit]
+	}
+
+	for _, coin := range filtered {
+		response.Coins = append(response.Coins, &CoinItem{
+			Id:      coin.ID.String(),
+			Symbol:  coin.Symbol,
+			Crr:     strconv.Itoa(int(coin.Crr)),
+			Volume:  coin.Volume.String(),
+			Reserve: coin.Reserve.String(),
+		})
+	}
+
+	return response, nil
+}
+
+// CoinHolders returns top addresses by balance and by total stake of a coin.
+// Holders are taken from node-side coins index, which keeps no history and has only the last committed height,
+// so holders at past heights are not available.
+func (s *Service) CoinHolders(id uint64, limit int) (*CoinHoldersResponse, error) {
+	index := s.blockchain.CoinsIndex()
+	if index == nil {
+		return nil, status.Error(codes.Unavailable, "coins index is disabled")
+	}
+
+	if limit < 0 || limit > maxCoinsLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit should be in range [0, %d]", maxCoinsLimit)
+	}
+
+	indexHeight := index.Height()
+
+	cState := s.blockchain.CurrentState()
+	cState.RLock()
+	coinID := types.CoinID(id)
+	if !cState.Coins().Exists(coinID) {
+		cState.RUnlock()
+		return nil, status.Error(codes.NotFound, "Coin not found")
+	}
+	coin := checkCoin(cState, coinID)
+	cState.RUnlock()
+
+	balances, stakes, err := index.Holders(coinID, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &CoinHoldersResponse{
+		Height:   strconv.FormatUint(indexHeight, 10),
+		Coin:     coin,
+		Balances: make([]*CoinHolder, 0, len(balances)),
+		Stakes:   make([]*CoinHolder, 0, len(stakes)),
+	}
+	for _, holder := range balances {
+		response.Balances = append(response.Balances, &CoinHolder{Address: holder.Address.String(), Value: holder.Value.String()})
+	}
+	for _, holder := range stakes {
+		response.Stakes = append(response.Stakes, &CoinHolder{Address: holder.Address.String(), Value: holder.Value.String()})
+	}
+
+	return response, nil
+}
+
+func coinsSortFunc(sortBy string) (func(a, b coinsindex.Coin) bool, error) {
+	switch sortBy {
+	case "", CoinsSortByID:
+		return func(a, b coinsindex.Coin) bool { return a.ID < b.ID }, nil
+	case CoinsSortByReserve:
+		return func(a, b coinsindex.Coin) bool { return a.Reserve.Cmp(b.Reserve) == -1 }, nil
+	case CoinsSortByVolume:
+		return func(a, b coinsindex.Coin) bool { return a.Volume.Cmp(b.Volume) == -1 }, nil
+	case CoinsSortByCrr:
+		return func(a, b coinsindex.Coin) bool { return a.Crr < b.Crr }, nil
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "unknown sort option %s", sortBy)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"github.com/MinterTeam/minter-go-node/core/state/coins"
 	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
 	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
 	"github.com/golang/protobuf/ptypes/any"
 	"google.golang.org/protobuf/proto"
@@ -193,6 +194,117 @@ func encode(data transaction.Data, coins coins.RCoins) (*any.Any, error) {
 			},
 			Value: d.Value.String(),
 		}
+	case *transaction.CreateTokenData:
+		// node-grpc-gateway has no messages for token transactions yet,
+		// so they are encoded as generic structs
+		st, err := toStruct(map[string]interface{}{
+			"name":           d.Name,
+			"symbol":         d.Symbol.String(),
+			"initial_amount": d.InitialAmount.String(),
+			"max_supply":     d.MaxSupply.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = st
+	case *transaction.MintTokenData:
+		st, err := toStruct(map[string]interface{}{
+			"coin":  map[string]interface{}{"id": strconv.Itoa(int(d.Coin)), "symbol": coins.GetCoin(d.Coin).GetFullSymbol()},
+			"value": d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = st
+	case *transaction.BurnTokenData:
+		st, err := toStruct(map[string]interface{}{
+			"coin":  map[string]interface{}{"id": strconv.Itoa(int(d.Coin)), "symbol": coins.GetCoin(d.Coin).GetFullSymbol()},
+			"value": d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = st
+	case *transaction.EditCandidateCommissionData:
+		st, err := toStruct(map[string]interface{}{
+			"pub_key":    d.PubKey.String(),
+			"commission": strconv.Itoa(int(d.Commission)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = st
+	case *transaction.RevokeCheckData:
+		st, err := toStruct(map[string]interface{}{
+			"raw_check": base64.StdEncoding.EncodeToString(d.RawCheck),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = st
+	case *transaction.RedeemPartialCheckData:
+		st, err := toStruct(map[string]interface{}{
+			"raw_check": base64.StdEncoding.EncodeToString(d.RawCheck),
+			"proof":     base64.StdEncoding.EncodeToString(d.Proof[:]),
+			"value":     d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = st
+	case *transaction.ApproveData:
+		st, err := toStruct(map[string]interface{}{
+			"spender":       d.Spender.String(),
+			"coin":          map[string]interface{}{"id": strconv.Itoa(int(d.Coin)), "symbol": coins.GetCoin(d.Coin).GetFullSymbol()},
+			"value":         d.Value.String(),
+			"expire_height": strconv.FormatUint(d.ExpireHeight, 10),
+			"period":        strconv.FormatUint(d.Period, 10),
+			"period_limit":  d.PeriodLimit.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = st
+	case *transaction.TransferFromData:
+		st, err := toStruct(map[string]interface{}{
+			"from":  d.From.String(),
+			"to":    d.To.String(),
+			"coin":  map[string]interface{}{"id": strconv.Itoa(int(d.Coin)), "symbol": coins.GetCoin(d.Coin).GetFullSymbol()},
+			"value": d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = st
+	case *transaction.RegisterNameData:
+		fields := map[string]interface{}{
+			"name": d.Name,
+		}
+		if d.PubKey != (types.Pubkey{}) {
+			fields["pub_key"] = d.PubKey.String()
+		}
+		st, err := toStruct(fields)
+		if err != nil {
+			return nil, err
+		}
+		m = st
+	case *transaction.RenewNameData:
+		st, err := toStruct(map[string]interface{}{
+			"name": d.Name,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = st
+	case *transaction.TransferNameData:
+		st, err := toStruct(map[string]interface{}{
+			"name": d.Name,
+			"to":   d.To.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = st
 	default:
 		return nil, errors.New("unknown tx type")
 	}
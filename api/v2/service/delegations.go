@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DelegationsResponse is a list of stakes of an address
+type DelegationsResponse struct {
+	Delegations []*Delegation `json:"delegations"`
+}
+
+// Delegation is a stake of an address in a candidate
+type Delegation struct {
+	PublicKey string    `json:"public_key"`
+	Coin      CheckCoin `json:"coin"`
+	Value     string    `json:"value"`
+	BipValue  string    `json:"bip_value"`
+}
+
+// Delegations returns stakes of an address in all candidates.
+func (s *Service) Delegations(ctx context.Context, address string, height uint64) (*DelegationsResponse, error) {
+	if !strings.HasPrefix(strings.Title(address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, err := hex.DecodeString(address[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	owner := types.BytesToAddress(decodeString)
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if height != 0 {
+		cState.Lock()
+		cState.Candidates().LoadCandidates()
+		cState.Candidates().LoadStakes()
+		cState.Unlock()
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx, "LoadCandidates", "LoadStakes"); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	delegations := cState.Candidates().GetDelegations(owner)
+
+	response := &DelegationsResponse{Delegations: make([]*Delegation, 0, len(delegations))}
+	for _, delegation := range delegations {
+		stake := cState.Candidates().GetStakeOfAddress(delegation.PubKey, owner, delegation.Coin)
+		if stake == nil {
+			continue
+		}
+
+		response.Delegations = append(response.Delegations, &Delegation{
+			PublicKey: delegation.PubKey.String(),
+			Coin:      checkCoin(cState, delegation.Coin),
+			Value:     stake.Value.String(),
+			BipValue:  stake.BipValue.String(),
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/commissions"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/state/coins"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/formula"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRouteDepth = 2
+	maxRouteDepth     = 4
+	maxRouteCoins     = 10
+)
+
+// EstimateRouteRequest is a request of EstimateRoute. Coins are the intermediate coins which routes may go through,
+// MaxDepth is the maximum number of conversions in a route.
+type EstimateRouteRequest struct {
+	CoinToSell  types.CoinID
+	CoinToBuy   types.CoinID
+	ValueToSell string
+	GasCoin     types.CoinID
+	Coins       []types.CoinID
+	MaxDepth    int
+	Height      uint64
+}
+
+// EstimateRouteResponse is the best route of selling a coin. Commission is the total commission of the route in gas coin.
+type EstimateRouteResponse struct {
+	WillGet    string      `json:"will_get"`
+	Commission string      `json:"commission"`
+	GasCoin    CheckCoin   `json:"gas_coin"`
+	Hops       []*RouteHop `json:"hops"`
+}
+
+// RouteHop is a single sell coin transaction of EstimateRouteResponse
+type RouteHop struct {
+	CoinToSell  CheckCoin `json:"coin_to_sell"`
+	CoinToBuy   CheckCoin `json:"coin_to_buy"`
+	ValueToSell string    `json:"value_to_sell"`
+	WillGet     string    `json:"will_get"`
+	Commission  string    `json:"commission"`
+}
+
+// EstimateRoute searches the route of selling ValueToSell of CoinToSell for CoinToBuy through the given intermediate coins,
+// which gives the most of CoinToBuy after the commissions of all its transactions are paid. Every route is estimated as
+// a sequence of sell coin transactions, each of them sells everything got by the previous one and pays its commission in
+// GasCoin, so every conversion sees volumes and reserves changed by the previous ones.
+func (s *Service) EstimateRoute(ctx context.Context, req *EstimateRouteRequest) (*EstimateRouteResponse, error) {
+	valueToSell, ok := big.NewInt(0).SetString(req.ValueToSell, 10)
+	if !ok || valueToSell.Sign() != 1 {
+		return nil, status.Error(codes.InvalidArgument, "Value to sell not specified")
+	}
+
+	maxDepth := req.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = defaultRouteDepth
+	}
+	if maxDepth < 1 || maxDepth > maxRouteDepth {
+		return nil, status.Errorf(codes.InvalidArgument, "max depth should be in range [1, %d]", maxRouteDepth)
+	}
+
+	if len(req.Coins) > maxRouteCoins {
+		return nil, status.Errorf(codes.InvalidArgument, "too many intermediate coins, maximum is %d", maxRouteCoins)
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	for _, coin := range []types.CoinID{req.CoinToSell, req.CoinToBuy, req.GasCoin} {
+		if !cState.Coins().Exists(coin) {
+			return nil, s.createError(status.New(codes.NotFound, "Coin not exists"), transaction.EncodeError(code.NewCoinNotExists("", coin.String())))
+		}
+	}
+
+	if req.CoinToSell == req.CoinToBuy {
+		return nil, s.createError(status.New(codes.InvalidArgument, "\"From\" coin equals to \"to\" coin"),
+			transaction.EncodeError(code.NewCrossConvert(req.CoinToSell.String(), cState.Coins().GetCoin(req.CoinToSell).GetFullSymbol(), req.CoinToBuy.String(), cState.Coins().GetCoin(req.CoinToBuy).GetFullSymbol())))
+	}
+
+	for _, coin := range []types.CoinID{req.CoinToSell, req.CoinToBuy, req.GasCoin} {
+		if errResp := transaction.CheckCoinHasReserve(cState.Coins().GetCoin(coin)); errResp != nil {
+			return nil, s.createError(status.New(codes.FailedPrecondition, errResp.Log), errResp.Info)
+		}
+	}
+
+	var intermediate []types.CoinID
+	seen := map[types.CoinID]bool{req.CoinToSell: true, req.CoinToBuy: true}
+	for _, coin := range req.Coins {
+		if seen[coin] {
+			continue
+		}
+		seen[coin] = true
+
+		model := cState.Coins().GetCoin(coin)
+		if model == nil {
+			return nil, s.createError(status.New(codes.NotFound, "Coin not exists"), transaction.EncodeError(code.NewCoinNotExists("", coin.String())))
+		}
+		if model.IsToken() {
+			continue
+		}
+
+		intermediate = append(intermediate, coin)
+	}
+
+	commissionInBaseCoin := big.NewInt(0).Mul(big.NewInt(commissions.ConvertTx), transaction.CommissionMultiplier)
+
+	var best *routeEstimate
+	var firstErr *transaction.Response
+	for _, path := range routePaths(req.CoinToSell, req.CoinToBuy, intermediate, maxDepth) {
+		estimate, errResp := estimateRoute(cState, path, valueToSell, req.GasCoin, commissionInBaseCoin)
+		if errResp != nil {
+			if firstErr == nil {
+				firstErr = errResp
+			}
+			continue
+		}
+
+		if best == nil || estimate.net.Cmp(best.net) == 1 {
+			best = estimate
+		}
+	}
+
+	if best == nil {
+		return nil, s.createError(status.New(codes.FailedPrecondition, firstErr.Log), firstErr.Info)
+	}
+
+	response := &EstimateRouteResponse{
+		WillGet:    best.willGet.String(),
+		Commission: best.commission.String(),
+		GasCoin:    checkCoin(cState, req.GasCoin),
+		Hops:       make([]*RouteHop, 0, len(best.hops)),
+	}
+	for _, hop := range best.hops {
+		response.Hops = append(response.Hops, &RouteHop{
+			CoinToSell:  checkCoin(cState, hop.coinToSell),
+			CoinToBuy:   checkCoin(cState, hop.coinToBuy),
+			ValueToSell: hop.valueToSell.String(),
+			WillGet:     hop.willGet.String(),
+			Commission:  hop.commission.String(),
+		})
+	}
+
+	return response, nil
+}
+
+// routePaths returns all routes from coinToSell to coinToBuy with no more than maxDepth conversions,
+// in which every intermediate coin is used once. The direct route goes first.
+func routePaths(coinToSell, coinToBuy types.CoinID, intermediate []types.CoinID, maxDepth int) [][]types.CoinID {
+	var paths [][]types.CoinID
+	used := make(map[types.CoinID]bool, len(intermediate))
+
+	var walk func(path []types.CoinID)
+	walk = func(path []types.CoinID) {
+		paths = append(paths, append(append([]types.CoinID{}, path...), coinToBuy))
+		if len(path) == maxDepth {
+			return
+		}
+
+		for _, coin := range intermediate {
+			if used[coin] {
+				continue
+			}
+
+			used[coin] = true
+			walk(append(path, coin))
+			used[coin] = false
+		}
+	}
+	walk([]types.CoinID{coinToSell})
+
+	return paths
+}
+
+type routeHop struct {
+	coinToSell  types.CoinID
+	coinToBuy   types.CoinID
+	valueToSell *big.Int
+	willGet     *big.Int
+	commission  *big.Int
+}
+
+type routeEstimate struct {
+	hops       []routeHop
+	willGet    *big.Int
+	commission *big.Int
+
+	// net is the amount of coin to buy got by the route minus the commission of the route in that coin
+	net *big.Int
+}
+
+// estimateRoute simulates sell coin transactions along the path on a copy of volumes and reserves of the coins
+func estimateRoute(cState *state.CheckState, path []types.CoinID, valueToSell *big.Int, gasCoin types.CoinID, commissionInBaseCoin *big.Int) (*routeEstimate, *transaction.Response) {
+	pool := routePool{cState: cState, coins: map[types.CoinID]*routeCoin{}}
+
+	estimate := &routeEstimate{commission: big.NewInt(0)}
+	value := valueToSell
+	for i := 1; i < len(path); i++ {
+		willGet, errResp := pool.sell(path[i-1], path[i], value)
+		if errResp != nil {
+			return nil, errResp
+		}
+
+		commission, errResp := pool.payCommission(gasCoin, commissionInBaseCoin)
+		if errResp != nil {
+			return nil, errResp
+		}
+
+		estimate.hops = append(estimate.hops, routeHop{
+			coinToSell:  path[i-1],
+			coinToBuy:   path[i],
+			valueToSell: value,
+			willGet:     willGet,
+			commission:  commission,
+		})
+		estimate.commission.Add(estimate.commission, commission)
+
+		value = willGet
+	}
+
+	estimate.willGet = value
+
+	coinToBuy := path[len(path)-1]
+	totalCommissionInBaseCoin := big.NewInt(0).Mul(commissionInBaseCoin, big.NewInt(int64(len(estimate.hops))))
+	estimate.net = big.NewInt(0).Sub(value, pool.commissionIn(coinToBuy, gasCoin, estimate.commission, totalCommissionInBaseCoin))
+
+	return estimate, nil
+}
+
+// routeCoin is a coin with volume and reserve changed by simulated transactions
+type routeCoin struct {
+	model   *coins.Model
+	volume  *big.Int
+	reserve *big.Int
+}
+
+// checkReserveUnderflow is the same check as transaction.CheckReserveUnderflow for simulated reserve
+func (c *routeCoin) checkReserveUnderflow(delta *big.Int) *transaction.Response {
+	total := big.NewInt(0).Sub(c.reserve, delta)
+
+	if total.Cmp(transaction.MinCoinReserve) == -1 {
+		min := big.NewInt(0).Add(transaction.MinCoinReserve, delta)
+		return &transaction.Response{
+			Code: code.CoinReserveUnderflow,
+			Log:  fmt.Sprintf("coin %s reserve is too small (%s, required at least %s)", c.model.GetFullSymbol(), c.reserve.String(), min.String()),
+			Info: transaction.EncodeError(code.NewCoinReserveUnderflow(delta.String(), c.reserve.String(), total.String(), transaction.MinCoinReserve.String(), c.model.GetFullSymbol(), c.model.ID().String())),
+		}
+	}
+
+	return nil
+}
+
+// checkSupplyOverflow is the same check as transaction.CheckForCoinSupplyOverflow for simulated volume
+func (c *routeCoin) checkSupplyOverflow(delta *big.Int) *transaction.Response {
+	total := big.NewInt(0).Add(c.volume, delta)
+
+	if total.Cmp(c.model.MaxSupply()) != -1 {
+		return &transaction.Response{
+			Code: code.CoinSupplyOverflow,
+			Log:  "coin supply overflow",
+			Info: transaction.EncodeError(code.NewCoinSupplyOverflow(delta.String(), c.volume.String(), total.String(), c.model.MaxSupply().String(), c.model.GetFullSymbol(), c.model.ID().String())),
+		}
+	}
+
+	return nil
+}
+
+// routePool holds coins changed by simulated transactions of a single route
+type routePool struct {
+	cState *state.CheckState
+	coins  map[types.CoinID]*routeCoin
+}
+
+func (p routePool) get(id types.CoinID) *routeCoin {
+	if coin, ok := p.coins[id]; ok {
+		return coin
+	}
+
+	model := p.cState.Coins().GetCoin(id)
+	coin := &routeCoin{model: model, volume: model.Volume(), reserve: model.Reserve()}
+	p.coins[id] = coin
+
+	return coin
+}
+
+// sell converts value of coinToSell to coinToBuy the same way as sell coin transaction does
+func (p routePool) sell(coinToSell, coinToBuy types.CoinID, value *big.Int) (*big.Int, *transaction.Response) {
+	baseValue := big.NewInt(0).Set(value)
+	if !coinToSell.IsBaseCoin() {
+		coin := p.get(coinToSell)
+		baseValue = formula.CalculateSaleReturn(coin.volume, coin.reserve, coin.model.Crr(), value)
+		if errResp := coin.checkReserveUnderflow(baseValue); errResp != nil {
+			return nil, errResp
+		}
+
+		coin.volume.Sub(coin.volume, value)
+		coin.reserve.Sub(coin.reserve, baseValue)
+	}
+
+	willGet := baseValue
+	if !coinToBuy.IsBaseCoin() {
+		coin := p.get(coinToBuy)
+		willGet = formula.CalculatePurchaseReturn(coin.volume, coin.reserve, coin.model.Crr(), baseValue)
+		if errResp := coin.checkSupplyOverflow(willGet); errResp != nil {
+			return nil, errResp
+		}
+
+		coin.volume.Add(coin.volume, willGet)
+		coin.reserve.Add(coin.reserve, baseValue)
+	}
+
+	return willGet, nil
+}
+
+// payCommission returns commission in gas coin and takes it out of gas coin volume and reserve
+func (p routePool) payCommission(gasCoin types.CoinID, commissionInBaseCoin *big.Int) (*big.Int, *transaction.Response) {
+	if gasCoin.IsBaseCoin() {
+		return big.NewInt(0).Set(commissionInBaseCoin), nil
+	}
+
+	coin := p.get(gasCoin)
+	if errResp := coin.checkReserveUnderflow(commissionInBaseCoin); errResp != nil {
+		return nil, errResp
+	}
+
+	commission := formula.CalculateSaleAmount(coin.volume, coin.reserve, coin.model.Crr(), commissionInBaseCoin)
+	coin.volume.Sub(coin.volume, commission)
+	coin.reserve.Sub(coin.reserve, commissionInBaseCoin)
+
+	return commission, nil
+}
+
+// commissionIn returns the value of commission in the given coin
+func (p routePool) commissionIn(coin, gasCoin types.CoinID, commission, commissionInBaseCoin *big.Int) *big.Int {
+	if coin == gasCoin {
+		return commission
+	}
+
+	if coin.IsBaseCoin() {
+		return commissionInBaseCoin
+	}
+
+	model := p.get(coin)
+	return formula.CalculatePurchaseReturn(model.volume, model.reserve, model.model.Crr(), commissionInBaseCoin)
+}
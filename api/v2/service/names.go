@@ -0,0 +1,109 @@
+package service
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NameResponse is a registered name
+type NameResponse struct {
+	Name         string `json:"name"`
+	Address      string `json:"address"`
+	PubKey       string `json:"pub_key,omitempty"`
+	ExpireHeight string `json:"expire_height"`
+}
+
+// Name returns address and public key which the name resolves to.
+func (s *Service) Name(name string, height uint64) (*NameResponse, error) {
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	model := cState.Names().GetName(name)
+	if model == nil || model.IsExpired(height) {
+		return nil, status.Error(codes.NotFound, "Name not found")
+	}
+
+	response := &NameResponse{
+		Name:         name,
+		Address:      model.Owner.String(),
+		ExpireHeight: strconv.FormatUint(model.ExpireHeight, 10),
+	}
+	if model.HasPubKey() {
+		response.PubKey = model.PubKey.String()
+	}
+
+	return response, nil
+}
+
+// ResolveAddress returns Mx address as is, otherwise treats it as a name and returns address which the name resolves to
+func (s *Service) ResolveAddress(address string, height uint64) (string, error) {
+	if isAddress(address) {
+		return address, nil
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return "", status.Error(codes.NotFound, err.Error())
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	resolved, ok := cState.Names().ResolveAddress(address, height)
+	if !ok {
+		return "", status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	return resolved.String(), nil
+}
+
+// AddressName returns name of the address, empty string if it has no name.
+// AddressResponse of node-grpc-gateway has no field for it, so it is added to Address response by API v2 HTTP handler.
+func (s *Service) AddressName(address string, height uint64) (string, error) {
+	if !isAddress(address) {
+		return "", status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, _ := hex.DecodeString(address[2:])
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return "", status.Error(codes.NotFound, err.Error())
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	return cState.Names().ReverseLookup(types.BytesToAddress(decodeString), height), nil
+}
+
+func isAddress(address string) bool {
+	if !strings.HasPrefix(strings.Title(address), "Mx") {
+		return false
+	}
+
+	decodeString, err := hex.DecodeString(address[2:])
+	return err == nil && len(decodeString) == types.AddressLength
+}
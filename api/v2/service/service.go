@@ -22,6 +22,7 @@ type Service struct {
 	tmNode     *tmNode.Node
 	minterCfg  *config.Config
 	version    string
+	streams    int32 // amount of active streams, accessed atomically
 	api_pb.UnimplementedApiServiceServer
 }
 
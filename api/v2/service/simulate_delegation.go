@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SimulateDelegationResponse tells whether a delegation would stay in candidate's stakes after the next recalculation of stakes
+type SimulateDelegationResponse struct {
+	Enters   bool      `json:"enters"`
+	Coin     CheckCoin `json:"coin"`
+	BipValue string    `json:"bip_value"`
+
+	// MinValue is the least value of coin which can outbid the smallest stake of candidate, 0 if there are free slots
+	MinValue    string         `json:"min_value"`
+	MinBipValue string         `json:"min_bip_value"`
+	Kicked      []*KickedStake `json:"kicked"`
+}
+
+// KickedStake is a stake which would be moved to waitlist
+type KickedStake struct {
+	Owner    string    `json:"owner"`
+	Coin     CheckCoin `json:"coin"`
+	Value    string    `json:"value"`
+	BipValue string    `json:"bip_value"`
+}
+
+// SimulateDelegation simulates the next recalculation of candidate's stakes as if address delegated value of coin,
+// and returns which stakes would be kicked to waitlist. State is not changed.
+func (s *Service) SimulateDelegation(ctx context.Context, publicKey string, address string, coinID uint64, value string, height uint64) (*SimulateDelegationResponse, error) {
+	if !strings.HasPrefix(publicKey, "Mp") {
+		return nil, status.Error(codes.InvalidArgument, "invalid public_key")
+	}
+
+	decodePubKey, err := hex.DecodeString(publicKey[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pubkey := types.BytesToPubkey(decodePubKey)
+
+	if !strings.HasPrefix(strings.Title(address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeAddress, err := hex.DecodeString(address[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	owner := types.BytesToAddress(decodeAddress)
+
+	delegated, ok := big.NewInt(0).SetString(value, 10)
+	if !ok || delegated.Sign() != 1 {
+		return nil, status.Error(codes.InvalidArgument, "Value not specified")
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if height != 0 {
+		cState.Lock()
+		cState.Candidates().LoadCandidates()
+		cState.Candidates().LoadStakes()
+		cState.Unlock()
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	if cState.Candidates().GetCandidate(pubkey) == nil {
+		return nil, status.Error(codes.NotFound, "Candidate not found")
+	}
+
+	coin := types.CoinID(coinID)
+	if !cState.Coins().Exists(coin) {
+		return nil, s.createError(status.New(codes.NotFound, "Coin not exists"), transaction.EncodeError(code.NewCoinNotExists("", coin.String())))
+	}
+
+	if errResp := transaction.CheckCoinHasReserve(cState.Coins().GetCoin(coin)); errResp != nil {
+		return nil, s.createError(status.New(codes.FailedPrecondition, errResp.Log), errResp.Info)
+	}
+
+	simulation := cState.Candidates().SimulateDelegation(owner, pubkey, coin, delegated)
+
+	response := &SimulateDelegationResponse{
+		Enters:      simulation.Enters,
+		Coin:        checkCoin(cState, coin),
+		BipValue:    simulation.BipValue.String(),
+		MinValue:    simulation.MinValue.String(),
+		MinBipValue: simulation.MinBipValue.String(),
+		Kicked:      make([]*KickedStake, 0, len(simulation.Kicked)),
+	}
+	for _, kicked := range simulation.Kicked {
+		response.Kicked = append(response.Kicked, &KickedStake{
+			Owner:    kicked.Owner.String(),
+			Coin:     checkCoin(cState, kicked.Coin),
+			Value:    kicked.Value.String(),
+			BipValue: kicked.BipValue.String(),
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/core/events"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Types of StreamMessage
+const (
+	StreamMessageBlock       = "block"
+	StreamMessageTransaction = "transaction"
+	StreamMessageEvent       = "event"
+)
+
+const (
+	streamPollInterval = time.Second
+
+	// maxStreamBacklog is how many blocks behind the last committed one a stream can be resumed from
+	maxStreamBacklog = 17280
+)
+
+// StreamFilter selects transactions and events sent by Stream. Every non-empty list has to contain a value
+// of transaction or event. Blocks are always sent, so clients can advance their cursor.
+// If TxTypes is not empty, events are not sent.
+type StreamFilter struct {
+	Addresses []types.Address
+	PubKeys   []types.Pubkey
+	Coins     []types.CoinID
+	TxTypes   []transaction.TxType
+}
+
+// StreamCursor is a position of a message in stream. Block has index 0 and is followed by its transactions
+// in order of the block and then by events of the block. Indexes don't depend on filter.
+type StreamCursor struct {
+	Height uint64
+	Index  uint64
+}
+
+// StreamMessage is a block, a transaction or an event sent by Stream
+type StreamMessage struct {
+	Height      string          `json:"height"`
+	Index       string          `json:"index"`
+	Type        string          `json:"type"`
+	Block       *StreamBlock    `json:"block,omitempty"`
+	Transaction json.RawMessage `json:"transaction,omitempty"`
+	Event       json.RawMessage `json:"event,omitempty"`
+}
+
+// StreamBlock is a block of StreamMessage
+type StreamBlock struct {
+	Hash             string `json:"hash"`
+	Time             string `json:"time"`
+	TransactionCount string `json:"transaction_count"`
+}
+
+// Stream sends committed blocks with their decoded transactions and events which match filter.
+// Stream starts right after the cursor, or from the next block if cursor height is 0, so clients can
+// reconnect with the cursor of the last received message without missing data.
+// Stream ends when ctx is done, WS connection duration is over or send fails.
+func (s *Service) Stream(ctx context.Context, filter *StreamFilter, cursor *StreamCursor, send func(*StreamMessage) error) error {
+	if s.client.NumClients()+int(atomic.LoadInt32(&s.streams)) >= s.minterCfg.RPC.MaxSubscriptionClients {
+		return status.Errorf(codes.ResourceExhausted, "max_subscription_clients %d reached", s.minterCfg.RPC.MaxSubscriptionClients)
+	}
+
+	atomic.AddInt32(&s.streams, 1)
+	defer atomic.AddInt32(&s.streams, -1)
+
+	lastHeight := s.blockchain.LastCommittedHeight()
+
+	height := lastHeight + 1
+	if cursor != nil {
+		if cursor.Height > lastHeight {
+			return status.Errorf(codes.InvalidArgument, "cursor height %d is greater than the last committed height %d", cursor.Height, lastHeight)
+		}
+		if lastHeight-cursor.Height > maxStreamBacklog {
+			return status.Errorf(codes.OutOfRange, "cursor height %d is more than %d blocks behind", cursor.Height, maxStreamBacklog)
+		}
+
+		height = cursor.Height
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.minterCfg.WSConnectionDuration)
+	defer cancel()
+
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ; height <= s.blockchain.LastCommittedHeight(); height++ {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+
+			messages, err := s.streamMessages(height, filter)
+			if err != nil {
+				return err
+			}
+
+			for index, message := range messages {
+				if message == nil {
+					continue
+				}
+
+				if cursor != nil && height == cursor.Height && uint64(index) <= cursor.Index {
+					continue
+				}
+
+				if err := send(message); err != nil {
+					return err
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// streamMessages returns messages of block at given height, indexed by their cursor index. Messages which don't match filter are nil.
+func (s *Service) streamMessages(height uint64, filter *StreamFilter) ([]*StreamMessage, error) {
+	h := int64(height)
+	block, err := s.client.Block(&h)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Block not found")
+	}
+
+	blockResults, err := s.client.BlockResults(&h)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Block results not found")
+	}
+
+	cState := s.blockchain.CurrentState()
+	cState.RLock()
+	txs, txsData, err := s.blockTransactionWithData(block, blockResults, cState.Coins())
+	cState.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	events := s.blockchain.GetEventsDB().LoadEvents(uint32(height))
+
+	heightString := strconv.FormatUint(height, 10)
+	messages := make([]*StreamMessage, 0, 1+len(txs)+len(events))
+	messages = append(messages, &StreamMessage{
+		Height: heightString,
+		Index:  "0",
+		Type:   StreamMessageBlock,
+		Block: &StreamBlock{
+			Hash:             hex.EncodeToString(block.Block.Hash()),
+			Time:             block.Block.Time.Format(time.RFC3339Nano),
+			TransactionCount: strconv.Itoa(len(block.Block.Txs)),
+		},
+	})
+
+	for i, tx := range txs {
+		if !filter.matchTransaction(tx, txsData[i]) {
+			messages = append(messages, nil)
+			continue
+		}
+
+		data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(tx)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		messages = append(messages, &StreamMessage{
+			Height:      heightString,
+			Index:       strconv.Itoa(len(messages)),
+			Type:        StreamMessageTransaction,
+			Transaction: data,
+		})
+	}
+
+	for _, event := range events {
+		if !filter.matchEvent(event) {
+			messages = append(messages, nil)
+			continue
+		}
+
+		data, err := s.cdc.MarshalJSON(event)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		messages = append(messages, &StreamMessage{
+			Height: heightString,
+			Index:  strconv.Itoa(len(messages)),
+			Type:   StreamMessageEvent,
+			Event:  data,
+		})
+	}
+
+	return messages, nil
+}
+
+func (f *StreamFilter) matchTransaction(tx *pb.BlockResponse_Transaction, data transaction.Data) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.TxTypes) != 0 {
+		matched := false
+		for _, txType := range f.TxTypes {
+			if uint64(txType) == tx.Type {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Addresses) != 0 {
+		matched := false
+		for _, address := range f.Addresses {
+			if tx.From == address.String() || tagsContain(tx.Tags, hex.EncodeToString(address.Bytes())) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.PubKeys) != 0 {
+		// delegations by name have no public key in data, it is resolved at delivery and stored in tags
+		candidateTx, ok := data.(transaction.CandidateTx)
+
+		matched := false
+		for _, pubkey := range f.PubKeys {
+			if (ok && candidateTx.GetPubKey() == pubkey) || tx.Tags["tx.public_key"] == hex.EncodeToString(pubkey[:]) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Coins) != 0 {
+		matched := false
+		for _, coin := range f.Coins {
+			if tx.GasCoin.Id == uint64(coin) || txHasCoin(tx.Tags, coin) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *StreamFilter) matchEvent(event eventsdb.Event) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.TxTypes) != 0 {
+		return false
+	}
+
+	if len(f.Addresses) != 0 {
+		matched := false
+		for _, address := range f.Addresses {
+			if event.AddressString() == address.String() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.PubKeys) != 0 {
+		matched := false
+		for _, pubkey := range f.PubKeys {
+			if event.ValidatorPubKeyString() == pubkey.String() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(f.Coins) != 0 {
+		coin, ok := eventCoin(event)
+		if !ok {
+			return false
+		}
+
+		matched := false
+		for _, c := range f.Coins {
+			if c == coin {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// tagsContain reports whether value of any tag contains hex encoded value. Some tags contain lists of addresses.
+func tagsContain(tags map[string]string, value string) bool {
+	for _, tag := range tags {
+		if strings.Contains(tag, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// txHasCoin reports whether tags of transaction refer to the coin
+func txHasCoin(tags map[string]string, coin types.CoinID) bool {
+	for _, key := range []string{"tx.coin_id", "tx.coin_to_buy", "tx.coin_to_sell", "tx.old_coin_id"} {
+		if value, ok := tags[key]; ok && value == coin.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// eventCoin returns coin of event, false if event has no coin
+func eventCoin(event eventsdb.Event) (types.CoinID, bool) {
+	switch e := event.(type) {
+	case *eventsdb.RewardEvent:
+		return types.GetBaseCoinID(), true
+	case *eventsdb.SlashEvent:
+		return types.CoinID(e.Coin), true
+	case *eventsdb.UnbondEvent:
+		return types.CoinID(e.Coin), true
+	case *eventsdb.StakeKickEvent:
+		return types.CoinID(e.Coin), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/core/rewards"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SupplyResponse is network-wide supply of base coin and staking statistics
+type SupplyResponse struct {
+	Height string `json:"height"`
+
+	// Emission is amount of base coin emitted by block rewards
+	Emission    string `json:"emission"`
+	BlockReward string `json:"block_reward"`
+	// TotalSupply is amount of base coin in existence, excluding total slashed
+	TotalSupply  string `json:"total_supply"`
+	TotalSlashed string `json:"total_slashed"`
+	// Reserves is amount of base coin in reserves of coins
+	Reserves string `json:"reserves"`
+	// AccumRewards is amount of base coin accumulated by validators and not paid yet
+	AccumRewards string `json:"accum_rewards"`
+
+	// TotalStake is sum of bip values of candidates' stakes
+	TotalStake      string `json:"total_stake"`
+	ValidatorsStake string `json:"validators_stake"`
+
+	Staked   []*CoinValue `json:"staked"`
+	Frozen   []*CoinValue `json:"frozen"`
+	Waitlist []*CoinValue `json:"waitlist"`
+}
+
+// CoinValue is a total value of a coin
+type CoinValue struct {
+	Coin  CheckCoin `json:"coin"`
+	Value string    `json:"value"`
+}
+
+// Supply returns emission and supply of base coin, total slashed and totals of staked, frozen and waitlisted coins.
+// Emission, supply and totals of frozen and waitlisted coins are kept by the state since UpgradeBlock2, other totals
+// are calculated at the given height.
+func (s *Service) Supply(ctx context.Context, height uint64) (*SupplyResponse, error) {
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if height != 0 {
+		cState.Lock()
+		cState.Candidates().LoadCandidates()
+		cState.Candidates().LoadStakes()
+		cState.Validators().LoadValidators()
+		cState.Unlock()
+	} else {
+		height = s.blockchain.Height()
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	if !cState.App().IsSupplyTracked() {
+		return nil, status.Errorf(codes.NotFound, "supply of base coin is not tracked before block %d", upgrades.UpgradeBlock2)
+	}
+
+	response := &SupplyResponse{
+		Height:       strconv.FormatUint(height, 10),
+		Emission:     cState.App().GetEmission().String(),
+		BlockReward:  rewards.GetRewardForBlock(height + 1).String(),
+		TotalSupply:  big.NewInt(0).Sub(cState.App().GetSupply(), cState.App().GetTotalSlashed()).String(),
+		TotalSlashed: cState.App().GetTotalSlashed().String(),
+	}
+
+	reserves := big.NewInt(0)
+	for id := types.CoinID(1); id <= types.CoinID(cState.App().GetCoinsCount()); id++ {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+
+		coin := cState.Coins().GetCoin(id)
+		if coin == nil || coin.IsToken() {
+			continue
+		}
+
+		reserves.Add(reserves, coin.Reserve())
+	}
+	response.Reserves = reserves.String()
+
+	accumRewards, validatorsStake := big.NewInt(0), big.NewInt(0)
+	for _, validator := range cState.Validators().GetValidators() {
+		accumRewards.Add(accumRewards, validator.GetAccumReward())
+		validatorsStake.Add(validatorsStake, validator.GetTotalBipStake())
+	}
+	response.AccumRewards = accumRewards.String()
+	response.ValidatorsStake = validatorsStake.String()
+
+	totalStake := big.NewInt(0)
+	for _, candidate := range cState.Candidates().GetCandidates() {
+		totalStake.Add(totalStake, cState.Candidates().GetTotalStake(candidate.PubKey))
+	}
+	response.TotalStake = totalStake.String()
+
+	response.Staked = coinValues(cState, cState.Candidates().GetStakedCoins())
+	response.Frozen = coinValues(cState, cState.App().GetFrozenTotals())
+	response.Waitlist = coinValues(cState, cState.App().GetWaitlistTotals())
+
+	return response, nil
+}
+
+// coinValues returns totals of coins ordered by coin id
+func coinValues(cState *state.CheckState, values map[types.CoinID]*big.Int) []*CoinValue {
+	ids := make([]types.CoinID, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	result := make([]*CoinValue, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, &CoinValue{
+			Coin:  checkCoin(cState, id),
+			Value: values[id].String(),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TraceTransactionResponse is a result of committed transaction re-executed at its height with state reads and writes
+// and formula calculations it made
+type TraceTransactionResponse struct {
+	Hash    string                  `json:"hash"`
+	Height  uint64                  `json:"height"`
+	Index   uint64                  `json:"index"`
+	Code    uint32                  `json:"code"`
+	Log     string                  `json:"log"`
+	GasUsed int64                   `json:"gas_used"`
+	Steps   []transaction.TraceStep `json:"steps"`
+}
+
+// TraceTransaction re-executes committed transaction against state of the previous height and returns its trace.
+func (s *Service) TraceTransaction(ctx context.Context, hash string) (*TraceTransactionResponse, error) {
+	if len(hash) < 3 {
+		return nil, status.Error(codes.InvalidArgument, "invalid hash")
+	}
+	decodeString, err := hex.DecodeString(hash[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tx, err := s.client.Tx(decodeString, false)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	block, err := s.client.Block(&tx.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Block not found")
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	trace := transaction.NewTrace()
+	response, err := s.blockchain.TraceTx(uint64(tx.Height), block.Block.Txs, int(tx.Index), trace)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &TraceTransactionResponse{
+		Hash:    "Mt" + strings.ToLower(hex.EncodeToString(tx.Tx.Hash())),
+		Height:  uint64(tx.Height),
+		Index:   uint64(tx.Index),
+		Code:    response.Code,
+		Log:     response.Log,
+		GasUsed: response.GasUsed,
+		Steps:   trace.Steps,
+	}, nil
+}
@@ -74,17 +74,18 @@ func Run(srv *service.Service, addrGRPC, addrAPI string, logger log.Logger) erro
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	marshaler := &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			UseProtoNames:   true,
+			EmitUnpopulated: true,
+		},
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			DiscardUnknown: true,
+		},
+	}
 	gwmux := runtime.NewServeMux(
 		runtime.WithErrorHandler(httpError),
-		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-			MarshalOptions: protojson.MarshalOptions{
-				UseProtoNames:   true,
-				EmitUnpopulated: true,
-			},
-			UnmarshalOptions: protojson.UnmarshalOptions{
-				DiscardUnknown: true,
-			},
-		}),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, marshaler),
 	)
 	opts := []grpc.DialOption{
 		grpc.WithInsecure(),
@@ -94,6 +95,10 @@ func Run(srv *service.Service, addrGRPC, addrAPI string, logger log.Logger) erro
 	if err != nil {
 		return err
 	}
+	err = registerExtensions(gwmux, marshaler, srv)
+	if err != nil {
+		return err
+	}
 
 	mux := http.NewServeMux()
 	openapi := "/v2/openapi-ui/"
@@ -161,6 +161,7 @@ func NewCLI(socketPath string) (interface {
 				&cli.IntFlag{Name: "from", Aliases: []string{"f"}, Required: true},
 				&cli.IntFlag{Name: "to", Aliases: []string{"t"}, Required: true},
 				&cli.IntFlag{Name: "batch", Aliases: []string{"b"}, Required: false, Value: 250, Usage: "the number of blocks to delete in one operation"},
+				&cli.BoolFlag{Name: "detach", Aliases: []string{"d"}, Required: false, Usage: "exit after pruning is started, it continues in background"},
 			},
 			Action: pruneBlocksCMD(client),
 		},
@@ -455,6 +456,12 @@ func pruneBlocksCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
 				fmt.Println("OK", time.Since(now).String())
 				return nil
 			case recv := <-recvCh:
+				if c.Bool("detach") {
+					_ = stream.CloseSend()
+					fmt.Printf("pruning of %d blocks continues in background\n", recv.Total)
+					return nil
+				}
+
 				var percent int64
 				if recv.Total != 0 {
 					percent = int64(float64(recv.Current) / float64(recv.Total) * 100.0)
@@ -255,47 +255,42 @@ func (m *managerServer) AvailableVersions(context.Context, *empty.Empty) (*pb.Av
 	return &pb.AvailableVersionsResponse{Heights: heights}, nil
 }
 
+// PruneBlocks starts deleting states in background and streams its progress. Pruning is not stopped if stream is closed.
 func (m *managerServer) PruneBlocks(req *pb.PruneBlocksRequest, stream pb.ManagerService_PruneBlocksServer) error {
-	total := req.ToHeight - req.FromHeight
+	if req.Batch < 1 || req.FromHeight < 1 || req.ToHeight <= req.FromHeight {
+		return status.Error(codes.InvalidArgument, "from should be positive and less than to, batch should be positive")
+	}
 
-	last := make(chan struct{})
-	from := req.FromHeight
-	for i := req.FromHeight + req.Batch; i < req.ToHeight+req.Batch; i += req.Batch {
-		if i >= req.ToHeight {
-			close(last)
-		}
+	task := m.blockchain.PruneStateVersions(req.FromHeight, req.ToHeight, req.Batch)
+	if err := stream.Send(&pb.PruneBlocksResponse{Total: task.Total(), Current: task.Current()}); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
 		select {
 		case <-stream.Context().Done():
 			return status.FromContextError(stream.Context().Err()).Err()
-		case <-last:
-			err := m.blockchain.DeleteStateVersions(from, req.ToHeight)
-			if err != nil {
-				return status.Error(codes.Aborted, err.Error())
-			}
-			if err := stream.Send(&pb.PruneBlocksResponse{
-				Total:   total,
-				Current: total,
-			}); err != nil {
-				return err
-			}
-			return nil
-		default:
-			err := m.blockchain.DeleteStateVersions(from, i)
-			if err != nil {
+		case <-task.Done():
+			if err := task.Err(); err != nil {
 				return status.Error(codes.Aborted, err.Error())
 			}
+
+			return stream.Send(&pb.PruneBlocksResponse{
+				Total:   task.Total(),
+				Current: task.Total(),
+			})
+		case <-ticker.C:
 			if err := stream.Send(&pb.PruneBlocksResponse{
-				Total:   total,
-				Current: i - req.FromHeight,
+				Total:   task.Total(),
+				Current: task.Current(),
 			}); err != nil {
 				return err
 			}
-			from = i
-			runtime.Gosched()
 		}
 	}
-
-	return nil
 }
 
 func (m *managerServer) DealPeer(_ context.Context, req *pb.DealPeerRequest) (*empty.Empty, error) {
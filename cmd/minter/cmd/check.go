@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/core/check"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"math/big"
+)
+
+var (
+	CheckCommand = &cobra.Command{
+		Use:              "check",
+		Short:            "Issue checks offline",
+		PersistentPreRun: offlinePreRun,
+	}
+
+	CheckIssueCommand = &cobra.Command{
+		Use:   "issue",
+		Short: "Issue check signed with a key from encrypted or hex-encoded keystore file and locked by passphrase",
+		Args:  cobra.NoArgs,
+		RunE:  checkIssue,
+	}
+)
+
+func checkIssue(cmd *cobra.Command, args []string) error {
+	keyFile, _ := cmd.Flags().GetString("key")
+	if keyFile == "" {
+		return errors.New("keystore file is not set")
+	}
+
+	valueFlag, _ := cmd.Flags().GetString("value")
+	value, ok := big.NewInt(0).SetString(valueFlag, 10)
+	if !ok || value.Sign() != 1 {
+		return fmt.Errorf("invalid value %q", valueFlag)
+	}
+
+	nonce, _ := cmd.Flags().GetString("nonce")
+	dueBlock, _ := cmd.Flags().GetUint64("due-block")
+	coin, _ := cmd.Flags().GetUint32("coin")
+	gasCoin, _ := cmd.Flags().GetUint32("gas-coin")
+
+	chainID := types.CurrentChainID
+	if id, _ := cmd.Flags().GetUint8("chain-id"); id != 0 {
+		chainID = types.ChainID(id)
+	}
+
+	key, err := loadKey(cmd, keyFile)
+	if err != nil {
+		return fmt.Errorf("cannot load key from %s: %s", keyFile, err)
+	}
+
+	passphrase, err := readSecret(cmd, "passphrase-file", "Check passphrase: ")
+	if err != nil {
+		return err
+	}
+
+	c := &check.Check{
+		Nonce:    []byte(nonce),
+		ChainID:  chainID,
+		DueBlock: dueBlock,
+		Coin:     types.CoinID(coin),
+		Value:    value,
+		GasCoin:  types.CoinID(gasCoin),
+	}
+
+	// lock is a signature of check by key derived from passphrase, recipient proves knowledge of it the same way
+	passphraseHash := sha256.Sum256([]byte(passphrase))
+	passphraseKey, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		return err
+	}
+
+	lock, err := crypto.Sign(c.HashWithoutLock().Bytes(), passphraseKey)
+	if err != nil {
+		return err
+	}
+	c.Lock = big.NewInt(0).SetBytes(lock)
+
+	if err := c.Sign(key); err != nil {
+		return err
+	}
+
+	raw, err := rlp.EncodeToBytes(c)
+	if err != nil {
+		return err
+	}
+
+	encoded := fmt.Sprintf("Mc%x", raw)
+
+	out, _ := cmd.Flags().GetString("out")
+	if out == "" {
+		fmt.Println(encoded)
+		return nil
+	}
+
+	return ioutil.WriteFile(out, []byte(encoded+"\n"), 0644)
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/crypto/keystore"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+var (
+	KeysCommand = &cobra.Command{
+		Use:              "keys",
+		Short:            "Manage keys in encrypted keystore files",
+		PersistentPreRun: offlinePreRun,
+	}
+
+	KeysNewCommand = &cobra.Command{
+		Use:   "new <keystore file>",
+		Short: "Generate BIP-39 mnemonic and store the key derived from it",
+		Args:  cobra.ExactArgs(1),
+		RunE:  keysNew,
+	}
+
+	KeysRecoverCommand = &cobra.Command{
+		Use:   "recover <keystore file>",
+		Short: "Recover key from BIP-39 mnemonic and store it",
+		Args:  cobra.ExactArgs(1),
+		RunE:  keysRecover,
+	}
+
+	KeysImportCommand = &cobra.Command{
+		Use:   "import <private key file> <keystore file>",
+		Short: "Encrypt hex-encoded private key and store it",
+		Args:  cobra.ExactArgs(2),
+		RunE:  keysImport,
+	}
+
+	KeysShowCommand = &cobra.Command{
+		Use:   "show <keystore file>",
+		Short: "Show address of stored key",
+		Args:  cobra.ExactArgs(1),
+		RunE:  keysShow,
+	}
+)
+
+// stdin is shared by all reads, so buffered lines are not lost between them
+var stdin = bufio.NewReader(os.Stdin)
+
+// offlinePreRun replaces config reading of RootCmd for commands which don't need node
+func offlinePreRun(cmd *cobra.Command, args []string) {
+	isTestnet, _ := cmd.Flags().GetBool("testnet")
+	if isTestnet {
+		types.CurrentChainID = types.ChainTestnet
+	}
+}
+
+func keysNew(cmd *cobra.Command, args []string) error {
+	words, _ := cmd.Flags().GetInt("words")
+	entropy := keystore.MnemonicEntropy12Words
+	switch words {
+	case 12:
+	case 24:
+		entropy = keystore.MnemonicEntropy24Words
+	default:
+		return fmt.Errorf("mnemonic should have 12 or 24 words, not %d", words)
+	}
+
+	mnemonic, err := keystore.NewMnemonic(entropy)
+	if err != nil {
+		return err
+	}
+
+	key, err := keyFromMnemonic(cmd, mnemonic)
+	if err != nil {
+		return err
+	}
+
+	if err := storeKey(cmd, args[0], key); err != nil {
+		return err
+	}
+
+	fmt.Printf("Address: %s\n", crypto.PubkeyToAddress(key.PublicKey).String())
+	fmt.Printf("Mnemonic: %s\n", mnemonic)
+	fmt.Println("Write down the mnemonic and keep it safe, it is the only way to recover the key.")
+	return nil
+}
+
+func keysRecover(cmd *cobra.Command, args []string) error {
+	mnemonic, err := readSecret(cmd, "mnemonic-file", "Mnemonic: ")
+	if err != nil {
+		return err
+	}
+
+	key, err := keyFromMnemonic(cmd, mnemonic)
+	if err != nil {
+		return err
+	}
+
+	if err := storeKey(cmd, args[0], key); err != nil {
+		return err
+	}
+
+	fmt.Printf("Address: %s\n", crypto.PubkeyToAddress(key.PublicKey).String())
+	return nil
+}
+
+func keysImport(cmd *cobra.Command, args []string) error {
+	key, err := crypto.LoadECDSA(args[0])
+	if err != nil {
+		return fmt.Errorf("cannot load key from %s: %s", args[0], err)
+	}
+
+	if err := storeKey(cmd, args[1], key); err != nil {
+		return err
+	}
+
+	fmt.Printf("Address: %s\n", crypto.PubkeyToAddress(key.PublicKey).String())
+	return nil
+}
+
+func keysShow(cmd *cobra.Command, args []string) error {
+	keyJSON, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	address, err := keystore.Address(keyJSON)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(address.String())
+	return nil
+}
+
+func keyFromMnemonic(cmd *cobra.Command, mnemonic string) (*ecdsa.PrivateKey, error) {
+	path, _ := cmd.Flags().GetString("path")
+	if index, _ := cmd.Flags().GetUint32("index"); index != 0 {
+		path = keystore.AccountPath(index)
+	}
+
+	return keystore.KeyFromMnemonic(mnemonic, "", path)
+}
+
+// storeKey encrypts key with password, which is asked twice if it is not set by --password-file flag
+func storeKey(cmd *cobra.Command, file string, key *ecdsa.PrivateKey) error {
+	if _, err := os.Stat(file); err == nil {
+		return fmt.Errorf("file %s already exists", file)
+	}
+
+	password, err := readSecret(cmd, "password-file", "Password: ")
+	if err != nil {
+		return err
+	}
+
+	if passwordFile, _ := cmd.Flags().GetString("password-file"); passwordFile == "" && terminal.IsTerminal(int(os.Stdin.Fd())) {
+		repeated, err := readSecret(cmd, "password-file", "Repeat password: ")
+		if err != nil {
+			return err
+		}
+		if repeated != password {
+			return errors.New("passwords do not match")
+		}
+	}
+
+	scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+	if light, _ := cmd.Flags().GetBool("light"); light {
+		scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+	}
+
+	return keystore.StoreKey(file, keystore.NewKey(key), password, scryptN, scryptP)
+}
+
+// loadKey loads private key from keystore file, encrypted or hex-encoded one
+func loadKey(cmd *cobra.Command, file string) (*ecdsa.PrivateKey, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if !keystore.IsEncrypted(content) {
+		return crypto.LoadECDSA(file)
+	}
+
+	password, err := readSecret(cmd, "password-file", "Password: ")
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(content, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.PrivateKey, nil
+}
+
+// readSecret reads the first line of file set by flag, otherwise prompts for it on terminal or reads a line from stdin
+func readSecret(cmd *cobra.Command, flag string, prompt string) (string, error) {
+	if file, _ := cmd.Flags().GetString(flag); file != "" {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimRight(strings.SplitN(string(content), "\n", 2)[0], "\r"), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if terminal.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, prompt)
+		secret, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(secret), err
+	}
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
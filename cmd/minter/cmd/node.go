@@ -140,24 +140,31 @@ func registerEvidenceMessages(cdc *amino.Codec) {
 }
 
 func runAPI(logger tmLog.Logger, app *minter.Blockchain, client *rpc.Local, node *tmNode.Node) {
+	cdc := newAPICodec()
+	go runAPIv2(cfg, cdc, logger, app, client, node)
+	go apiV1.RunAPI(cdc, app, client, cfg, logger)
+}
+
+func newAPICodec() *amino.Codec {
 	cdc := amino.NewCodec()
 	registerCryptoAmino(cdc)
 	eventsdb.RegisterAminoEvents(cdc)
 	registerEvidenceMessages(cdc)
-	go func(srv *serviceApi.Service) {
-		grpcURL, err := url.Parse(cfg.GRPCListenAddress)
-		if err != nil {
-			logger.Error("Failed to parse gRPC address", err)
-		}
-		apiV2url, err := url.Parse(cfg.APIv2ListenAddress)
-		if err != nil {
-			logger.Error("Failed to parse API v2 address", err)
-		}
-		logger.Error("Failed to start Api V2 in both gRPC and RESTful",
-			apiV2.Run(srv, grpcURL.Host, apiV2url.Host, logger.With("module", "rpc")))
-	}(serviceApi.NewService(cdc, app, client, node, cfg, version.Version))
+	return cdc
+}
 
-	go apiV1.RunAPI(cdc, app, client, cfg, logger)
+// runAPIv2 serves gRPC and API v2 of the node with given config. API v1 is not served here, because it is bound to a single node.
+func runAPIv2(cfg *config.Config, cdc *amino.Codec, logger tmLog.Logger, app *minter.Blockchain, client *rpc.Local, node *tmNode.Node) {
+	grpcURL, err := url.Parse(cfg.GRPCListenAddress)
+	if err != nil {
+		logger.Error("Failed to parse gRPC address", err)
+	}
+	apiV2url, err := url.Parse(cfg.APIv2ListenAddress)
+	if err != nil {
+		logger.Error("Failed to parse API v2 address", err)
+	}
+	logger.Error("Failed to start Api V2 in both gRPC and RESTful",
+		apiV2.Run(serviceApi.NewService(cdc, app, client, node, cfg, version.Version), grpcURL.Host, apiV2url.Host, logger.With("module", "rpc")))
 }
 
 func enablePprof(cmd *cobra.Command, logger tmLog.Logger) error {
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/config"
+	"github.com/MinterTeam/minter-go-node/core/minter"
+	"github.com/spf13/cobra"
+	tmNode "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/store"
+	"time"
+)
+
+// ReplayCommand re-executes blocks stored by Tendermint to rebuild data derived from them
+var ReplayCommand = &cobra.Command{
+	Use:   "replay",
+	Short: "Re-execute stored blocks and rebuild events and coins index",
+	Long: `Re-execute blocks stored by Tendermint against state at height --from minus one, rebuilding events and coins
+index of replayed blocks. App hash of every block is verified, replay stops at the first diverged block and prints
+difference between committed and replayed states. Committed states are not overwritten. The node has to be stopped.`,
+	RunE: replay,
+}
+
+func replay(cmd *cobra.Command, args []string) error {
+	from, err := cmd.Flags().GetUint64("from")
+	if err != nil {
+		return err
+	}
+
+	to, err := cmd.Flags().GetUint64("to")
+	if err != nil {
+		return err
+	}
+
+	tmConfig := config.GetTmConfig(cfg)
+
+	blockStoreDB, err := tmNode.DefaultDBProvider(&tmNode.DBContext{ID: "blockstore", Config: tmConfig})
+	if err != nil {
+		return err
+	}
+	defer blockStoreDB.Close()
+
+	stateDB, err := tmNode.DefaultDBProvider(&tmNode.DBContext{ID: "state", Config: tmConfig})
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+
+	app := minter.NewMinterBlockchain(cfg)
+	defer app.Stop()
+
+	if to == 0 {
+		to = app.LastCommittedHeight()
+	}
+
+	fmt.Printf("Replaying blocks %d-%d...\n", from, to)
+
+	start := time.Now()
+	err = app.Replay(minter.NewReplaySource(store.NewBlockStore(blockStoreDB), stateDB), from, to, func(height uint64) {
+		if height%1000 == 0 {
+			fmt.Printf("Block %d is replayed\n", height)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Blocks %d-%d are replayed. Took %s\n", from, to, time.Since(start))
+
+	return nil
+}
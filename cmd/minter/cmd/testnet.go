@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/config"
+	"github.com/MinterTeam/minter-go-node/core/minter"
+	candidatesState "github.com/MinterTeam/minter-go-node/core/state/candidates"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/crypto/keystore"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+	tmOS "github.com/tendermint/tendermint/libs/os"
+	tmNode "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+	rpc "github.com/tendermint/tendermint/rpc/client/local"
+	tmTypes "github.com/tendermint/tendermint/types"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	TestnetCommand = &cobra.Command{
+		Use:              "testnet",
+		Short:            "Generate and run local multi-validator devnet",
+		PersistentPreRun: offlinePreRun,
+	}
+
+	TestnetInitCommand = &cobra.Command{
+		Use:   "init",
+		Short: "Generate keys, shared genesis and configs of devnet nodes with peers wired together",
+		Args:  cobra.NoArgs,
+		RunE:  testnetInit,
+	}
+
+	TestnetStartCommand = &cobra.Command{
+		Use:   "start",
+		Short: "Run all nodes of devnet generated by init in one process",
+		Args:  cobra.NoArgs,
+		RunE:  testnetStart,
+	}
+)
+
+// Offsets of ports of a devnet node from its base port
+const (
+	p2pPortOffset = iota
+	rpcPortOffset
+	apiPortOffset
+	grpcPortOffset
+	apiV2PortOffset
+
+	portsPerNode = 10
+)
+
+func testnetInit(cmd *cobra.Command, args []string) error {
+	validators, _ := cmd.Flags().GetInt("validators")
+	accounts, _ := cmd.Flags().GetInt("accounts")
+	out, _ := cmd.Flags().GetString("out")
+	chainID, _ := cmd.Flags().GetString("chain-id")
+	host, _ := cmd.Flags().GetString("host")
+	basePort, _ := cmd.Flags().GetInt("base-port")
+	balance, _ := cmd.Flags().GetInt64("balance")
+	stake, _ := cmd.Flags().GetInt64("stake")
+
+	if validators < 1 {
+		return fmt.Errorf("there should be at least one validator")
+	}
+	if accounts < validators {
+		accounts = validators
+	}
+
+	if tmOS.FileExists(out) {
+		return fmt.Errorf("%s already exists", out)
+	}
+
+	// funded accounts are derived from one mnemonic, the first ones own candidates of validators
+	mnemonic, err := keystore.NewMnemonic(keystore.MnemonicEntropy12Words)
+	if err != nil {
+		return err
+	}
+
+	if err := tmOS.EnsureDir(filepath.Join(out, "keys"), 0700); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(out, "mnemonic.txt"), []byte(mnemonic+"\n"), 0600); err != nil {
+		return err
+	}
+
+	appState := types.AppState{TotalSlashed: "0"}
+	owners := make([]types.Address, 0, accounts)
+	for i := 0; i < accounts; i++ {
+		key, err := keystore.KeyFromMnemonic(mnemonic, "", keystore.AccountPath(uint32(i)))
+		if err != nil {
+			return err
+		}
+
+		if err := crypto.SaveECDSA(filepath.Join(out, "keys", fmt.Sprintf("account%d.key", i)), key); err != nil {
+			return err
+		}
+
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		owners = append(owners, address)
+		appState.Accounts = append(appState.Accounts, types.Account{
+			Address: address,
+			Balance: []types.Balance{
+				{
+					Coin:  uint64(types.GetBaseCoinID()),
+					Value: helpers.BipToPip(big.NewInt(balance)).String(),
+				},
+			},
+		})
+	}
+
+	stakeValue := helpers.BipToPip(big.NewInt(stake)).String()
+	peers := make([]string, 0, validators)
+	for i := 0; i < validators; i++ {
+		nodeCfg := devnetNodeConfig(filepath.Join(out, fmt.Sprintf("node%d", i)), i, host, basePort)
+		if err := tmOS.EnsureDir(filepath.Join(nodeCfg.RootDir, "config"), 0700); err != nil {
+			return err
+		}
+
+		nodeKey, err := p2p.LoadOrGenNodeKey(nodeCfg.NodeKeyFile())
+		if err != nil {
+			return err
+		}
+		peers = append(peers, p2p.IDAddressString(nodeKey.ID(), fmt.Sprintf("%s:%d", host, basePort+i*portsPerNode+p2pPortOffset)))
+
+		pv := privval.GenFilePV(nodeCfg.PrivValidatorKeyFile(), nodeCfg.PrivValidatorStateFile())
+		pv.Save()
+
+		var pubKey types.Pubkey
+		copy(pubKey[:], pv.Key.PubKey.Bytes()[5:])
+
+		appState.Validators = append(appState.Validators, types.Validator{
+			TotalBipStake: stakeValue,
+			PubKey:        pubKey,
+			AccumReward:   "0",
+			AbsentTimes:   types.NewBitArray(24),
+		})
+		appState.Candidates = append(appState.Candidates, types.Candidate{
+			ID:             uint64(i) + 1,
+			RewardAddress:  owners[i],
+			OwnerAddress:   owners[i],
+			ControlAddress: owners[i],
+			TotalBipStake:  stakeValue,
+			PubKey:         pubKey,
+			Commission:     10,
+			Stakes: []types.Stake{
+				{
+					Owner:    owners[i],
+					Coin:     uint64(types.GetBaseCoinID()),
+					Value:    stakeValue,
+					BipValue: stakeValue,
+				},
+			},
+			Status: candidatesState.CandidateStatusOnline,
+		})
+	}
+
+	if err := appState.Verify(); err != nil {
+		return err
+	}
+
+	appStateJSON, err := amino.MarshalJSON(appState)
+	if err != nil {
+		return err
+	}
+
+	appHash := [32]byte{}
+	genesis := tmTypes.GenesisDoc{
+		GenesisTime: time.Now(),
+		ChainID:     chainID,
+		ConsensusParams: &tmTypes.ConsensusParams{
+			Block: tmTypes.BlockParams{
+				MaxBytes:   blockMaxBytes,
+				MaxGas:     blockMaxGas,
+				TimeIotaMs: blockTimeIotaMs,
+			},
+			Evidence: tmTypes.EvidenceParams{
+				MaxAgeNumBlocks: evidenceMaxAgeNumBlocks,
+				MaxAgeDuration:  evidenceMaxAgeDuration,
+			},
+			Validator: tmTypes.ValidatorParams{
+				PubKeyTypes: []string{
+					tmTypes.ABCIPubKeyTypeEd25519,
+				},
+			},
+		},
+		AppHash:  appHash[:],
+		AppState: json.RawMessage(appStateJSON),
+	}
+	if err := genesis.ValidateAndComplete(); err != nil {
+		return err
+	}
+
+	for i := 0; i < validators; i++ {
+		nodeCfg := devnetNodeConfig(filepath.Join(out, fmt.Sprintf("node%d", i)), i, host, basePort)
+
+		var nodePeers []string
+		for j, peer := range peers {
+			if j != i {
+				nodePeers = append(nodePeers, peer)
+			}
+		}
+		nodeCfg.P2P.PersistentPeers = strings.Join(nodePeers, ",")
+
+		if err := genesis.SaveAs(nodeCfg.GenesisFile()); err != nil {
+			return err
+		}
+		config.WriteConfigFile(filepath.Join(nodeCfg.RootDir, "config", "config.toml"), nodeCfg)
+
+		fmt.Printf("node%d: p2p %s, API v2 %s\n", i, peers[i], nodeCfg.APIv2ListenAddress)
+	}
+
+	fmt.Printf("Devnet of %d validators is generated in %s\n", validators, out)
+	fmt.Printf("Keys of %d funded accounts are in %s, candidates are owned by the first %d of them\n", accounts, filepath.Join(out, "keys"), validators)
+	return nil
+}
+
+// devnetNodeConfig returns config of i-th devnet node, which listens on ports starting from basePort+i*portsPerNode
+func devnetNodeConfig(root string, i int, host string, basePort int) *config.Config {
+	nodeCfg := config.DefaultConfig()
+	nodeCfg.SetRoot(root)
+
+	port := basePort + i*portsPerNode
+	nodeCfg.Moniker = fmt.Sprintf("node%d", i)
+	nodeCfg.P2P.ListenAddress = fmt.Sprintf("tcp://%s:%d", host, port+p2pPortOffset)
+	nodeCfg.P2P.Seeds = ""
+	nodeCfg.P2P.AddrBookStrict = false
+	nodeCfg.P2P.AllowDuplicateIP = true
+	nodeCfg.P2P.AddrBook = "config/addrbook.json"
+	nodeCfg.RPC.ListenAddress = fmt.Sprintf("tcp://%s:%d", host, port+rpcPortOffset)
+	nodeCfg.APIListenAddress = fmt.Sprintf("tcp://%s:%d", host, port+apiPortOffset)
+	nodeCfg.GRPCListenAddress = fmt.Sprintf("tcp://%s:%d", host, port+grpcPortOffset)
+	nodeCfg.APIv2ListenAddress = fmt.Sprintf("tcp://%s:%d", host, port+apiV2PortOffset)
+	nodeCfg.Instrumentation.Prometheus = false
+
+	return nodeCfg
+}
+
+func testnetStart(cmd *cobra.Command, args []string) error {
+	out, _ := cmd.Flags().GetString("out")
+	withAPI, _ := cmd.Flags().GetBool("api")
+
+	dirs, err := filepath.Glob(filepath.Join(out, "node*"))
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 {
+		return fmt.Errorf("there are no nodes in %s, generate them by testnet init", out)
+	}
+
+	cdc := newAPICodec()
+	var apps []*minter.Blockchain
+	var nodes []*tmNode.Node
+	defer func() {
+		for _, node := range nodes {
+			_ = node.Stop()
+		}
+		for _, app := range apps {
+			app.Stop()
+		}
+	}()
+
+	for _, dir := range dirs {
+		nodeCfg, err := readNodeConfig(dir)
+		if err != nil {
+			return err
+		}
+
+		logger := log.NewLogger(nodeCfg).With("node", nodeCfg.Moniker)
+		tmConfig := config.GetTmConfig(nodeCfg)
+
+		nodeKey, err := p2p.LoadNodeKey(tmConfig.NodeKeyFile())
+		if err != nil {
+			return err
+		}
+
+		app := minter.NewMinterBlockchain(nodeCfg)
+		apps = append(apps, app)
+
+		node, err := tmNode.NewNode(
+			tmConfig,
+			privval.LoadFilePV(tmConfig.PrivValidatorKeyFile(), tmConfig.PrivValidatorStateFile()),
+			nodeKey,
+			proxy.NewLocalClientCreator(app),
+			tmNode.DefaultGenesisDocProviderFunc(tmConfig),
+			tmNode.DefaultDBProvider,
+			tmNode.DefaultMetricsProvider(tmConfig.Instrumentation),
+			logger.With("module", "tendermint"),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %s", nodeCfg.Moniker, err)
+		}
+
+		if err := node.Start(); err != nil {
+			return fmt.Errorf("failed to start %s: %s", nodeCfg.Moniker, err)
+		}
+		nodes = append(nodes, node)
+		app.SetTmNode(node)
+
+		if withAPI {
+			go runAPIv2(nodeCfg, cdc, logger, app, rpc.New(node), node)
+		}
+
+		logger.Info("Started node", "nodeInfo", node.Switch().NodeInfo())
+	}
+
+	<-cmd.Context().Done()
+	return nil
+}
+
+// readNodeConfig reads config of devnet node in dir
+func readNodeConfig(dir string) (*config.Config, error) {
+	nodeCfg := config.DefaultConfig()
+
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(dir, "config", "config.toml"))
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	if err := v.Unmarshal(nodeCfg); err != nil {
+		return nil, err
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	nodeCfg.SetRoot(root)
+
+	if err := os.MkdirAll(nodeCfg.DataDir(), 0700); err != nil {
+		return nil, err
+	}
+
+	return nodeCfg, nil
+}
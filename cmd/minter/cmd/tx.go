@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/spf13/cobra"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	tmTypes "github.com/tendermint/tendermint/types"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	TxCommand = &cobra.Command{
+		Use:   "tx",
+		Short: "Build, sign, decode and broadcast transactions",
+		Long: `Transactions are built and signed offline, only broadcast requires a running node.
+Transactions are passed between subcommands as files with hex-encoded transaction.`,
+		PersistentPreRun: offlinePreRun,
+	}
+
+	TxBuildCommand = &cobra.Command{
+		Use:     "build",
+		Short:   "Build unsigned transaction",
+		Example: `minter tx build --type 0x01 --nonce 1 --data '{"Coin":0,"To":"Mx...","Value":1000000000000000000}'`,
+		Args:    cobra.NoArgs,
+		RunE:    txBuild,
+	}
+
+	TxSignCommand = &cobra.Command{
+		Use:   "sign <tx file>",
+		Short: "Sign transaction with a key from encrypted or hex-encoded keystore file, signature of multisig transaction is added to the existing ones",
+		Args:  cobra.ExactArgs(1),
+		RunE:  txSign,
+	}
+
+	TxMultisignCommand = &cobra.Command{
+		Use:   "multisign <tx file>...",
+		Short: "Combine signatures of multisig transaction collected in several files",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  txMultisign,
+	}
+
+	TxBroadcastCommand = &cobra.Command{
+		Use:   "broadcast <tx file>",
+		Short: "Send signed transaction to a node via API v2",
+		Args:  cobra.ExactArgs(1),
+		RunE:  txBroadcast,
+	}
+
+	TxDecodeCommand = &cobra.Command{
+		Use:   "decode <tx file or hex>",
+		Short: "Decode transaction to JSON",
+		Args:  cobra.ExactArgs(1),
+		RunE:  txDecode,
+	}
+)
+
+func txBuild(cmd *cobra.Command, args []string) error {
+	txTypeFlag, _ := cmd.Flags().GetString("type")
+	txType, err := strconv.ParseUint(txTypeFlag, 0, 8)
+	if err != nil {
+		return fmt.Errorf("invalid tx type %q: %s", txTypeFlag, err)
+	}
+
+	dataJSON, _ := cmd.Flags().GetString("data")
+	if dataFile, _ := cmd.Flags().GetString("data-file"); dataFile != "" {
+		content, err := ioutil.ReadFile(dataFile)
+		if err != nil {
+			return err
+		}
+		dataJSON = string(content)
+	}
+
+	data, err := transaction.TxDecoder.DecodeDataFromJSON(transaction.TxType(txType), []byte(dataJSON))
+	if err != nil {
+		return fmt.Errorf("invalid tx data: %s", err)
+	}
+
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return err
+	}
+
+	nonce, _ := cmd.Flags().GetUint64("nonce")
+	gasPrice, _ := cmd.Flags().GetUint32("gas-price")
+	gasCoin, _ := cmd.Flags().GetUint32("gas-coin")
+	payload, _ := cmd.Flags().GetString("payload")
+
+	chainID := types.CurrentChainID
+	if id, _ := cmd.Flags().GetUint8("chain-id"); id != 0 {
+		chainID = types.ChainID(id)
+	}
+
+	tx := &transaction.Transaction{
+		Nonce:         nonce,
+		ChainID:       chainID,
+		GasPrice:      gasPrice,
+		GasCoin:       types.CoinID(gasCoin),
+		Type:          transaction.TxType(txType),
+		Data:          encodedData,
+		Payload:       []byte(payload),
+		SignatureType: transaction.SigTypeSingle,
+	}
+	tx.SetDecodedData(data)
+
+	if multisig, _ := cmd.Flags().GetString("multisig"); multisig != "" {
+		address, err := parseAddress(multisig)
+		if err != nil {
+			return err
+		}
+
+		tx.SignatureType = transaction.SigTypeMulti
+		tx.SetMultisigAddress(address)
+	}
+
+	return writeTx(cmd, tx)
+}
+
+func txSign(cmd *cobra.Command, args []string) error {
+	keyFile, _ := cmd.Flags().GetString("key")
+	if keyFile == "" {
+		return errors.New("keystore file is not set")
+	}
+
+	key, err := loadKey(cmd, keyFile)
+	if err != nil {
+		return fmt.Errorf("cannot load key from %s: %s", keyFile, err)
+	}
+
+	tx, _, err := readTx(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Sign(key); err != nil {
+		return err
+	}
+
+	return writeTx(cmd, tx)
+}
+
+func txMultisign(cmd *cobra.Command, args []string) error {
+	var tx *transaction.Transaction
+	var multisig transaction.SignatureMulti
+	signers := map[types.Address]bool{}
+
+	for _, arg := range args {
+		signed, _, err := readTx(arg)
+		if err != nil {
+			return err
+		}
+
+		if signed.SignatureType != transaction.SigTypeMulti {
+			return fmt.Errorf("%s: transaction is not multisig", arg)
+		}
+
+		var signatures transaction.SignatureMulti
+		if err := rlp.DecodeBytes(signed.SignatureData, &signatures); err != nil {
+			return fmt.Errorf("%s: %s", arg, err)
+		}
+
+		if tx == nil {
+			tx = signed
+			multisig.Multisig = signatures.Multisig
+		} else if signed.Hash() != tx.Hash() || signatures.Multisig != multisig.Multisig {
+			return fmt.Errorf("%s: transaction differs from %s", arg, args[0])
+		}
+
+		for _, signature := range signatures.Signatures {
+			signer, err := transaction.RecoverPlain(tx.Hash(), signature.R, signature.S, signature.V)
+			if err != nil {
+				return fmt.Errorf("%s: %s", arg, err)
+			}
+
+			if signers[signer] {
+				continue
+			}
+
+			signers[signer] = true
+			multisig.Signatures = append(multisig.Signatures, signature)
+		}
+	}
+
+	signatureData, err := rlp.EncodeToBytes(multisig)
+	if err != nil {
+		return err
+	}
+	tx.SignatureData = signatureData
+
+	return writeTx(cmd, tx)
+}
+
+func txBroadcast(cmd *cobra.Command, args []string) error {
+	_, raw, err := readTx(args[0])
+	if err != nil {
+		return err
+	}
+
+	node, _ := cmd.Flags().GetString("node")
+	url := fmt.Sprintf("%s/send_transaction/0x%x", strings.TrimSuffix(node, "/"), raw)
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("node responded with %s: %s", resp.Status, body)
+	}
+
+	fmt.Println(string(body))
+	return nil
+}
+
+// txJSON is a decoded transaction. Hash is empty for unsigned transaction.
+type txJSON struct {
+	Hash          string          `json:"hash,omitempty"`
+	ChainID       types.ChainID   `json:"chain_id"`
+	Nonce         uint64          `json:"nonce"`
+	GasPrice      uint32          `json:"gas_price"`
+	GasCoin       types.CoinID    `json:"gas_coin"`
+	Gas           int64           `json:"gas"`
+	Type          string          `json:"type"`
+	Data          json.RawMessage `json:"data"`
+	Payload       string          `json:"payload"`
+	SignatureType string          `json:"signature_type"`
+	From          string          `json:"from,omitempty"`
+	Signers       []string        `json:"signers,omitempty"`
+}
+
+func txDecode(cmd *cobra.Command, args []string) error {
+	tx, raw, err := readTx(args[0])
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(tx.GetDecodedData())
+	if err != nil {
+		return err
+	}
+
+	decoded := txJSON{
+		ChainID:       tx.ChainID,
+		Nonce:         tx.Nonce,
+		GasPrice:      tx.GasPrice,
+		GasCoin:       tx.GasCoin,
+		Gas:           tx.Gas(),
+		Type:          fmt.Sprintf("0x%02X", byte(tx.Type)),
+		Data:          data,
+		Payload:       string(tx.Payload),
+		SignatureType: "single",
+	}
+
+	switch tx.SignatureType {
+	case transaction.SigTypeSingle:
+		if len(tx.SignatureData) != 0 {
+			sender, err := tx.Sender()
+			if err != nil {
+				return err
+			}
+
+			decoded.From = sender.String()
+			decoded.Signers = []string{sender.String()}
+		}
+	case transaction.SigTypeMulti:
+		decoded.SignatureType = "multi"
+
+		var multisig transaction.SignatureMulti
+		if err := rlp.DecodeBytes(tx.SignatureData, &multisig); err != nil {
+			return err
+		}
+
+		decoded.From = multisig.Multisig.String()
+		for _, signature := range multisig.Signatures {
+			signer, err := transaction.RecoverPlain(tx.Hash(), signature.R, signature.S, signature.V)
+			if err != nil {
+				return err
+			}
+			decoded.Signers = append(decoded.Signers, signer.String())
+		}
+	}
+
+	if len(decoded.Signers) != 0 {
+		decoded.Hash = fmt.Sprintf("Mt%x", tmTypes.Tx(raw).Hash())
+	}
+
+	out, err := json.MarshalIndent(decoded, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
+
+// readTx reads hex-encoded transaction from file, or from the argument itself if there is no such file.
+// Signature is decoded only if it is present, so unsigned transactions are read as well.
+func readTx(arg string) (*transaction.Transaction, []byte, error) {
+	content := arg
+	if tmos.FileExists(arg) {
+		file, err := ioutil.ReadFile(arg)
+		if err != nil {
+			return nil, nil, err
+		}
+		content = string(file)
+	}
+
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(strings.TrimPrefix(content, "0x"), "0X")
+
+	raw, err := hex.DecodeString(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: invalid hex: %s", arg, err)
+	}
+
+	tx, err := transaction.TxDecoder.DecodeFromBytesWithoutSig(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", arg, err)
+	}
+
+	if len(tx.SignatureData) != 0 {
+		tx, err = transaction.DecodeSig(tx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %s", arg, err)
+		}
+	}
+
+	return tx, raw, nil
+}
+
+// writeTx writes hex-encoded transaction to the file set by --out flag, or to stdout
+func writeTx(cmd *cobra.Command, tx *transaction.Transaction) error {
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+
+	encoded := fmt.Sprintf("0x%x", raw)
+
+	out, _ := cmd.Flags().GetString("out")
+	if out == "" {
+		fmt.Println(encoded)
+		return nil
+	}
+
+	return ioutil.WriteFile(out, []byte(encoded+"\n"), 0644)
+}
+
+func parseAddress(address string) (types.Address, error) {
+	if !strings.HasPrefix(address, "Mx") || len(address) != 2+types.AddressLength*2 {
+		return types.Address{}, fmt.Errorf("invalid address %q", address)
+	}
+
+	if _, err := hex.DecodeString(address[2:]); err != nil {
+		return types.Address{}, fmt.Errorf("invalid address %q", address)
+	}
+
+	return types.HexToAddress(address), nil
+}
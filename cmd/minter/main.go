@@ -4,6 +4,8 @@ import (
 	"context"
 	"github.com/MinterTeam/minter-go-node/cmd/minter/cmd"
 	"github.com/MinterTeam/minter-go-node/cmd/utils"
+	"github.com/MinterTeam/minter-go-node/crypto/keystore"
+	"github.com/spf13/cobra"
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"os"
@@ -27,6 +29,35 @@ func main() {
 		cmd.VerifyGenesis,
 		cmd.Version,
 		cmd.ExportCommand,
+		cmd.TxCommand,
+		cmd.KeysCommand,
+		cmd.CheckCommand,
+		cmd.TestnetCommand,
+		cmd.ReplayCommand,
+	)
+
+	cmd.TestnetCommand.AddCommand(
+		cmd.TestnetInitCommand,
+		cmd.TestnetStartCommand,
+	)
+
+	cmd.KeysCommand.AddCommand(
+		cmd.KeysNewCommand,
+		cmd.KeysRecoverCommand,
+		cmd.KeysImportCommand,
+		cmd.KeysShowCommand,
+	)
+
+	cmd.CheckCommand.AddCommand(
+		cmd.CheckIssueCommand,
+	)
+
+	cmd.TxCommand.AddCommand(
+		cmd.TxBuildCommand,
+		cmd.TxSignCommand,
+		cmd.TxMultisignCommand,
+		cmd.TxBroadcastCommand,
+		cmd.TxDecodeCommand,
 	)
 
 	rootCmd.PersistentFlags().StringVar(&utils.MinterHome, "home-dir", "", "base dir (default is $HOME/.minter)")
@@ -42,6 +73,59 @@ func main() {
 	cmd.ExportCommand.Flags().String("chain-id", "", "export chain id")
 	cmd.ExportCommand.Flags().Duration("genesis-time", 0, "export height")
 
+	cmd.ReplayCommand.Flags().Uint64("from", 0, "first replayed height, state at the previous height has to be kept")
+	cmd.ReplayCommand.Flags().Uint64("to", 0, "last replayed height, the last committed height is default")
+
+	cmd.TxBuildCommand.Flags().String("type", "", "transaction type, e.g. 0x01 for send")
+	cmd.TxBuildCommand.Flags().String("data", "{}", "transaction data in JSON, values are in pip")
+	cmd.TxBuildCommand.Flags().String("data-file", "", "path to file with transaction data in JSON, overrides --data")
+	cmd.TxBuildCommand.Flags().Uint64("nonce", 0, "nonce of sender")
+	cmd.TxBuildCommand.Flags().Uint32("gas-price", 1, "gas price")
+	cmd.TxBuildCommand.Flags().Uint32("gas-coin", 0, "id of coin to pay commission")
+	cmd.TxBuildCommand.Flags().String("payload", "", "transaction payload")
+	cmd.TxBuildCommand.Flags().Uint8("chain-id", 0, "chain id, 0 for mainnet or testnet depending on --testnet flag")
+	cmd.TxBuildCommand.Flags().String("multisig", "", "address of multisig sending the transaction")
+	cmd.TxSignCommand.Flags().String("key", "", "path to encrypted keystore file or file with hex-encoded private key")
+	cmd.TxSignCommand.Flags().String("password-file", "", "path to file with password of keystore, it is prompted if not set")
+	cmd.TxBroadcastCommand.Flags().String("node", "http://localhost:8843/v2", "API v2 address of the node")
+	for _, c := range []*cobra.Command{cmd.TxBuildCommand, cmd.TxSignCommand, cmd.TxMultisignCommand} {
+		c.Flags().String("out", "", "path to file to write transaction to, stdout is default")
+	}
+
+	cmd.KeysNewCommand.Flags().Int("words", 12, "amount of words of mnemonic, 12 or 24")
+	cmd.KeysRecoverCommand.Flags().String("mnemonic-file", "", "path to file with mnemonic, it is prompted if not set")
+	for _, c := range []*cobra.Command{cmd.KeysNewCommand, cmd.KeysRecoverCommand} {
+		c.Flags().String("path", keystore.DefaultDerivationPath, "BIP-44 derivation path")
+		c.Flags().Uint32("index", 0, "index of address, overrides --path with m/44'/60'/0'/0/<index>")
+	}
+	for _, c := range []*cobra.Command{cmd.KeysNewCommand, cmd.KeysRecoverCommand, cmd.KeysImportCommand} {
+		c.Flags().String("password-file", "", "path to file with password to encrypt key, it is prompted if not set")
+		c.Flags().Bool("light", false, "use light scrypt parameters, for tests and devnets only")
+	}
+
+	cmd.CheckIssueCommand.Flags().String("key", "", "path to encrypted keystore file or file with hex-encoded private key of issuer")
+	cmd.CheckIssueCommand.Flags().String("password-file", "", "path to file with password of keystore, it is prompted if not set")
+	cmd.CheckIssueCommand.Flags().String("passphrase-file", "", "path to file with passphrase of check, it is prompted if not set")
+	cmd.CheckIssueCommand.Flags().String("nonce", "", "unique nonce of check")
+	cmd.CheckIssueCommand.Flags().Uint64("due-block", 0, "last block at which check can be redeemed")
+	cmd.CheckIssueCommand.Flags().Uint32("coin", 0, "id of coin of check")
+	cmd.CheckIssueCommand.Flags().String("value", "", "value of check in pip")
+	cmd.CheckIssueCommand.Flags().Uint32("gas-coin", 0, "id of coin to pay commission of redemption")
+	cmd.CheckIssueCommand.Flags().Uint8("chain-id", 0, "chain id, 0 for mainnet or testnet depending on --testnet flag")
+	cmd.CheckIssueCommand.Flags().String("out", "", "path to file to write check to, stdout is default")
+
+	cmd.TestnetInitCommand.Flags().Int("validators", 4, "amount of validators")
+	cmd.TestnetInitCommand.Flags().Int("accounts", 0, "amount of funded accounts, not less than amount of validators")
+	cmd.TestnetInitCommand.Flags().String("chain-id", "minter-devnet", "chain id of genesis")
+	cmd.TestnetInitCommand.Flags().String("host", "127.0.0.1", "host nodes listen on")
+	cmd.TestnetInitCommand.Flags().Int("base-port", 26656, "first port of the first node, every node uses 10 ports")
+	cmd.TestnetInitCommand.Flags().Int64("balance", 1000000, "balance of funded accounts in bip")
+	cmd.TestnetInitCommand.Flags().Int64("stake", 1000000, "stake of validators in bip")
+	cmd.TestnetStartCommand.Flags().Bool("api", true, "serve gRPC and API v2 of every node")
+	for _, c := range []*cobra.Command{cmd.TestnetInitCommand, cmd.TestnetStartCommand} {
+		c.Flags().String("out", "./devnet", "directory of devnet")
+	}
+
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		panic(err)
 	}
@@ -246,6 +246,12 @@ type BaseConfig struct {
 
 	KeepLastStates int64 `mapstructure:"keep_last_states"`
 
+	// Strategy of deleting old states: nothing, everything, window or keep_every
+	StatePruning string `mapstructure:"state_pruning"`
+
+	// Every StateKeepEvery-th state is kept by keep_every pruning
+	StateKeepEvery int64 `mapstructure:"state_keep_every"`
+
 	APISimultaneousRequests int `mapstructure:"api_simultaneous_requests"`
 
 	LogPath string `mapstructure:"log_path"`
@@ -255,6 +261,9 @@ type BaseConfig struct {
 	StateMemAvailable int `mapstructure:"state_mem_available"`
 
 	HaltHeight int `mapstructure:"halt_height"`
+
+	// Index coins and their holders for API v2 Coins and CoinHolders endpoints
+	CoinsIndex bool `mapstructure:"coins_index"`
 }
 
 // DefaultBaseConfig returns a default base configuration for a Tendermint node
@@ -278,6 +287,8 @@ func DefaultBaseConfig() BaseConfig {
 		WSConnectionDuration:    15 * time.Second,
 		ValidatorMode:           false,
 		KeepLastStates:          120,
+		StatePruning:            "window",
+		StateKeepEvery:          0,
 		StateCacheSize:          1000000,
 		StateMemAvailable:       1024,
 		APISimultaneousRequests: 100,
@@ -316,6 +327,11 @@ func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
 }
 
+// DataDir returns the full path to the directory of application databases
+func (cfg BaseConfig) DataDir() string {
+	return rootify(defaultDataDir, cfg.RootDir)
+}
+
 // DefaultLogLevel returns a default log level of "error"
 func DefaultLogLevel() string {
 	return "error"
@@ -87,6 +87,17 @@ validator_mode = {{ .BaseConfig.ValidatorMode }}
 # Sets number of last stated to be saved on disk.
 keep_last_states = {{ .BaseConfig.KeepLastStates }}
 
+# Strategy of deleting old states:
+#   "nothing" keeps all states (archive mode),
+#   "everything" keeps only the last two states,
+#   "window" keeps keep_last_states last states,
+#   "keep_every" keeps every state_keep_every-th state in addition to keep_last_states last states.
+# States at heights of halt blocks are kept by all strategies.
+state_pruning = "{{ .BaseConfig.StatePruning }}"
+
+# Every N-th state is kept by "keep_every" pruning
+state_keep_every = {{ .BaseConfig.StateKeepEvery }}
+
 # State cache size 
 state_cache_size = {{ .BaseConfig.StateCacheSize }}
 
@@ -96,6 +107,10 @@ state_mem_available = {{ .BaseConfig.StateMemAvailable }}
 # Limit for simultaneous requests to API
 api_simultaneous_requests = {{ .BaseConfig.APISimultaneousRequests }}
 
+# Index coins and their holders for API v2 coins and coin holders endpoints.
+# Index is built from current state on start, if it is enabled on a synced node.
+coins_index = {{ .BaseConfig.CoinsIndex }}
+
 # If this node is many blocks behind the tip of the chain, FastSync
 # allows them to catchup quickly by downloading blocks in parallel
 # and verifying their commits
@@ -181,6 +196,9 @@ upnp = {{ .P2P.UPNP }}
 # Set true for strict address routability rules
 addr_book_strict = {{ .P2P.AddrBookStrict }}
 
+# Set true to allow several peers with the same IP, e.g. nodes of local devnet
+allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
+
 # Time to wait before flushing messages out on the connection, in ms
 flush_throttle_timeout = "{{ .P2P.FlushThrottleTimeout }}"
 
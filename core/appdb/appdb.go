@@ -3,7 +3,6 @@ package appdb
 import (
 	"encoding/binary"
 	"errors"
-	"github.com/MinterTeam/minter-go-node/cmd/utils"
 	"github.com/MinterTeam/minter-go-node/config"
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/abci/types"
@@ -184,6 +183,6 @@ func (appDB *AppDB) SetLastBlocksTimeDelta(height uint64, delta int) {
 // NewAppDB creates AppDB instance with given config
 func NewAppDB(cfg *config.Config) *AppDB {
 	return &AppDB{
-		db: db.NewDB(dbName, db.BackendType(cfg.DBBackend), utils.GetMinterHome()+"/data"),
+		db: db.NewDB(dbName, db.BackendType(cfg.DBBackend), cfg.DataDir()),
 	}
 }
@@ -48,23 +48,7 @@ func (check *Check) Sender() (types.Address, error) {
 
 // LockPubKey returns bytes of public key, which is used for proving check's recipient rights
 func (check *Check) LockPubKey() ([]byte, error) {
-	sig := check.Lock.Bytes()
-
-	if len(sig) < 65 {
-		sig = append(make([]byte, 65-len(sig)), sig...)
-	}
-
-	hash := check.HashWithoutLock()
-
-	pub, err := crypto.Ecrecover(hash[:], sig)
-	if err != nil {
-		return nil, err
-	}
-	if len(pub) == 0 || pub[0] != 4 {
-		return nil, errors.New("invalid public key")
-	}
-
-	return pub, nil
+	return lockPubKey(check.Lock, check.HashWithoutLock())
 }
 
 // HashWithoutLock returns a types.Hash to be used in process of signing and checking Lock
@@ -106,9 +90,7 @@ func (check *Check) Sign(prv *ecdsa.PrivateKey) error {
 }
 
 func (check *Check) setSignature(sig []byte) {
-	check.R = new(big.Int).SetBytes(sig[:32])
-	check.S = new(big.Int).SetBytes(sig[32:64])
-	check.V = new(big.Int).SetBytes([]byte{sig[64] + 27})
+	check.R, check.S, check.V = signatureValues(sig)
 }
 
 func (check *Check) String() string {
@@ -133,6 +115,32 @@ func DecodeFromBytes(buf []byte) (*Check, error) {
 	return &check, nil
 }
 
+func lockPubKey(lock *big.Int, hash types.Hash) ([]byte, error) {
+	sig := lock.Bytes()
+
+	if len(sig) < 65 {
+		sig = append(make([]byte, 65-len(sig)), sig...)
+	}
+
+	pub, err := crypto.Ecrecover(hash[:], sig)
+	if err != nil {
+		return nil, err
+	}
+	if len(pub) == 0 || pub[0] != 4 {
+		return nil, errors.New("invalid public key")
+	}
+
+	return pub, nil
+}
+
+func signatureValues(sig []byte) (r, s, v *big.Int) {
+	r = new(big.Int).SetBytes(sig[:32])
+	s = new(big.Int).SetBytes(sig[32:64])
+	v = new(big.Int).SetBytes([]byte{sig[64] + 27})
+
+	return r, s, v
+}
+
 func rlpHash(x interface{}) (h types.Hash) {
 	hw := sha3.NewLegacyKeccak256()
 	err := rlp.Encode(hw, x)
@@ -0,0 +1,118 @@
+package check
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+// partialCheckVersion separates hashes of partial checks from hashes of ordinary ones
+const partialCheckVersion = 2
+
+// PartialCheck is a check which can be redeemed in several parts.
+// Receivers may draw from the check until Value is spent, each redemption is limited by RedemptionLimit.
+// Lock and proof of receiver's rights are the same as in ordinary Check.
+//
+// Value - spending cap of the check.
+// RedemptionLimit - maximum amount of coins for a single redemption.
+type PartialCheck struct {
+	Nonce           []byte
+	ChainID         types.ChainID
+	DueBlock        uint64
+	Coin            types.CoinID
+	Value           *big.Int
+	RedemptionLimit *big.Int
+	GasCoin         types.CoinID
+	Lock            *big.Int
+	V               *big.Int
+	R               *big.Int
+	S               *big.Int
+}
+
+// Sender returns sender's address of a PartialCheck, recovered from signature
+func (check *PartialCheck) Sender() (types.Address, error) {
+	return recoverPlain(check.Hash(), check.R, check.S, check.V)
+}
+
+// LockPubKey returns bytes of public key, which is used for proving check's recipient rights
+func (check *PartialCheck) LockPubKey() ([]byte, error) {
+	return lockPubKey(check.Lock, check.HashWithoutLock())
+}
+
+// HashWithoutLock returns a types.Hash to be used in process of signing and checking Lock
+func (check *PartialCheck) HashWithoutLock() types.Hash {
+	return rlpHash([]interface{}{
+		uint(partialCheckVersion),
+		check.Nonce,
+		check.ChainID,
+		check.DueBlock,
+		check.Coin,
+		check.Value,
+		check.RedemptionLimit,
+		check.GasCoin,
+	})
+}
+
+// Hash returns a types.Hash to be used in process of signing a PartialCheck by sender
+func (check *PartialCheck) Hash() types.Hash {
+	return rlpHash([]interface{}{
+		uint(partialCheckVersion),
+		check.Nonce,
+		check.ChainID,
+		check.DueBlock,
+		check.Coin,
+		check.Value,
+		check.RedemptionLimit,
+		check.GasCoin,
+		check.Lock,
+	})
+}
+
+// Sign signs the check with given private key, returns error
+func (check *PartialCheck) Sign(prv *ecdsa.PrivateKey) error {
+	h := check.Hash()
+	sig, err := crypto.Sign(h[:], prv)
+	if err != nil {
+		return err
+	}
+
+	check.R, check.S, check.V = signatureValues(sig)
+
+	return nil
+}
+
+func (check *PartialCheck) String() string {
+	sender, _ := check.Sender()
+
+	return fmt.Sprintf("Partial check sender: %s nonce: %x, dueBlock: %d, value: %s %s, redemption limit: %s", sender.String(), check.Nonce,
+		check.DueBlock, check.Value.String(), check.Coin.String(), check.RedemptionLimit.String())
+}
+
+// DecodePartialFromBytes decodes partial check from bytes
+func DecodePartialFromBytes(buf []byte) (*PartialCheck, error) {
+	var check PartialCheck
+	err := rlp.Decode(bytes.NewReader(buf), &check)
+	if err != nil {
+		return nil, err
+	}
+
+	if check.S == nil || check.R == nil || check.V == nil {
+		return nil, errors.New("incorrect tx signature")
+	}
+
+	if check.Value == nil || check.RedemptionLimit == nil {
+		return nil, errors.New("incorrect check value")
+	}
+
+	if check.RedemptionLimit.Sign() != 1 || check.RedemptionLimit.Cmp(check.Value) == 1 {
+		return nil, errors.New("incorrect check redemption limit")
+	}
+
+	return &check, nil
+}
@@ -24,6 +24,7 @@ const (
 	CoinReserveUnderflow         uint32 = 116
 	WrongHaltHeight              uint32 = 117
 	HaltAlreadyExists            uint32 = 118
+	CoinHasNotReserve            uint32 = 119
 
 	// coin creation
 	CoinAlreadyExists uint32 = 201
@@ -35,24 +36,28 @@ const (
 	// recreate coin
 	IsNotOwnerOfCoin uint32 = 206
 
+	// mint and burn token
+	CoinIsNotToken uint32 = 207
+
 	// convert
 	CrossConvert              uint32 = 301
 	MaximumValueToSellReached uint32 = 302
 	MinimumValueToBuyReached  uint32 = 303
 
 	// candidate
-	CandidateExists       uint32 = 401
-	WrongCommission       uint32 = 402
-	CandidateNotFound     uint32 = 403
-	StakeNotFound         uint32 = 404
-	InsufficientStake     uint32 = 405
-	IsNotOwnerOfCandidate uint32 = 406
-	IncorrectPubKey       uint32 = 407
-	StakeShouldBePositive uint32 = 408
-	TooLowStake           uint32 = 409
-	PublicKeyInBlockList  uint32 = 410
-	NewPublicKeyIsBad     uint32 = 411
-	InsufficientWaitList  uint32 = 412
+	CandidateExists        uint32 = 401
+	WrongCommission        uint32 = 402
+	CandidateNotFound      uint32 = 403
+	StakeNotFound          uint32 = 404
+	InsufficientStake      uint32 = 405
+	IsNotOwnerOfCandidate  uint32 = 406
+	IncorrectPubKey        uint32 = 407
+	StakeShouldBePositive  uint32 = 408
+	TooLowStake            uint32 = 409
+	PublicKeyInBlockList   uint32 = 410
+	NewPublicKeyIsBad      uint32 = 411
+	InsufficientWaitList   uint32 = 412
+	TooEarlyCommissionEdit uint32 = 413
 
 	// check
 	CheckInvalidLock uint32 = 501
@@ -61,6 +66,9 @@ const (
 	TooHighGasPrice  uint32 = 504
 	WrongGasCoin     uint32 = 505
 	TooLongNonce     uint32 = 506
+	CheckRevoked     uint32 = 507
+	IsNotCheckIssuer uint32 = 508
+	WrongCheckValue  uint32 = 509
 
 	// multisig
 	IncorrectWeights                  uint32 = 601
@@ -72,6 +80,19 @@ const (
 	DifferentCountAddressesAndWeights uint32 = 607
 	IncorrectTotalWeights             uint32 = 608
 	NotEnoughMultisigVotes            uint32 = 609
+
+	// allowance
+	AllowanceNotFound      uint32 = 701
+	AllowanceExpired       uint32 = 702
+	InsufficientAllowance  uint32 = 703
+	WrongAllowancePeriod   uint32 = 704
+	SelfAllowanceForbidden uint32 = 705
+
+	// names
+	InvalidName       uint32 = 801
+	NameAlreadyExists uint32 = 802
+	NameNotExists     uint32 = 803
+	IsNotOwnerOfName  uint32 = 804
 )
 
 type wrongNonce struct {
@@ -237,6 +258,16 @@ func NewCoinReserveUnderflow(delta string, coinReserve string, currentReserve st
 	return &coinReserveUnderflow{Code: strconv.Itoa(int(CoinReserveUnderflow)), Delta: delta, CoinReserve: coinReserve, CurrentReserve: currentReserve, MinCoinReserve: minCoinReserve, CoinSymbol: coinSymbol, CoinId: coinId}
 }
 
+type coinHasNotReserve struct {
+	Code       string `json:"code,omitempty"`
+	CoinSymbol string `json:"coin_symbol,omitempty"`
+	CoinId     string `json:"coin_id,omitempty"`
+}
+
+func NewCoinHasNotReserve(coinSymbol string, coinId string) *coinHasNotReserve {
+	return &coinHasNotReserve{Code: strconv.Itoa(int(CoinHasNotReserve)), CoinSymbol: coinSymbol, CoinId: coinId}
+}
+
 type coinAlreadyExists struct {
 	Code       string `json:"code,omitempty"`
 	CoinSymbol string `json:"coin_symbol,omitempty"`
@@ -468,6 +499,16 @@ func NewIsNotOwnerOfCoin(coinSymbol string, owner *string) *isNotOwnerOfCoin {
 	return &isNotOwnerOfCoin{Code: strconv.Itoa(int(IsNotOwnerOfCoin)), CoinSymbol: coinSymbol, Owner: own}
 }
 
+type coinIsNotToken struct {
+	Code       string `json:"code,omitempty"`
+	CoinSymbol string `json:"coin_symbol,omitempty"`
+	CoinId     string `json:"coin_id,omitempty"`
+}
+
+func NewCoinIsNotToken(coinSymbol string, coinId string) *coinIsNotToken {
+	return &coinIsNotToken{Code: strconv.Itoa(int(CoinIsNotToken)), CoinSymbol: coinSymbol, CoinId: coinId}
+}
+
 type isNotOwnerOfCandidate struct {
 	Code      string `json:"code,omitempty"`
 	Sender    string `json:"sender,omitempty"`
@@ -498,6 +539,124 @@ func NewCheckUsed() *checkUsed {
 	return &checkUsed{Code: strconv.Itoa(int(CheckUsed))}
 }
 
+type checkRevoked struct {
+	Code string `json:"code,omitempty"`
+}
+
+func NewCheckRevoked() *checkRevoked {
+	return &checkRevoked{Code: strconv.Itoa(int(CheckRevoked))}
+}
+
+type isNotCheckIssuer struct {
+	Code   string `json:"code,omitempty"`
+	Sender string `json:"sender,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+}
+
+func NewIsNotCheckIssuer(sender string, issuer string) *isNotCheckIssuer {
+	return &isNotCheckIssuer{Code: strconv.Itoa(int(IsNotCheckIssuer)), Sender: sender, Issuer: issuer}
+}
+
+type wrongCheckValue struct {
+	Code     string `json:"code,omitempty"`
+	Value    string `json:"value,omitempty"`
+	MaxValue string `json:"max_value,omitempty"`
+}
+
+func NewWrongCheckValue(value string, maxValue string) *wrongCheckValue {
+	return &wrongCheckValue{Code: strconv.Itoa(int(WrongCheckValue)), Value: value, MaxValue: maxValue}
+}
+
+type allowanceNotFound struct {
+	Code    string `json:"code,omitempty"`
+	Owner   string `json:"owner,omitempty"`
+	Spender string `json:"spender,omitempty"`
+	CoinID  string `json:"coin_id,omitempty"`
+}
+
+func NewAllowanceNotFound(owner string, spender string, coinID string) *allowanceNotFound {
+	return &allowanceNotFound{Code: strconv.Itoa(int(AllowanceNotFound)), Owner: owner, Spender: spender, CoinID: coinID}
+}
+
+type allowanceExpired struct {
+	Code         string `json:"code,omitempty"`
+	ExpireHeight string `json:"expire_height,omitempty"`
+	CurrentBlock string `json:"current_block,omitempty"`
+}
+
+func NewAllowanceExpired(expireHeight string, currentBlock string) *allowanceExpired {
+	return &allowanceExpired{Code: strconv.Itoa(int(AllowanceExpired)), ExpireHeight: expireHeight, CurrentBlock: currentBlock}
+}
+
+type insufficientAllowance struct {
+	Code      string `json:"code,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Available string `json:"available,omitempty"`
+}
+
+func NewInsufficientAllowance(value string, available string) *insufficientAllowance {
+	return &insufficientAllowance{Code: strconv.Itoa(int(InsufficientAllowance)), Value: value, Available: available}
+}
+
+type wrongAllowancePeriod struct {
+	Code        string `json:"code,omitempty"`
+	Period      string `json:"period,omitempty"`
+	PeriodLimit string `json:"period_limit,omitempty"`
+}
+
+func NewWrongAllowancePeriod(period string, periodLimit string) *wrongAllowancePeriod {
+	return &wrongAllowancePeriod{Code: strconv.Itoa(int(WrongAllowancePeriod)), Period: period, PeriodLimit: periodLimit}
+}
+
+type selfAllowanceForbidden struct {
+	Code    string `json:"code,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+func NewSelfAllowanceForbidden(address string) *selfAllowanceForbidden {
+	return &selfAllowanceForbidden{Code: strconv.Itoa(int(SelfAllowanceForbidden)), Address: address}
+}
+
+type invalidName struct {
+	Code    string `json:"code,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+	Name    string `json:"name,omitempty"`
+}
+
+func NewInvalidName(pattern string, name string) *invalidName {
+	return &invalidName{Code: strconv.Itoa(int(InvalidName)), Pattern: pattern, Name: name}
+}
+
+type nameAlreadyExists struct {
+	Code         string `json:"code,omitempty"`
+	Name         string `json:"name,omitempty"`
+	ExpireHeight string `json:"expire_height,omitempty"`
+}
+
+func NewNameAlreadyExists(name string, expireHeight string) *nameAlreadyExists {
+	return &nameAlreadyExists{Code: strconv.Itoa(int(NameAlreadyExists)), Name: name, ExpireHeight: expireHeight}
+}
+
+type nameNotExists struct {
+	Code string `json:"code,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+func NewNameNotExists(name string) *nameNotExists {
+	return &nameNotExists{Code: strconv.Itoa(int(NameNotExists)), Name: name}
+}
+
+type isNotOwnerOfName struct {
+	Code   string `json:"code,omitempty"`
+	Sender string `json:"sender,omitempty"`
+	Owner  string `json:"owner,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+func NewIsNotOwnerOfName(sender string, owner string, name string) *isNotOwnerOfName {
+	return &isNotOwnerOfName{Code: strconv.Itoa(int(IsNotOwnerOfName)), Sender: sender, Owner: owner, Name: name}
+}
+
 type notEnoughMultisigVotes struct {
 	Code        string `json:"code,omitempty"`
 	NeededVotes string `json:"needed_votes,omitempty"`
@@ -570,6 +729,16 @@ func NewWrongCommission(got string, min string, max string) *wrongCommission {
 	return &wrongCommission{Code: strconv.Itoa(int(WrongCommission)), MaxCommission: max, MinCommission: min, GotCommission: got}
 }
 
+type tooEarlyCommissionEdit struct {
+	Code           string `json:"code,omitempty"`
+	LastEditHeight string `json:"last_edit_height,omitempty"`
+	NextEditHeight string `json:"next_edit_height,omitempty"`
+}
+
+func NewTooEarlyCommissionEdit(lastEditHeight string, nextEditHeight string) *tooEarlyCommissionEdit {
+	return &tooEarlyCommissionEdit{Code: strconv.Itoa(int(TooEarlyCommissionEdit)), LastEditHeight: lastEditHeight, NextEditHeight: nextEditHeight}
+}
+
 type multisigNotExists struct {
 	Code    string `json:"code,omitempty"`
 	Address string `json:"address,omitempty"`
@@ -0,0 +1,479 @@
+package coinsindex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/core/state/bus"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/tendermint/tm-db"
+)
+
+const (
+	coinPrefix      = byte('c')
+	balancePrefix   = byte('b')
+	stakePrefix     = byte('s')
+	candidatePrefix = byte('k')
+	pricePrefix     = byte('p')
+
+	heightPath      = "height"
+	historyFromPath = "history_from"
+)
+
+// Coin is an indexed coin
+type Coin struct {
+	ID      types.CoinID
+	Symbol  string
+	Crr     uint32
+	Volume  *big.Int
+	Reserve *big.Int
+}
+
+// Holder is an address holding a coin on its balance or in stakes
+type Holder struct {
+	Address types.Address
+	Value   *big.Int
+}
+
+// PricePoint is volume and reserve of a coin changed at Height
+type PricePoint struct {
+	Height  uint64
+	Crr     uint32
+	Volume  *big.Int
+	Reserve *big.Int
+}
+
+// stakeKey is an entry of list of stakes indexed for a candidate
+type stakeKey struct {
+	Coin  types.CoinID
+	Owner types.Address
+}
+
+// Index is an optional node-side index of coins and their holders. It is not a part of consensus state,
+// so it is kept in a separate db and reflects only the last committed height.
+// State modules report changed coins, balances and stakes on commit, Commit writes them atomically.
+// Index also keeps history of volumes and reserves of coins since the height it was created or rebuilt at.
+type Index struct {
+	db db.DB
+
+	batch         db.Batch
+	pendingStakes map[uint32][]stakeKey
+	pendingPrices map[types.CoinID]*PricePoint
+
+	lock sync.Mutex
+}
+
+// NewIndex creates new index in given DB
+func NewIndex(db db.DB) *Index {
+	return &Index{
+		db:            db,
+		pendingStakes: map[uint32][]stakeKey{},
+		pendingPrices: map[types.CoinID]*PricePoint{},
+	}
+}
+
+// SetCoin stores actual info of a coin
+func (i *Index) SetCoin(coin bus.Coin) {
+	data, err := rlp.EncodeToBytes(&Coin{
+		ID:      coin.ID,
+		Symbol:  coin.GetFullSymbol(),
+		Crr:     coin.Crr,
+		Volume:  coin.Volume,
+		Reserve: coin.Reserve,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode coin %d: %s", coin.ID, err))
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.getBatch().Set(getCoinPath(coin.ID), data)
+	i.pendingPrices[coin.ID] = &PricePoint{
+		Crr:     coin.Crr,
+		Volume:  big.NewInt(0).Set(coin.Volume),
+		Reserve: big.NewInt(0).Set(coin.Reserve),
+	}
+}
+
+// SetBalance stores actual balance of an address, zero balance is removed
+func (i *Index) SetBalance(address types.Address, coin types.CoinID, value *big.Int) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	path := getBalancePath(coin, address)
+	if value.Sign() == 0 {
+		i.getBatch().Delete(path)
+		return
+	}
+
+	i.getBatch().Set(path, value.Bytes())
+}
+
+// SetStakes replaces indexed stakes of a candidate with given ones
+func (i *Index) SetStakes(candidateID uint32, stakes []bus.Stake) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	batch := i.getBatch()
+	for _, key := range i.getCandidateStakes(candidateID) {
+		batch.Delete(getStakePath(key.Coin, key.Owner, candidateID))
+	}
+
+	keys := make([]stakeKey, 0, len(stakes))
+	for _, stake := range stakes {
+		if stake.Value.Sign() == 0 {
+			continue
+		}
+
+		batch.Set(getStakePath(stake.Coin, stake.Owner, candidateID), stake.Value.Bytes())
+		keys = append(keys, stakeKey{Coin: stake.Coin, Owner: stake.Owner})
+	}
+
+	i.pendingStakes[candidateID] = keys
+	if len(keys) == 0 {
+		batch.Delete(getCandidatePath(candidateID))
+		return
+	}
+
+	data, err := rlp.EncodeToBytes(keys)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode stakes of candidate %d: %s", candidateID, err))
+	}
+	batch.Set(getCandidatePath(candidateID), data)
+}
+
+// Commit writes changes of given height to db
+func (i *Index) Commit(height uint64) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	batch := i.getBatch()
+	defer func() {
+		batch.Close()
+		i.batch = nil
+		i.pendingStakes = map[uint32][]stakeKey{}
+		i.pendingPrices = map[types.CoinID]*PricePoint{}
+	}()
+
+	for id, point := range i.pendingPrices {
+		point.Height = height
+		data, err := rlp.EncodeToBytes(point)
+		if err != nil {
+			return fmt.Errorf("failed to encode price of coin %d: %s", id, err)
+		}
+		batch.Set(getPricePath(id, height), data)
+	}
+
+	historyFrom, err := i.db.Get([]byte(historyFromPath))
+	if err != nil {
+		return err
+	}
+	if len(historyFrom) == 0 {
+		batch.Set([]byte(historyFromPath), heightBytes(height))
+	}
+
+	batch.Set([]byte(heightPath), heightBytes(height))
+
+	return batch.Write()
+}
+
+// Height returns the last height written to the index, 0 if the index is empty
+func (i *Index) Height() uint64 {
+	value, err := i.db.Get([]byte(heightPath))
+	if err != nil {
+		panic(err)
+	}
+
+	if len(value) == 0 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(value)
+}
+
+// HistoryFrom returns the height since which price history of coins is complete, 0 if the index is empty
+func (i *Index) HistoryFrom() uint64 {
+	value, err := i.db.Get([]byte(historyFromPath))
+	if err != nil {
+		panic(err)
+	}
+
+	if len(value) == 0 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(value)
+}
+
+// PriceHistory returns volume and reserve of a coin at height from, nil if the coin has not existed yet,
+// followed by their changes within (from, to] ordered by height
+func (i *Index) PriceHistory(coin types.CoinID, from, to uint64) (*PricePoint, []PricePoint, error) {
+	it, err := i.db.ReverseIterator(getPricePath(coin, 0), getPricePath(coin, from+1))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var start *PricePoint
+	if it.Valid() {
+		start = &PricePoint{}
+		if err := rlp.DecodeBytes(it.Value(), start); err != nil {
+			it.Close()
+			return nil, nil, err
+		}
+	}
+	it.Close()
+
+	var changes []PricePoint
+	it, err = i.db.Iterator(getPricePath(coin, from+1), getPricePath(coin, to+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		var point PricePoint
+		if err := rlp.DecodeBytes(it.Value(), &point); err != nil {
+			return nil, nil, err
+		}
+
+		changes = append(changes, point)
+	}
+
+	return start, changes, nil
+}
+
+// Rebuild fills the index with coins, balances and stakes of given state. Used when the index is enabled on a synced node.
+// Price history is restarted from the given height.
+func (i *Index) Rebuild(state types.AppState, height uint64) error {
+	if err := i.clear(); err != nil {
+		return err
+	}
+
+	i.lock.Lock()
+	i.getBatch().Set([]byte(historyFromPath), heightBytes(height))
+	i.lock.Unlock()
+
+	for _, coin := range state.Coins {
+		i.SetCoin(bus.Coin{
+			ID:      types.CoinID(coin.ID),
+			Crr:     uint32(coin.Crr),
+			Symbol:  coin.Symbol,
+			Version: types.CoinVersion(coin.Version),
+			Volume:  helpers.StringToBigInt(coin.Volume),
+			Reserve: helpers.StringToBigInt(coin.Reserve),
+		})
+	}
+
+	for _, account := range state.Accounts {
+		for _, balance := range account.Balance {
+			i.SetBalance(account.Address, types.CoinID(balance.Coin), helpers.StringToBigInt(balance.Value))
+		}
+	}
+
+	for _, candidate := range state.Candidates {
+		stakes := make([]bus.Stake, 0, len(candidate.Stakes))
+		for _, stake := range candidate.Stakes {
+			stakes = append(stakes, bus.Stake{
+				Owner: stake.Owner,
+				Coin:  types.CoinID(stake.Coin),
+				Value: helpers.StringToBigInt(stake.Value),
+			})
+		}
+		i.SetStakes(uint32(candidate.ID), stakes)
+	}
+
+	return i.Commit(height)
+}
+
+// Coins returns all indexed coins ordered by ID
+func (i *Index) Coins() ([]Coin, error) {
+	var coins []Coin
+	err := i.iterate([]byte{coinPrefix}, func(key []byte, value []byte) error {
+		var coin Coin
+		if err := rlp.DecodeBytes(value, &coin); err != nil {
+			return err
+		}
+
+		coins = append(coins, coin)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return coins, nil
+}
+
+// Holders returns addresses holding the coin on balances and in stakes, ordered by value descending.
+// Stakes of an address in different candidates are summed up. Results are limited to limit items, 0 means no limit.
+func (i *Index) Holders(coin types.CoinID, limit int) (balances []Holder, stakes []Holder, err error) {
+	err = i.iterate(append([]byte{balancePrefix}, coin.Bytes()...), func(key []byte, value []byte) error {
+		balances = append(balances, Holder{
+			Address: types.BytesToAddress(key[5:25]),
+			Value:   big.NewInt(0).SetBytes(value),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stakesByAddress := map[types.Address]*big.Int{}
+	err = i.iterate(append([]byte{stakePrefix}, coin.Bytes()...), func(key []byte, value []byte) error {
+		address := types.BytesToAddress(key[5:25])
+		total, ok := stakesByAddress[address]
+		if !ok {
+			total = big.NewInt(0)
+			stakesByAddress[address] = total
+		}
+		total.Add(total, big.NewInt(0).SetBytes(value))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for address, value := range stakesByAddress {
+		stakes = append(stakes, Holder{Address: address, Value: value})
+	}
+
+	return top(balances, limit), top(stakes, limit), nil
+}
+
+func (i *Index) getCandidateStakes(candidateID uint32) []stakeKey {
+	if keys, ok := i.pendingStakes[candidateID]; ok {
+		return keys
+	}
+
+	value, err := i.db.Get(getCandidatePath(candidateID))
+	if err != nil {
+		panic(err)
+	}
+
+	var keys []stakeKey
+	if len(value) != 0 {
+		if err := rlp.DecodeBytes(value, &keys); err != nil {
+			panic(fmt.Sprintf("failed to decode stakes of candidate %d: %s", candidateID, err))
+		}
+	}
+
+	return keys
+}
+
+func (i *Index) getBatch() db.Batch {
+	if i.batch == nil {
+		i.batch = i.db.NewBatch()
+	}
+
+	return i.batch
+}
+
+func (i *Index) clear() error {
+	var keys [][]byte
+	err := i.iterate(nil, func(key []byte, value []byte) error {
+		keys = append(keys, append([]byte{}, key...))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	batch := i.getBatch()
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+
+	return nil
+}
+
+func (i *Index) iterate(prefix []byte, fn func(key []byte, value []byte) error) error {
+	var end []byte
+	if len(prefix) != 0 {
+		end = prefixEnd(prefix)
+	}
+
+	it, err := i.db.Iterator(prefix, end)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		if err := fn(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func top(holders []Holder, limit int) []Holder {
+	sort.SliceStable(holders, func(i, j int) bool {
+		if c := holders[i].Value.Cmp(holders[j].Value); c != 0 {
+			return c == 1
+		}
+
+		return holders[i].Address.String() < holders[j].Address.String()
+	})
+
+	if limit > 0 && len(holders) > limit {
+		holders = holders[:limit]
+	}
+
+	return holders
+}
+
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
+
+func heightBytes(height uint64) []byte {
+	bs := make([]byte, 8)
+	binary.BigEndian.PutUint64(bs, height)
+	return bs
+}
+
+func getCoinPath(coin types.CoinID) []byte {
+	return append([]byte{coinPrefix}, coin.Bytes()...)
+}
+
+func getBalancePath(coin types.CoinID, address types.Address) []byte {
+	path := append([]byte{balancePrefix}, coin.Bytes()...)
+	return append(path, address.Bytes()...)
+}
+
+func getStakePath(coin types.CoinID, owner types.Address, candidateID uint32) []byte {
+	path := append([]byte{stakePrefix}, coin.Bytes()...)
+	path = append(path, owner.Bytes()...)
+	id := make([]byte, 4)
+	binary.BigEndian.PutUint32(id, candidateID)
+	return append(path, id...)
+}
+
+func getPricePath(coin types.CoinID, height uint64) []byte {
+	path := append([]byte{pricePrefix}, coin.Bytes()...)
+	return append(path, heightBytes(height)...)
+}
+
+func getCandidatePath(candidateID uint32) []byte {
+	id := make([]byte, 4)
+	binary.BigEndian.PutUint32(id, candidateID)
+	return append([]byte{candidatePrefix}, id...)
+}
@@ -0,0 +1,164 @@
+package coinsindex
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/state/bus"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestIndex_FeedByState(t *testing.T) {
+	cState, err := state.NewState(0, db.NewMemDB(), nil, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index := NewIndex(db.NewMemDB())
+	cState.SetCoinsIndex(index)
+
+	coinID := cState.App.GetNextCoinID()
+	cState.Coins.Create(coinID, types.StrToCoinSymbol("TEST"), "TEST COIN", helpers.BipToPip(big.NewInt(1000)), 10, helpers.BipToPip(big.NewInt(100000)), helpers.BipToPip(big.NewInt(1000000)), nil)
+	cState.App.SetCoinsCount(coinID.Uint32())
+
+	address1, address2 := types.Address{1}, types.Address{2}
+	cState.Accounts.SetBalance(address1, coinID, big.NewInt(100))
+	cState.Accounts.SetBalance(address2, coinID, big.NewInt(200))
+
+	pubkey := types.Pubkey{1}
+	cState.Candidates.Create(address1, address1, address1, pubkey, 10)
+	cState.Candidates.SetStakes(pubkey, []types.Stake{
+		{Owner: address1, Coin: uint64(coinID), Value: "300", BipValue: "0"},
+	}, nil)
+
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := index.Commit(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if index.Height() != 1 {
+		t.Fatalf("height %d", index.Height())
+	}
+
+	coins, err := index.Coins()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coins) != 1 || coins[0].ID != coinID || coins[0].Symbol != "TEST" || coins[0].Crr != 10 {
+		t.Fatalf("wrong coins %v", coins)
+	}
+
+	balances, stakes, err := index.Holders(coinID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 2 || balances[0].Address != address2 || balances[1].Value.String() != "100" {
+		t.Fatalf("wrong balances %v", balances)
+	}
+	if len(stakes) != 1 || stakes[0].Address != address1 || stakes[0].Value.String() != "300" {
+		t.Fatalf("wrong stakes %v", stakes)
+	}
+
+	cState.Accounts.SetBalance(address2, coinID, big.NewInt(0))
+	cState.Candidates.SubStake(address1, pubkey, coinID, big.NewInt(300))
+
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := index.Commit(2); err != nil {
+		t.Fatal(err)
+	}
+
+	balances, stakes, err = index.Holders(coinID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 1 || balances[0].Address != address1 {
+		t.Fatalf("wrong balances %v", balances)
+	}
+	if len(stakes) != 0 {
+		t.Fatalf("wrong stakes %v", stakes)
+	}
+}
+
+func TestIndex_Rebuild(t *testing.T) {
+	index := NewIndex(db.NewMemDB())
+	index.SetBalance(types.Address{9}, 1, big.NewInt(1))
+	if err := index.Commit(1); err != nil {
+		t.Fatal(err)
+	}
+
+	appState := types.AppState{
+		Coins: []types.Coin{{ID: 1, Symbol: types.StrToCoinSymbol("TEST"), Volume: "1000", Crr: 50, Reserve: "500"}},
+		Accounts: []types.Account{
+			{Address: types.Address{1}, Balance: []types.Balance{{Coin: 1, Value: "10"}}},
+			{Address: types.Address{2}, Balance: []types.Balance{{Coin: 1, Value: "20"}}},
+			{Address: types.Address{3}, Balance: []types.Balance{{Coin: 1, Value: "30"}}},
+		},
+		Candidates: []types.Candidate{
+			{ID: 1, Stakes: []types.Stake{{Owner: types.Address{1}, Coin: 1, Value: "5"}}},
+			{ID: 2, Stakes: []types.Stake{{Owner: types.Address{1}, Coin: 1, Value: "7"}}},
+		},
+	}
+
+	if err := index.Rebuild(appState, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if index.Height() != 10 {
+		t.Fatalf("height %d", index.Height())
+	}
+
+	balances, stakes, err := index.Holders(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 2 || balances[0].Address != (types.Address{3}) || balances[1].Address != (types.Address{2}) {
+		t.Fatalf("wrong balances %v", balances)
+	}
+	if len(stakes) != 1 || stakes[0].Value.String() != "12" {
+		t.Fatalf("wrong stakes %v", stakes)
+	}
+}
+
+func TestIndex_PriceHistory(t *testing.T) {
+	index := NewIndex(db.NewMemDB())
+
+	for i, volume := range []int64{1000, 1100, 1200} {
+		index.SetCoin(bus.Coin{ID: 1, Crr: 50, Symbol: types.StrToCoinSymbol("TEST"), Volume: big.NewInt(volume), Reserve: big.NewInt(volume / 2)})
+		if err := index.Commit(uint64(2 + i*2)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if index.HistoryFrom() != 2 {
+		t.Fatalf("history from %d", index.HistoryFrom())
+	}
+
+	start, changes, err := index.PriceHistory(1, 1, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start != nil {
+		t.Fatalf("coin should not exist at height 1, got %v", start)
+	}
+	if len(changes) != 3 || changes[0].Height != 2 || changes[2].Volume.String() != "1200" {
+		t.Fatalf("wrong changes %v", changes)
+	}
+
+	start, changes, err = index.PriceHistory(1, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start == nil || start.Height != 2 || start.Reserve.String() != "500" {
+		t.Fatalf("wrong start %v", start)
+	}
+	if len(changes) != 1 || changes[0].Height != 4 || changes[0].Crr != 50 {
+		t.Fatalf("wrong changes %v", changes)
+	}
+}
@@ -12,6 +12,8 @@ type IEventsDB interface {
 	AddEvent(height uint32, event Event)
 	LoadEvents(height uint32) Events
 	CommitEvents() error
+	DeleteEvents(height uint32) error
+	Close() error
 }
 
 type eventsStore struct {
@@ -103,6 +105,10 @@ func (store *eventsStore) CommitEvents() error {
 
 	store.pending.Lock()
 	defer store.pending.Unlock()
+	if len(store.pending.items) == 0 {
+		return nil
+	}
+
 	var data []compactEvent
 	for _, item := range store.pending.items {
 		pubKey := store.savePubKey(item.validatorPubKey())
@@ -120,9 +126,27 @@ func (store *eventsStore) CommitEvents() error {
 	if err := store.db.Set(uint32ToBytes(store.pending.height), bytes); err != nil {
 		return err
 	}
+
+	store.pending.items = Events{}
 	return nil
 }
 
+// DeleteEvents deletes committed events of given height, e.g. before the height is replayed
+func (store *eventsStore) DeleteEvents(height uint32) error {
+	store.Lock()
+	defer store.Unlock()
+
+	return store.db.Delete(uint32ToBytes(height))
+}
+
+// Close closes DB of events
+func (store *eventsStore) Close() error {
+	store.Lock()
+	defer store.Unlock()
+
+	return store.db.Close()
+}
+
 func (store *eventsStore) loadCache() {
 	store.Lock()
 	if len(store.idPubKey) == 0 {
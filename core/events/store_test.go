@@ -167,3 +167,29 @@ func TestIEventsDB(t *testing.T) {
 		t.Fatal("invalid Coin")
 	}
 }
+
+func TestIEventsDB_DeleteEvents(t *testing.T) {
+	store := NewEventsStore(db.NewMemDB())
+
+	store.AddEvent(12, &UnbondEvent{
+		Coin:            1,
+		Address:         types.HexToAddress("Mx18467bbb64a8edf890201d526c35957d82be3d91"),
+		Amount:          "891977800000000000001",
+		ValidatorPubKey: types.HexToPubkey("Mp738da41ba6a7b7d69b7294afa158b89c5a1b410cbf0c2443c85c5fe24ad1dd11"),
+	})
+	if err := store.CommitEvents(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(store.LoadEvents(12)) != 1 {
+		t.Fatal("event is not committed")
+	}
+
+	if err := store.DeleteEvents(12); err != nil {
+		t.Fatal(err)
+	}
+
+	if events := store.LoadEvents(12); len(events) != 0 {
+		t.Fatalf("events are not deleted: %v", events)
+	}
+}
@@ -0,0 +1,34 @@
+package minter
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// mempoolSenders is a set of senders of transactions accepted to mempool since the last commit, which keeps its size,
+// so it is not counted on every CheckTx
+type mempoolSenders struct {
+	senders sync.Map
+	count   int64
+}
+
+func (m *mempoolSenders) Load(sender interface{}) (interface{}, bool) {
+	return m.senders.Load(sender)
+}
+
+func (m *mempoolSenders) Store(sender, value interface{}) {
+	if _, loaded := m.senders.LoadOrStore(sender, value); !loaded {
+		atomic.AddInt64(&m.count, 1)
+	}
+}
+
+func (m *mempoolSenders) Delete(sender interface{}) {
+	if _, loaded := m.senders.LoadAndDelete(sender); loaded {
+		atomic.AddInt64(&m.count, -1)
+	}
+}
+
+// Count returns amount of senders
+func (m *mempoolSenders) Count() int {
+	return int(atomic.LoadInt64(&m.count))
+}
@@ -3,9 +3,10 @@ package minter
 import (
 	"bytes"
 	"fmt"
-	"github.com/MinterTeam/minter-go-node/cmd/utils"
 	"github.com/MinterTeam/minter-go-node/config"
 	"github.com/MinterTeam/minter-go-node/core/appdb"
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/coinsindex"
 	eventsdb "github.com/MinterTeam/minter-go-node/core/events"
 	"github.com/MinterTeam/minter-go-node/core/rewards"
 	"github.com/MinterTeam/minter-go-node/core/state"
@@ -21,6 +22,7 @@ import (
 	"github.com/tendermint/go-amino"
 	abciTypes "github.com/tendermint/tendermint/abci/types"
 	tmNode "github.com/tendermint/tendermint/node"
+	tmTypes "github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tm-db"
 	"math/big"
 	"sort"
@@ -57,6 +59,7 @@ type Blockchain struct {
 	stateDB            db.DB
 	appDB              *appdb.AppDB
 	eventsDB           eventsdb.IEventsDB
+	coinsIndex         *coinsindex.Index
 	stateDeliver       *state.State
 	stateCheck         *state.CheckState
 	height             uint64   // current Blockchain height
@@ -67,19 +70,20 @@ type Blockchain struct {
 	tmNode *tmNode.Node
 
 	// currentMempool is responsive for prevent sending multiple transactions from one address in one block
-	currentMempool *sync.Map
+	currentMempool *mempoolSenders
 
-	lock sync.RWMutex
+	lock      sync.RWMutex
+	pruneLock sync.Mutex
 
 	haltHeight uint64
 	cfg        *config.Config
 }
 
-// NewMinterBlockchain creates Minter Blockchain instance, should be only called once
+// NewMinterBlockchain creates Minter Blockchain instance, should be only called once per data directory
 func NewMinterBlockchain(cfg *config.Config) *Blockchain {
 	var err error
 
-	ldb, err := db.NewGoLevelDBWithOpts("state", utils.GetMinterHome()+"/data", getDbOpts(cfg.StateMemAvailable))
+	ldb, err := db.NewGoLevelDBWithOpts("state", cfg.DataDir(), getDbOpts(cfg.StateMemAvailable))
 	if err != nil {
 		panic(err)
 	}
@@ -87,7 +91,7 @@ func NewMinterBlockchain(cfg *config.Config) *Blockchain {
 	// Initiate Application DB. Used for persisting data like current block, validators, etc.
 	applicationDB := appdb.NewAppDB(cfg)
 
-	edb, err := db.NewGoLevelDBWithOpts("events", utils.GetMinterHome()+"/data", getDbOpts(1024))
+	edb, err := db.NewGoLevelDBWithOpts("events", cfg.DataDir(), getDbOpts(1024))
 	if err != nil {
 		panic(err)
 	}
@@ -97,7 +101,7 @@ func NewMinterBlockchain(cfg *config.Config) *Blockchain {
 		appDB:          applicationDB,
 		height:         applicationDB.GetLastHeight(),
 		eventsDB:       eventsdb.NewEventsStore(edb),
-		currentMempool: &sync.Map{},
+		currentMempool: &mempoolSenders{},
 		cfg:            cfg,
 	}
 
@@ -107,6 +111,28 @@ func NewMinterBlockchain(cfg *config.Config) *Blockchain {
 		panic(err)
 	}
 
+	pruning, err := state.NewPruning(cfg.StatePruning, cfg.KeepLastStates, cfg.StateKeepEvery)
+	if err != nil {
+		panic(err)
+	}
+	pruning.Retain = blockchain.isSnapshotHeight
+	blockchain.stateDeliver.SetPruning(pruning)
+
+	if cfg.CoinsIndex && !cfg.ValidatorMode {
+		cdb, err := db.NewGoLevelDBWithOpts("coins", cfg.DataDir(), getDbOpts(1024))
+		if err != nil {
+			panic(err)
+		}
+
+		blockchain.coinsIndex = coinsindex.NewIndex(cdb)
+		if blockchain.height != 0 && blockchain.coinsIndex.Height() != blockchain.height {
+			if err := blockchain.coinsIndex.Rebuild(blockchain.stateDeliver.Export(blockchain.height), blockchain.height); err != nil {
+				panic(err)
+			}
+		}
+		blockchain.stateDeliver.SetCoinsIndex(blockchain.coinsIndex)
+	}
+
 	blockchain.stateCheck = state.NewCheckState(blockchain.stateDeliver)
 
 	// Set start height for rewards and validators
@@ -150,6 +176,11 @@ func (app *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTypes.Res
 
 	app.stateDeliver.Lock()
 
+	// start tracking supply of base coin from the state committed before the upgrade, unless it is imported by genesis
+	if height >= upgrades.UpgradeBlock2 && !app.stateDeliver.App.IsSupplyTracked() {
+		app.stateDeliver.InitSupply(height)
+	}
+
 	// compute max gas
 	app.updateBlocksTimeDelta(height, 3)
 	maxGas := app.calcMaxGas(height)
@@ -214,6 +245,11 @@ func (app *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTypes.Res
 		app.stateDeliver.FrozenFunds.Delete(frozenFunds.Height())
 	}
 
+	// apply scheduled commissions of candidates
+	if height >= upgrades.UpgradeBlock2 {
+		app.stateDeliver.Candidates.ApplyPendingCommissions(height)
+	}
+
 	app.stateDeliver.Halts.Delete(height)
 
 	return abciTypes.ResponseBeginBlock{}
@@ -254,7 +290,11 @@ func (app *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.Respons
 
 	// accumulate rewards
 	reward := rewards.GetRewardForBlock(height)
-	app.stateDeliver.Checker.AddCoinVolume(types.GetBaseCoinID(), reward)
+	if app.stateDeliver.App.IsSupplyTracked() {
+		app.stateDeliver.App.AddEmission(reward)
+	} else {
+		app.stateDeliver.Checker.AddCoinVolume(types.GetBaseCoinID(), reward)
+	}
 	reward.Add(reward, app.rewards)
 
 	// compute remainder to keep total emission consist
@@ -288,6 +328,11 @@ func (app *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.Respons
 		hasChangedPublicKeys = true
 	}
 
+	// delete used checks which can't be redeemed anymore
+	if height >= upgrades.UpgradeBlock2 {
+		app.stateDeliver.Checks.DeleteExpired(height)
+	}
+
 	// update validators
 	var updates []abciTypes.ValidatorUpdate
 	if req.Height%120 == 0 || hasDroppedValidators || hasChangedPublicKeys {
@@ -310,7 +355,9 @@ func (app *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.Respons
 }
 
 func (app *Blockchain) updateValidators(height uint64) []abciTypes.ValidatorUpdate {
-	app.stateDeliver.Candidates.RecalculateStakes(height)
+	start := time.Now()
+	kicks := app.stateDeliver.Candidates.RecalculateStakes(height)
+	app.StatisticData().SetStakesRecalculation(time.Since(start), kicks)
 
 	valsCount := validators.GetValidatorsCountForBlock(height)
 	newCandidates := app.stateDeliver.Candidates.GetNewCandidates(valsCount)
@@ -384,6 +431,15 @@ func (app *Blockchain) Info(req abciTypes.RequestInfo) (resInfo abciTypes.Respon
 func (app *Blockchain) DeliverTx(req abciTypes.RequestDeliverTx) abciTypes.ResponseDeliverTx {
 	response := transaction.RunTx(app.stateDeliver, req.Tx, app.rewards, app.height, &sync.Map{}, 0)
 
+	if statisticData := app.StatisticData(); statisticData != nil {
+		txType := statistics.UnknownTxType
+		if response.Type != 0 {
+			txType = fmt.Sprintf("0x%02X", byte(response.Type))
+		}
+
+		statisticData.AddDeliverTx(txType, response.Code, response.GasUsed)
+	}
+
 	return abciTypes.ResponseDeliverTx{
 		Code:      response.Code,
 		Data:      response.Data,
@@ -404,6 +460,14 @@ func (app *Blockchain) DeliverTx(req abciTypes.RequestDeliverTx) abciTypes.Respo
 func (app *Blockchain) CheckTx(req abciTypes.RequestCheckTx) abciTypes.ResponseCheckTx {
 	response := transaction.RunTx(app.stateCheck, req.Tx, nil, app.height, app.currentMempool, app.MinGasPrice())
 
+	if statisticData := app.StatisticData(); statisticData != nil {
+		if response.Code != code.OK {
+			statisticData.AddCheckTxRejection(response.Code)
+		}
+
+		statisticData.SetMempoolSenders(app.currentMempool.Count())
+	}
+
 	return abciTypes.ResponseCheckTx{
 		Code:      response.Code,
 		Data:      response.Data,
@@ -422,6 +486,8 @@ func (app *Blockchain) CheckTx(req abciTypes.RequestCheckTx) abciTypes.ResponseC
 
 // Commit the state and return the application Merkle root hash
 func (app *Blockchain) Commit() abciTypes.ResponseCommit {
+	start := time.Now()
+
 	if err := app.stateDeliver.Check(); err != nil {
 		panic(err)
 	}
@@ -438,6 +504,13 @@ func (app *Blockchain) Commit() abciTypes.ResponseCommit {
 		panic(err)
 	}
 
+	// Flush coins index
+	if app.coinsIndex != nil {
+		if err := app.coinsIndex.Commit(app.height); err != nil {
+			panic(err)
+		}
+	}
+
 	// Persist application hash and height
 	app.appDB.SetLastBlockHash(hash)
 	app.appDB.SetLastHeight(app.height)
@@ -448,7 +521,10 @@ func (app *Blockchain) Commit() abciTypes.ResponseCommit {
 	app.resetCheckState()
 
 	// Clear mempool
-	app.currentMempool = &sync.Map{}
+	app.currentMempool = &mempoolSenders{}
+
+	app.StatisticData().SetCommit(time.Since(start), app.stateDeliver.SaveVersionDuration())
+	app.StatisticData().SetMempoolSenders(0)
 
 	return abciTypes.ResponseCommit{
 		Data: hash,
@@ -471,6 +547,9 @@ func (app *Blockchain) Stop() {
 	if err := app.stateDB.Close(); err != nil {
 		panic(err)
 	}
+	if err := app.eventsDB.Close(); err != nil {
+		panic(err)
+	}
 }
 
 // CurrentState returns immutable state of Minter Blockchain
@@ -486,11 +565,19 @@ func (app *Blockchain) GetStateForHeight(height uint64) (*state.CheckState, erro
 	if height > 0 {
 		s, err := state.NewCheckStateAtHeight(height, app.stateDB)
 		if err != nil {
+			if height <= app.LastCommittedHeight() {
+				return nil, &PrunedStateError{Height: height, Available: app.stateDeliver.Tree().AvailableVersions()}
+			}
 			return nil, err
 		}
 		return s, nil
 	}
-	return blockchain.CurrentState(), nil
+	return app.CurrentState(), nil
+}
+
+// CoinsIndex returns node-side index of coins and their holders, nil if it is disabled
+func (app *Blockchain) CoinsIndex() *coinsindex.Index {
+	return app.coinsIndex
 }
 
 // Height returns current height of Minter Blockchain
@@ -498,13 +585,25 @@ func (app *Blockchain) Height() uint64 {
 	return atomic.LoadUint64(&app.height)
 }
 
-// SetTmNode sets Tendermint node
+// LastCommittedHeight returns height of the last committed block. Unlike Height, it doesn't include the block in progress.
+func (app *Blockchain) LastCommittedHeight() uint64 {
+	return app.appDB.GetLastHeight()
+}
+
+// SetTmNode sets Tendermint node and starts recovery of signatures of proposed blocks
 func (app *Blockchain) SetTmNode(node *tmNode.Node) {
 	app.tmNode = node
+
+	go app.recoverProposedTxs()
 }
 
 // MinGasPrice returns minimal acceptable gas price
 func (app *Blockchain) MinGasPrice() uint32 {
+	// there is no mempool if application is run without Tendermint node
+	if app.tmNode == nil {
+		return 1
+	}
+
 	mempoolSize := app.tmNode.Mempool().Size()
 
 	if mempoolSize > 5000 {
@@ -547,12 +646,19 @@ func (app *Blockchain) updateBlocksTimeDelta(height uint64, count int64) {
 		return
 	}
 
+	app.setBlocksTimeDelta(app.tmNode.BlockStore(), height, count)
+}
+
+// blockMetaStore is a store of block headers, it is implemented by Tendermint's block store
+type blockMetaStore interface {
+	LoadBlockMeta(height int64) *tmTypes.BlockMeta
+}
+
+func (app *Blockchain) setBlocksTimeDelta(blockStore blockMetaStore, height uint64, count int64) {
 	if int64(height)-count-1 < 1 {
 		return
 	}
 
-	blockStore := app.tmNode.BlockStore()
-
 	blockA := blockStore.LoadBlockMeta(int64(height) - count - 1)
 	blockB := blockStore.LoadBlockMeta(int64(height) - 1)
 
@@ -1,6 +1,7 @@
 package minter
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"encoding/base64"
@@ -27,11 +28,13 @@ import (
 	"github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
 	rpc "github.com/tendermint/tendermint/rpc/client/local"
+	"github.com/tendermint/tendermint/store"
 	types2 "github.com/tendermint/tendermint/types"
 	"math/big"
 	"math/rand"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -140,7 +143,7 @@ var port = 0
 
 func getPort() string {
 	port++
-	return strconv.Itoa(port)
+	return strconv.Itoa(25560 + port)
 }
 
 func initTestNode(t *testing.T) (*Blockchain, *rpc.Local, *privval.FilePV) {
@@ -162,7 +165,7 @@ func initTestNode(t *testing.T) (*Blockchain, *rpc.Local, *privval.FilePV) {
 	cfg.Consensus.TimeoutProposeDelta = 0
 	cfg.Consensus.SkipTimeoutCommit = true
 	cfg.RPC.ListenAddress = ""
-	cfg.P2P.ListenAddress = "0.0.0.0:2556" + getPort() // todo
+	cfg.P2P.ListenAddress = "0.0.0.0:" + getPort()
 	cfg.P2P.Seeds = ""
 	cfg.P2P.PersistentPeers = ""
 	cfg.DBBackend = "memdb"
@@ -484,6 +487,136 @@ func TestBlockchain_SendTx(t *testing.T) {
 	}
 }
 
+type testReplaySource struct {
+	*store.BlockStore
+	validators *types2.ValidatorSet
+	skipTxs    int64
+}
+
+func (s *testReplaySource) LoadBlock(height int64) *types2.Block {
+	block := s.BlockStore.LoadBlock(height)
+	if block != nil && height == s.skipTxs {
+		block.Txs = nil
+	}
+
+	return block
+}
+
+func (s *testReplaySource) LoadValidators(height int64) (*types2.ValidatorSet, error) {
+	return s.validators, nil
+}
+
+func TestBlockchain_Replay(t *testing.T) {
+	blockchain, tmCli, pv := initTestNode(t)
+	defer blockchain.Stop()
+
+	to := types.Address([20]byte{1})
+	data := transaction.SendData{
+		Coin:  types.GetBaseCoinID(),
+		To:    to,
+		Value: helpers.BipToPip(big.NewInt(10)),
+	}
+
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := transaction.Transaction{
+		Nonce:         1,
+		ChainID:       types.CurrentChainID,
+		GasPrice:      1,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          transaction.TypeSend,
+		Data:          encodedData,
+		SignatureType: transaction.SigTypeSingle,
+	}
+
+	if err := tx.Sign(getPrivateKey()); err != nil {
+		t.Fatal(err)
+	}
+
+	txBytes, _ := tx.Serialize()
+
+	txs, err := tmCli.Subscribe(context.Background(), "test-client", "tm.event = 'Tx'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := tmCli.BroadcastTxSync(txBytes)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+
+	if res.Code != 0 {
+		t.Fatalf("CheckTx code is not 0: %d", res.Code)
+	}
+
+	var txHeight int64
+	select {
+	case event := <-txs:
+		txHeight = event.Data.(types2.EventDataTx).Height
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Timeout waiting for the tx to be committed")
+	}
+
+	blocks, err := tmCli.Subscribe(context.Background(), "test-client", "tm.event = 'NewBlock'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for block := range blocks {
+		if block.Data.(types2.EventDataNewBlock).Block.Height >= txHeight+3 {
+			break
+		}
+	}
+
+	if err := blockchain.tmNode.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	lastHeight, lastHash := blockchain.LastCommittedHeight(), blockchain.appDB.GetLastBlockHash()
+
+	// stale events of replayed block
+	blockchain.eventsDB.AddEvent(uint32(txHeight), &eventsdb.UnbondEvent{
+		Address:         to,
+		Amount:          "1",
+		ValidatorPubKey: types.BytesToPubkey(pv.Key.PubKey.Bytes()[5:]),
+	})
+	if err := blockchain.eventsDB.CommitEvents(); err != nil {
+		t.Fatal(err)
+	}
+
+	source := &testReplaySource{
+		BlockStore: blockchain.tmNode.BlockStore(),
+		validators: types2.NewValidatorSet([]*types2.Validator{types2.NewValidator(pv.Key.PubKey, 1)}),
+	}
+
+	if err := blockchain.Replay(source, uint64(txHeight), lastHeight, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if events := blockchain.eventsDB.LoadEvents(uint32(txHeight)); len(events) != 0 {
+		t.Fatalf("events of block %d are not rebuilt: %v", txHeight, events)
+	}
+
+	if blockchain.LastCommittedHeight() != lastHeight || !bytes.Equal(blockchain.appDB.GetLastBlockHash(), lastHash) {
+		t.Fatal("application db is not restored after replay")
+	}
+
+	source.skipTxs = txHeight
+	err = blockchain.Replay(source, uint64(txHeight), lastHeight, nil)
+
+	divergence, ok := err.(*DivergenceError)
+	if !ok || divergence.Height != uint64(txHeight) {
+		t.Fatalf("replay of block %d without tx does not diverge: %v", txHeight, err)
+	}
+
+	if !strings.Contains(divergence.Error(), to.String()) {
+		t.Fatalf("diff does not contain balance of recipient: %s", divergence)
+	}
+}
+
 func TestBlockchain_FrozenFunds(t *testing.T) {
 	blockchain, tmCli, pv := initTestNode(t)
 
@@ -826,3 +959,124 @@ func TestStopNetworkByHaltBlocks(t *testing.T) {
 		t.Fatalf("Application not halted at height %d", haltHeight)
 	}
 }
+
+func TestPrunedStateError(t *testing.T) {
+	err := &PrunedStateError{Height: 4, Available: []int{1, 2, 3, 10, 20, 21}}
+	if err.Error() != "state at height 4 is pruned, available heights: 1-3, 10, 20-21" {
+		t.Fatalf("Wrong error: %s", err)
+	}
+
+	var versions []int
+	for i := 0; i < 30; i += 2 {
+		versions = append(versions, i)
+	}
+	err = &PrunedStateError{Height: 1, Available: versions}
+	if err.Error() != "state at height 1 is pruned, available heights: 0, 2, 4, 6, 8, ..., 20, 22, 24, 26, 28" {
+		t.Fatalf("Wrong error: %s", err)
+	}
+}
+
+func TestBlockchain_TraceTx(t *testing.T) {
+	blockchain, tmCli, _ := initTestNode(t)
+	defer blockchain.Stop()
+
+	to := types.Address([20]byte{2})
+	value := helpers.BipToPip(big.NewInt(10))
+	data := transaction.SendData{
+		Coin:  types.GetBaseCoinID(),
+		To:    to,
+		Value: value,
+	}
+
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := transaction.Transaction{
+		Nonce:         1,
+		ChainID:       types.CurrentChainID,
+		GasPrice:      1,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          transaction.TypeSend,
+		Data:          encodedData,
+		SignatureType: transaction.SigTypeSingle,
+	}
+
+	if err := tx.Sign(getPrivateKey()); err != nil {
+		t.Fatal(err)
+	}
+
+	txBytes, _ := tx.Serialize()
+
+	blocks, err := tmCli.Subscribe(context.Background(), "test-client", "tm.event = 'NewBlock'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := tmCli.BroadcastTxSync(txBytes)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+
+	if res.Code != 0 {
+		t.Fatalf("CheckTx code is not 0: %d", res.Code)
+	}
+
+	var block *types2.Block
+	for event := range blocks {
+		if b := event.Data.(types2.EventDataNewBlock).Block; len(b.Txs) != 0 {
+			block = b
+			break
+		}
+	}
+
+	for event := range blocks {
+		if event.Data.(types2.EventDataNewBlock).Block.Height > block.Height {
+			break
+		}
+	}
+
+	if err := tmCli.UnsubscribeAll(context.Background(), "test-client"); err != nil {
+		t.Fatal(err)
+	}
+
+	// consensus keeps writing to home directory until it's stopped
+	if err := blockchain.tmNode.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	blockchain.tmNode.ConsensusState().Wait()
+
+	trace := transaction.NewTrace()
+	response, err := blockchain.TraceTx(uint64(block.Height), block.Txs, 0, trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0: %d %s", response.Code, response.Log)
+	}
+
+	expected := transaction.TraceStep{
+		Op:      transaction.TraceBalanceWrite,
+		Address: to.String(),
+		Coin:    types.GetBaseCoinID().String(),
+		Before:  "0",
+		After:   value.String(),
+	}
+
+	traced := false
+	for _, step := range trace.Steps {
+		if step == expected {
+			traced = true
+		}
+	}
+
+	if !traced {
+		t.Fatalf("balance change of recipient is not traced: %+v", trace.Steps)
+	}
+
+	if balance := blockchain.CurrentState().Accounts().GetBalance(to, types.GetBaseCoinID()); balance.Cmp(value) != 0 {
+		t.Fatalf("state is changed by trace, balance of recipient is %s", balance)
+	}
+}
@@ -0,0 +1,146 @@
+package minter
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// maxListedRanges limits amount of ranges of available heights listed in PrunedStateError
+const maxListedRanges = 10
+
+// PrunedStateError is returned by GetStateForHeight if state at height was deleted by pruning
+type PrunedStateError struct {
+	Height    uint64
+	Available []int
+}
+
+func (e *PrunedStateError) Error() string {
+	return fmt.Sprintf("state at height %d is pruned, available heights: %s", e.Height, formatVersions(e.Available))
+}
+
+// formatVersions joins sorted versions into ranges, listing only the first and the last ones if there are too many
+func formatVersions(versions []int) string {
+	var ranges []string
+	for i := 0; i < len(versions); {
+		j := i
+		for j+1 < len(versions) && versions[j+1] == versions[j]+1 {
+			j++
+		}
+
+		if i == j {
+			ranges = append(ranges, fmt.Sprintf("%d", versions[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", versions[i], versions[j]))
+		}
+		i = j + 1
+	}
+
+	if len(ranges) == 0 {
+		return "none"
+	}
+
+	if len(ranges) > maxListedRanges {
+		ranges = append(append(ranges[:maxListedRanges/2:maxListedRanges/2], "..."), ranges[len(ranges)-maxListedRanges/2:]...)
+	}
+
+	return strings.Join(ranges, ", ")
+}
+
+// PruneTask is a range of state versions deleted in background
+type PruneTask struct {
+	from    int64
+	to      int64
+	batch   int64
+	current int64
+
+	done chan struct{}
+	err  error
+}
+
+// Total returns amount of versions in range of the task
+func (t *PruneTask) Total() int64 {
+	return t.to - t.from
+}
+
+// Current returns amount of versions processed by the task
+func (t *PruneTask) Current() int64 {
+	return atomic.LoadInt64(&t.current)
+}
+
+// Done returns a channel which is closed when the task is finished
+func (t *PruneTask) Done() <-chan struct{} {
+	return t.done
+}
+
+// Err returns error of finished task
+func (t *PruneTask) Err() error {
+	<-t.done
+	return t.err
+}
+
+// PruneStateVersions starts deleting states within [from, to) in background. Tree is locked for batch versions at once,
+// so Commit is not stalled for long. States kept by pruning strategy, such as every N-th or snapshot heights, and the
+// last committed state are not deleted. Tasks are run one after another.
+func (app *Blockchain) PruneStateVersions(from, to, batch int64) *PruneTask {
+	task := &PruneTask{from: from, to: to, batch: batch, done: make(chan struct{})}
+
+	go func() {
+		defer close(task.done)
+
+		app.pruneLock.Lock()
+		defer app.pruneLock.Unlock()
+
+		for from := task.from; from < task.to; from += task.batch {
+			to := from + task.batch
+			if to > task.to {
+				to = task.to
+			}
+
+			if err := app.pruneStateVersions(from, to); err != nil {
+				task.err = err
+				return
+			}
+
+			atomic.StoreInt64(&task.current, to-task.from)
+			runtime.Gosched()
+		}
+	}()
+
+	return task
+}
+
+func (app *Blockchain) pruneStateVersions(from, to int64) error {
+	app.lock.RLock()
+	defer app.lock.RUnlock()
+
+	pruning := app.stateDeliver.Pruning()
+	stateTree := app.stateDeliver.Tree()
+
+	stateTree.GlobalLock()
+	defer stateTree.GlobalUnlock()
+
+	last := int64(app.appDB.GetLastHeight())
+	for version := from; version < to && version < last; version++ {
+		if pruning.Keeps(version) {
+			continue
+		}
+
+		if err := stateTree.DeleteVersionIfExists(version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isSnapshotHeight reports whether state at version has to be kept for export, because the network halts at the next height
+func (app *Blockchain) isSnapshotHeight(version int64) bool {
+	next := uint64(version) + 1
+	if app.haltHeight != 0 && next == app.haltHeight {
+		return true
+	}
+
+	return app.stateDeliver.Halts.GetHaltBlocks(next) != nil
+}
@@ -0,0 +1,307 @@
+package minter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	tmTypes "github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tm-db"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// ReplaySource provides blocks committed by the network and validator sets of Tendermint, which are needed to
+// re-execute blocks the same way Tendermint does
+type ReplaySource interface {
+	// Height returns height of the last stored block
+	Height() int64
+	LoadBlock(height int64) *tmTypes.Block
+	LoadBlockMeta(height int64) *tmTypes.BlockMeta
+	LoadValidators(height int64) (*tmTypes.ValidatorSet, error)
+}
+
+type replaySource struct {
+	*store.BlockStore
+	stateDB db.DB
+}
+
+// NewReplaySource returns source of blocks stored by Tendermint in block store and state db
+func NewReplaySource(blockStore *store.BlockStore, stateDB db.DB) ReplaySource {
+	return &replaySource{
+		BlockStore: blockStore,
+		stateDB:    stateDB,
+	}
+}
+
+func (s *replaySource) LoadValidators(height int64) (*tmTypes.ValidatorSet, error) {
+	return sm.LoadValidators(s.stateDB, height)
+}
+
+// DivergenceError is returned by Replay if app hash of a re-executed block differs from the one committed by the network
+type DivergenceError struct {
+	Height   uint64
+	Expected []byte
+	Got      []byte
+
+	// Diff lists entries of exported committed state which are missing in re-executed one with "-" prefix, and
+	// entries of re-executed state which are missing in committed one with "+" prefix. It is nil if committed state
+	// is pruned.
+	Diff []string
+}
+
+func (e *DivergenceError) Error() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "app hash of block %d diverges: committed %X, replayed %X", e.Height, e.Expected, e.Got)
+
+	if e.Diff == nil {
+		b.WriteString(", committed state is pruned")
+		return b.String()
+	}
+
+	if len(e.Diff) == 0 {
+		b.WriteString(", exported states are equal")
+		return b.String()
+	}
+
+	for _, line := range e.Diff {
+		b.WriteString("\n")
+		b.WriteString(line)
+	}
+
+	return b.String()
+}
+
+// Replay re-executes blocks in range [from, to] against state at height from-1 and rebuilds events and coins index of
+// the range. App hash of every block is verified before commit: the one of the last committed block is taken from
+// application db, others are taken from headers of the next blocks. Replay stops at the first divergence with
+// *DivergenceError, the diverged block is not committed.
+// Kept versions of state are not overwritten, only verified, so replay doesn't roll the node back: application db is
+// restored when replay is finished, and coins index is rebuilt on the next start if replay is stopped before the last
+// committed height. Blockchain should be stopped after replay.
+func (app *Blockchain) Replay(source ReplaySource, from, to uint64, progress func(height uint64)) error {
+	lastHeight := app.LastCommittedHeight()
+	if from < 2 {
+		return fmt.Errorf("blocks can be replayed from height 2, the first block is executed on top of genesis")
+	}
+	if from > to || to > lastHeight {
+		return fmt.Errorf("wrong range of blocks %d-%d, the last committed height is %d", from, to, lastHeight)
+	}
+	if storeHeight := uint64(source.Height()); to > storeHeight {
+		return fmt.Errorf("block %d is not stored, the last stored height is %d", to, storeHeight)
+	}
+
+	lastHash, vals := app.appDB.GetLastBlockHash(), app.appDB.GetValidators()
+	defer func() {
+		app.appDB.SetLastBlockHash(lastHash)
+		app.appDB.SetLastHeight(lastHeight)
+		app.appDB.SaveValidators(vals)
+	}()
+
+	stateDeliver, err := state.NewStateForReplay(from-1, app.stateDB, app.eventsDB, app.cfg.StateCacheSize)
+	if err != nil {
+		return fmt.Errorf("cannot load state at height %d: %s", from-1, err)
+	}
+
+	if app.coinsIndex != nil {
+		if err := app.coinsIndex.Rebuild(stateDeliver.Export(from-1), from-1); err != nil {
+			return err
+		}
+		stateDeliver.SetCoinsIndex(app.coinsIndex)
+	}
+
+	app.stateDeliver = stateDeliver
+	app.resetCheckState()
+	atomic.StoreUint64(&app.height, from-1)
+
+	for height := from; height <= to; height++ {
+		expected := lastHash
+		if height != lastHeight {
+			meta := source.LoadBlockMeta(int64(height) + 1)
+			if meta == nil {
+				return fmt.Errorf("app hash of block %d is unknown, block %d is not stored", height, height+1)
+			}
+			expected = meta.Header.AppHash
+		}
+
+		if err := app.replayBlock(source, height, expected); err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(height)
+		}
+	}
+
+	return nil
+}
+
+// replayBlock executes block the way Tendermint does and commits it if app hash is equal to expected one
+func (app *Blockchain) replayBlock(source ReplaySource, height uint64, expected []byte) error {
+	block := source.LoadBlock(int64(height))
+	if block == nil {
+		return fmt.Errorf("block %d is not stored", height)
+	}
+
+	commitInfo, byzVals, err := getBeginBlockValidatorInfo(source, block)
+	if err != nil {
+		return err
+	}
+
+	if err := app.eventsDB.DeleteEvents(uint32(height)); err != nil {
+		return err
+	}
+
+	app.setBlocksTimeDelta(source, height, 3)
+
+	app.BeginBlock(abciTypes.RequestBeginBlock{
+		Hash:                block.Hash(),
+		Header:              tmTypes.TM2PB.Header(&block.Header),
+		LastCommitInfo:      commitInfo,
+		ByzantineValidators: byzVals,
+	})
+
+	for _, tx := range block.Txs {
+		app.DeliverTx(abciTypes.RequestDeliverTx{Tx: tx})
+	}
+
+	app.EndBlock(abciTypes.RequestEndBlock{Height: int64(height)})
+
+	hash, err := app.stateDeliver.WorkingHash()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(hash, expected) {
+		app.stateDeliver.Unlock()
+
+		return &DivergenceError{
+			Height:   height,
+			Expected: expected,
+			Got:      hash,
+			Diff:     app.diffCommittedState(height),
+		}
+	}
+
+	app.Commit()
+
+	return nil
+}
+
+// diffCommittedState compares committed state at given height with the working one, returns nil if committed state is pruned
+func (app *Blockchain) diffCommittedState(height uint64) []string {
+	committed, err := state.NewCheckStateAtHeight(height, app.stateDB)
+	if err != nil {
+		return nil
+	}
+
+	return diffAppStates(committed.Export(height), app.stateDeliver.ExportWorking(height))
+}
+
+// getBeginBlockValidatorInfo returns votes for the previous block and evidences of block, which Tendermint passes to BeginBlock
+func getBeginBlockValidatorInfo(source ReplaySource, block *tmTypes.Block) (abciTypes.LastCommitInfo, []abciTypes.Evidence, error) {
+	votes := make([]abciTypes.VoteInfo, block.LastCommit.Size())
+
+	// the first block has empty last commit
+	if block.Height > 1 {
+		lastVals, err := source.LoadValidators(block.Height - 1)
+		if err != nil {
+			return abciTypes.LastCommitInfo{}, nil, err
+		}
+
+		if len(lastVals.Validators) != len(votes) {
+			return abciTypes.LastCommitInfo{}, nil, fmt.Errorf("commit size %d doesn't match amount of validators %d at height %d", len(votes), len(lastVals.Validators), block.Height)
+		}
+
+		for i, val := range lastVals.Validators {
+			votes[i] = abciTypes.VoteInfo{
+				Validator:       tmTypes.TM2PB.Validator(val),
+				SignedLastBlock: !block.LastCommit.Signatures[i].Absent(),
+			}
+		}
+	}
+
+	byzVals := make([]abciTypes.Evidence, len(block.Evidence.Evidence))
+	for i, ev := range block.Evidence.Evidence {
+		vals, err := source.LoadValidators(ev.Height())
+		if err != nil {
+			return abciTypes.LastCommitInfo{}, nil, err
+		}
+
+		byzVals[i] = tmTypes.TM2PB.Evidence(ev, vals, block.Time)
+	}
+
+	return abciTypes.LastCommitInfo{
+		Round: int32(block.LastCommit.Round),
+		Votes: votes,
+	}, byzVals, nil
+}
+
+// diffAppStates lists fields and entries of lists of exported states which differ
+func diffAppStates(committed, replayed types.AppState) []string {
+	diff := []string{}
+
+	a, b := reflect.ValueOf(committed), reflect.ValueOf(replayed)
+	for i := 0; i < a.NumField(); i++ {
+		name := strings.Split(a.Type().Field(i).Tag.Get("json"), ",")[0]
+
+		if a.Field(i).Kind() != reflect.Slice {
+			if x, y := jsonString(a.Field(i)), jsonString(b.Field(i)); x != y {
+				diff = append(diff, fmt.Sprintf("- %s: %s", name, x), fmt.Sprintf("+ %s: %s", name, y))
+			}
+			continue
+		}
+
+		removed, added := diffEntries(a.Field(i), b.Field(i))
+		for _, entry := range removed {
+			diff = append(diff, fmt.Sprintf("- %s: %s", name, entry))
+		}
+		for _, entry := range added {
+			diff = append(diff, fmt.Sprintf("+ %s: %s", name, entry))
+		}
+	}
+
+	return diff
+}
+
+// diffEntries returns entries of committed list which are missing in replayed one and vice versa, in order of lists
+func diffEntries(committed, replayed reflect.Value) (removed []string, added []string) {
+	counts := map[string]int{}
+	for i := 0; i < replayed.Len(); i++ {
+		counts[jsonString(replayed.Index(i))]++
+	}
+
+	for i := 0; i < committed.Len(); i++ {
+		entry := jsonString(committed.Index(i))
+		if counts[entry] > 0 {
+			counts[entry]--
+			continue
+		}
+
+		removed = append(removed, entry)
+	}
+
+	for i := 0; i < replayed.Len(); i++ {
+		entry := jsonString(replayed.Index(i))
+		if counts[entry] > 0 {
+			counts[entry]--
+			added = append(added, entry)
+		}
+	}
+
+	return removed, added
+}
+
+func jsonString(v reflect.Value) string {
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprintf("%+v", v.Interface())
+	}
+
+	return string(data)
+}
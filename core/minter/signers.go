@@ -0,0 +1,41 @@
+package minter
+
+import (
+	"context"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// recoverProposedTxs recovers signatures of transactions of every complete proposal in parallel, so DeliverTx finds
+// signers of transactions which haven't passed CheckTx of this node cached. Recovery is an optimization, so it stops
+// silently when Tendermint's event bus is stopped.
+func (app *Blockchain) recoverProposedTxs() {
+	sub, err := app.tmNode.EventBus().Subscribe(context.Background(), "recover-proposed-txs", tmTypes.EventQueryCompleteProposal, 100)
+	if err != nil {
+		return
+	}
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			proposal, ok := msg.Data().(tmTypes.EventDataCompleteProposal)
+			if !ok {
+				continue
+			}
+
+			roundState := app.tmNode.ConsensusState().GetRoundState()
+			if roundState.Height != proposal.Height || roundState.ProposalBlock == nil {
+				continue
+			}
+
+			txs := make([][]byte, len(roundState.ProposalBlock.Txs))
+			for i, tx := range roundState.ProposalBlock.Txs {
+				txs[i] = tx
+			}
+
+			transaction.RecoveredSigners.RecoverAll(txs)
+		case <-sub.Cancelled():
+			return
+		}
+	}
+}
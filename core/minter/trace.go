@@ -0,0 +1,105 @@
+package minter
+
+import (
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/state/bus"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	tmTypes "github.com/tendermint/tendermint/types"
+	"math/big"
+	"sync"
+)
+
+// TraceTx re-executes transaction with given index of block txs at given height against state of the previous height,
+// recording it by tracer. Preceding transactions of the block are re-executed without tracing.
+// Of changes made in BeginBlock, only payouts of unbonded stakes and scheduled commissions are applied before the
+// transactions, penalties of validators are not. Neither state nor events are changed.
+func (app *Blockchain) TraceTx(height uint64, txs tmTypes.Txs, index int, tracer bus.Tracer) (transaction.Response, error) {
+	if height < 2 || height > app.LastCommittedHeight() {
+		return transaction.Response{}, fmt.Errorf("transactions of block %d can't be traced", height)
+	}
+	if index < 0 || index >= len(txs) {
+		return transaction.Response{}, fmt.Errorf("block %d has no transaction with index %d", height, index)
+	}
+
+	blockTracer := &txTracer{Tracer: tracer}
+	deliverState, err := state.NewStateAtHeight(height-1, app.stateDB, blockTracer)
+	if err != nil {
+		return transaction.Response{}, &PrunedStateError{Height: height - 1, Available: app.stateDeliver.Tree().AvailableVersions()}
+	}
+
+	if frozenFunds := deliverState.FrozenFunds.GetFrozenFunds(height); frozenFunds != nil {
+		for _, item := range frozenFunds.List {
+			deliverState.Accounts.AddBalance(item.Address, item.Coin, item.Value)
+		}
+		deliverState.FrozenFunds.Delete(frozenFunds.Height())
+	}
+	if height >= upgrades.UpgradeBlock2 {
+		deliverState.Candidates.ApplyPendingCommissions(height)
+	}
+
+	rewards := big.NewInt(0)
+	for _, tx := range txs[:index] {
+		transaction.RunTx(deliverState, tx, rewards, height, &sync.Map{}, 0)
+	}
+
+	blockTracer.tracing = true
+	return transaction.RunTx(deliverState, txs[index], rewards, height, &sync.Map{}, 0), nil
+}
+
+// txTracer passes to tracer only reads and writes made while tracing is on, so preceding transactions of the block
+// are re-executed untraced
+type txTracer struct {
+	bus.Tracer
+	tracing bool
+}
+
+func (t *txTracer) OnBalanceRead(address types.Address, coin types.CoinID, value *big.Int) {
+	if t.tracing {
+		t.Tracer.OnBalanceRead(address, coin, value)
+	}
+}
+
+func (t *txTracer) OnBalanceWrite(address types.Address, coin types.CoinID, before, after *big.Int) {
+	if t.tracing {
+		t.Tracer.OnBalanceWrite(address, coin, before, after)
+	}
+}
+
+func (t *txTracer) OnCoinRead(coin types.CoinID, volume, reserve *big.Int, crr uint32) {
+	if t.tracing {
+		t.Tracer.OnCoinRead(coin, volume, reserve, crr)
+	}
+}
+
+func (t *txTracer) OnVolumeWrite(coin types.CoinID, before, after *big.Int) {
+	if t.tracing {
+		t.Tracer.OnVolumeWrite(coin, before, after)
+	}
+}
+
+func (t *txTracer) OnReserveWrite(coin types.CoinID, before, after *big.Int) {
+	if t.tracing {
+		t.Tracer.OnReserveWrite(coin, before, after)
+	}
+}
+
+func (t *txTracer) OnStakeRead(owner types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int) {
+	if t.tracing {
+		t.Tracer.OnStakeRead(owner, pubkey, coin, value)
+	}
+}
+
+func (t *txTracer) OnStakeWrite(owner types.Address, pubkey types.Pubkey, coin types.CoinID, before, after *big.Int) {
+	if t.tracing {
+		t.Tracer.OnStakeWrite(owner, pubkey, coin, before, after)
+	}
+}
+
+func (t *txTracer) OnFormula(name string, supply, reserve *big.Int, crr uint32, amount, result *big.Int) {
+	if t.tracing {
+		t.Tracer.OnFormula(name, supply, reserve, crr, amount, result)
+	}
+}
@@ -36,6 +36,16 @@ func GetRewardForBlock(blockHeight uint64) *big.Int {
 	return helpers.BipToPip(reward)
 }
 
+// GetEmissionBefore returns sum of rewards for blocks before given height, including blocks before genesis
+func GetEmissionBefore(blockHeight uint64) *big.Int {
+	emission := big.NewInt(0).Set(beforeGenesis)
+	for i := uint64(1); i < blockHeight; i++ {
+		emission.Add(emission, GetRewardForBlock(i))
+	}
+
+	return emission
+}
+
 // SetStartHeight sets base height for rewards calculations
 func SetStartHeight(sHeight uint64) {
 	for i := uint64(1); i <= sHeight; i++ {
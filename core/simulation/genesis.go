@@ -0,0 +1,101 @@
+package simulation
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/state/candidates"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"math/big"
+)
+
+var (
+	genesisBalance = helpers.BipToPip(big.NewInt(1000000))
+	genesisStake   = helpers.BipToPip(big.NewInt(1000000))
+
+	genesisCoinVolume  = helpers.BipToPip(big.NewInt(1000000))
+	genesisCoinReserve = helpers.BipToPip(big.NewInt(100000))
+)
+
+// genesis generates accounts and returns genesis state in which every account has base coin and a coin with reserve,
+// and the first accounts own candidates of validators
+func (sim *Simulation) genesis() types.AppState {
+	for len(sim.accounts) < sim.cfg.Accounts {
+		seed := make([]byte, 32)
+		sim.rng.Read(seed)
+
+		key, err := crypto.ToECDSA(seed)
+		if err != nil {
+			continue
+		}
+
+		sim.accounts = append(sim.accounts, Account{Address: crypto.PubkeyToAddress(key.PublicKey), Key: key})
+	}
+
+	appState := types.AppState{
+		StartHeight:  1,
+		TotalSlashed: "0",
+	}
+
+	coinID := uint64(types.GetBaseCoinID()) + 1
+	owner := sim.accounts[0].Address
+	appState.Coins = append(appState.Coins, types.Coin{
+		ID:           coinID,
+		Name:         "Simulation coin",
+		Symbol:       types.StrToCoinSymbol("SIMULATION"),
+		Volume:       genesisCoinVolume.String(),
+		Crr:          50,
+		Reserve:      genesisCoinReserve.String(),
+		MaxSupply:    helpers.BipToPip(big.NewInt(1000000000000000)).String(),
+		OwnerAddress: &owner,
+	})
+
+	coinBalance := big.NewInt(0).Div(genesisCoinVolume, big.NewInt(int64(len(sim.accounts))))
+	coinRemainder := big.NewInt(0).Sub(genesisCoinVolume, big.NewInt(0).Mul(coinBalance, big.NewInt(int64(len(sim.accounts)))))
+	for i, account := range sim.accounts {
+		balance := coinBalance
+		if i == 0 {
+			balance = big.NewInt(0).Add(coinBalance, coinRemainder)
+		}
+
+		appState.Accounts = append(appState.Accounts, types.Account{
+			Address: account.Address,
+			Balance: []types.Balance{
+				{Coin: uint64(types.GetBaseCoinID()), Value: genesisBalance.String()},
+				{Coin: coinID, Value: balance.String()},
+			},
+		})
+	}
+
+	for i := 0; i < sim.cfg.Validators; i++ {
+		var pubkey types.Pubkey
+		sim.rng.Read(pubkey[:])
+
+		owner := sim.accounts[i%len(sim.accounts)].Address
+		appState.Validators = append(appState.Validators, types.Validator{
+			TotalBipStake: genesisStake.String(),
+			PubKey:        pubkey,
+			AccumReward:   "0",
+			AbsentTimes:   types.NewBitArray(24),
+		})
+		appState.Candidates = append(appState.Candidates, types.Candidate{
+			ID:             uint64(i) + 1,
+			RewardAddress:  owner,
+			OwnerAddress:   owner,
+			ControlAddress: owner,
+			TotalBipStake:  genesisStake.String(),
+			PubKey:         pubkey,
+			Commission:     10,
+			Stakes: []types.Stake{
+				{
+					Owner:    owner,
+					Coin:     uint64(types.GetBaseCoinID()),
+					Value:    genesisStake.String(),
+					BipValue: genesisStake.String(),
+				},
+			},
+			Status: candidates.CandidateStatusOnline,
+		})
+	}
+
+	return appState
+}
@@ -0,0 +1,149 @@
+package simulation
+
+import (
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"math/big"
+)
+
+// Invariant is a property of state which holds after every block. State is checked right after commit, exported is
+// the committed state exported as genesis.
+type Invariant struct {
+	Name  string
+	Check func(s *state.State, exported types.AppState) error
+}
+
+// DefaultInvariants returns invariants of coins, base coin supply and stakes
+func DefaultInvariants() []Invariant {
+	return []Invariant{
+		{Name: "genesis", Check: exportIsValidGenesis},
+		{Name: "supply", Check: supplyIsHeld},
+		{Name: "coins", Check: coinsAreBacked},
+		{Name: "stakes", Check: stakesArePositive},
+	}
+}
+
+// exportIsValidGenesis checks that state can be exported, and volumes of coins are equal to amounts held by accounts,
+// stakes, wait list and frozen funds
+func exportIsValidGenesis(_ *state.State, exported types.AppState) error {
+	return exported.Verify()
+}
+
+// supplyIsHeld checks that supply of base coin, which is changed only by emission, is equal to the sum of base coin
+// holdings, and totals of frozen and waitlisted coins are equal to the sums of frozen funds and waitlist. Supply is not
+// tracked before UpgradeBlock2.
+func supplyIsHeld(s *state.State, exported types.AppState) error {
+	if !s.App.IsSupplyTracked() {
+		return nil
+	}
+
+	held := exported.BaseCoinSupply()
+	if supply := s.App.GetSupply(); supply.Cmp(held) != 0 {
+		return fmt.Errorf("supply of base coin is %s, but %s is held (%s)", supply, held, big.NewInt(0).Sub(held, supply))
+	}
+
+	frozen := map[types.CoinID]*big.Int{}
+	for _, ff := range exported.FrozenFunds {
+		addValue(frozen, types.CoinID(ff.Coin), helpers.StringToBigInt(ff.Value))
+	}
+	if err := totalsAreEqual("frozen", s.App.GetFrozenTotals(), frozen); err != nil {
+		return err
+	}
+
+	waitlist := map[types.CoinID]*big.Int{}
+	for _, item := range exported.Waitlist {
+		addValue(waitlist, types.CoinID(item.Coin), helpers.StringToBigInt(item.Value))
+	}
+
+	return totalsAreEqual("waitlisted", s.App.GetWaitlistTotals(), waitlist)
+}
+
+func addValue(values map[types.CoinID]*big.Int, coin types.CoinID, value *big.Int) {
+	if values[coin] == nil {
+		values[coin] = big.NewInt(0)
+	}
+
+	values[coin].Add(values[coin], value)
+}
+
+func totalsAreEqual(name string, totals, held map[types.CoinID]*big.Int) error {
+	for coin, value := range held {
+		if value.Sign() != 0 && (totals[coin] == nil || totals[coin].Cmp(value) != 0) {
+			return fmt.Errorf("total of %s coin %s is %v, but %s is held", name, coin, totals[coin], value)
+		}
+	}
+
+	for coin, value := range totals {
+		if held[coin] == nil {
+			return fmt.Errorf("total of %s coin %s is %s, but none is held", name, coin, value)
+		}
+	}
+
+	return nil
+}
+
+// coinsAreBacked checks that coins with reserve have positive reserve and crr within allowed range, and volumes of all
+// coins do not exceed max supply
+func coinsAreBacked(_ *state.State, exported types.AppState) error {
+	for _, coin := range exported.Coins {
+		volume, reserve, maxSupply := helpers.StringToBigInt(coin.Volume), helpers.StringToBigInt(coin.Reserve), helpers.StringToBigInt(coin.MaxSupply)
+		if volume.Sign() < 0 || reserve.Sign() < 0 {
+			return fmt.Errorf("coin %s has negative volume %s or reserve %s", coin.Symbol, volume, reserve)
+		}
+
+		if volume.Cmp(maxSupply) == 1 {
+			return fmt.Errorf("volume %s of coin %s exceeds max supply %s", volume, coin.Symbol, maxSupply)
+		}
+
+		if coin.Crr == 0 {
+			if reserve.Sign() != 0 {
+				return fmt.Errorf("token %s has reserve %s", coin.Symbol, reserve)
+			}
+			continue
+		}
+
+		if coin.Crr < 10 || coin.Crr > 100 {
+			return fmt.Errorf("coin %s has crr %d", coin.Symbol, coin.Crr)
+		}
+
+		if volume.Sign() != 0 && reserve.Sign() == 0 {
+			return fmt.Errorf("coin %s has volume %s without reserve", coin.Symbol, volume)
+		}
+	}
+
+	return nil
+}
+
+// stakesArePositive checks that stakes, wait list and frozen funds do not keep negative values. Zero values are allowed,
+// they are left by delegations and unbonds of zero value.
+func stakesArePositive(_ *state.State, exported types.AppState) error {
+	for _, candidate := range exported.Candidates {
+		for _, stakes := range [][]types.Stake{candidate.Stakes, candidate.Updates} {
+			for _, stake := range stakes {
+				if helpers.StringToBigInt(stake.Value).Sign() < 0 {
+					return fmt.Errorf("stake of %s in candidate %s has value %s", stake.Owner.String(), candidate.PubKey.String(), stake.Value)
+				}
+			}
+		}
+
+		if helpers.StringToBigInt(candidate.TotalBipStake).Sign() < 0 {
+			return fmt.Errorf("candidate %s has total stake %s", candidate.PubKey.String(), candidate.TotalBipStake)
+		}
+	}
+
+	for _, item := range exported.Waitlist {
+		if helpers.StringToBigInt(item.Value).Sign() < 0 {
+			return fmt.Errorf("wait list of %s has value %s", item.Owner.String(), item.Value)
+		}
+	}
+
+	for _, ff := range exported.FrozenFunds {
+		if helpers.StringToBigInt(ff.Value).Sign() < 0 {
+			return fmt.Errorf("frozen fund of %s has value %s", ff.Address.String(), ff.Value)
+		}
+	}
+
+	return nil
+}
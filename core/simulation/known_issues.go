@@ -0,0 +1,67 @@
+package simulation
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"math/big"
+)
+
+// KnownIssue is a defect of transactions which is known and not fixed yet. Operations matching it are skipped instead
+// of being delivered, so that simulation keeps finding other defects.
+type KnownIssue struct {
+	Name  string
+	Match func(s *state.State, op Operation) bool
+}
+
+// DefaultKnownIssues returns known defects of buy, sell and edit candidate public key transactions
+func DefaultKnownIssues() []KnownIssue {
+	return []KnownIssue{
+		{Name: "buy_above_reserve", Match: buysBaseCoinAboveReserve},
+		{Name: "sell_above_volume", Match: sellsCoinAboveVolume},
+		{Name: "edit_validator_public_key", Match: editsPublicKeyOfValidator},
+	}
+}
+
+// buysBaseCoinAboveReserve matches buying more base coin than reserve of sold coin, sale amount formula panics on it
+func buysBaseCoinAboveReserve(s *state.State, op Operation) bool {
+	data, ok := op.Data.(transaction.BuyCoinData)
+	if !ok || !data.CoinToBuy.IsBaseCoin() || data.CoinToSell.IsBaseCoin() {
+		return false
+	}
+
+	coin := s.Coins.GetCoin(data.CoinToSell)
+	if coin == nil || data.ValueToBuy == nil {
+		return false
+	}
+
+	value := big.NewInt(0).Mul(big.NewInt(data.Gas()), transaction.CommissionMultiplier)
+	value.Add(value, data.ValueToBuy)
+
+	return coin.Reserve().Cmp(value) < 0
+}
+
+// sellsCoinAboveVolume matches selling more coins than their volume, sale return formula is undefined on it
+func sellsCoinAboveVolume(s *state.State, op Operation) bool {
+	data, ok := op.Data.(transaction.SellCoinData)
+	if !ok || data.CoinToSell.IsBaseCoin() {
+		return false
+	}
+
+	coin := s.Coins.GetCoin(data.CoinToSell)
+	if coin == nil || data.ValueToSell == nil {
+		return false
+	}
+
+	return coin.Volume().Cmp(data.ValueToSell) < 0
+}
+
+// editsPublicKeyOfValidator matches changing public key of a candidate which is a validator, the validator keeps the
+// old key and its accumulated reward is lost
+func editsPublicKeyOfValidator(s *state.State, op Operation) bool {
+	data, ok := op.Data.(transaction.EditCandidatePublicKeyData)
+	if !ok {
+		return false
+	}
+
+	return s.Validators.GetByPublicKey(data.PubKey) != nil
+}
@@ -0,0 +1,183 @@
+package simulation
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"math/big"
+	"reflect"
+)
+
+const symbolLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+const nameLetters = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+var (
+	typeAddress    = reflect.TypeOf(types.Address{})
+	typeCoinID     = reflect.TypeOf(types.CoinID(0))
+	typePubkey     = reflect.TypeOf(types.Pubkey{})
+	typeCoinSymbol = reflect.TypeOf(types.CoinSymbol{})
+	typeBigInt     = reflect.TypeOf(big.NewInt(0))
+)
+
+// randomOperations returns random transactions of all registered types from random accounts
+func (sim *Simulation) randomOperations(height uint64) []Operation {
+	txTypes := transaction.TxDecoder.RegisteredTypes()
+
+	ops := make([]Operation, sim.rng.Intn(sim.cfg.TxsPerBlock+1))
+	for i := range ops {
+		txType := txTypes[sim.rng.Intn(len(txTypes))]
+
+		ops[i] = Operation{
+			Height:  height,
+			Sender:  sim.rng.Intn(len(sim.accounts)),
+			GasCoin: types.GetBaseCoinID(),
+			Type:    txType,
+			Data:    sim.randomData(txType),
+		}
+
+		if sim.rng.Intn(5) == 0 {
+			ops[i].GasCoin = sim.randomCoin()
+		}
+	}
+
+	return ops
+}
+
+// randomData fills data of tx type with random values. Values of domain types are mostly taken from existing accounts,
+// coins and candidates, so that a fair share of transactions passes checks and changes state.
+func (sim *Simulation) randomData(txType transaction.TxType) transaction.Data {
+	zero, err := transaction.TxDecoder.NewData(txType)
+	if err != nil {
+		panic(err)
+	}
+
+	data := reflect.New(reflect.TypeOf(zero)).Elem()
+	sim.randomValue(data, "")
+
+	return data.Interface().(transaction.Data)
+}
+
+func (sim *Simulation) randomValue(v reflect.Value, tag string) {
+	switch v.Type() {
+	case typeAddress:
+		v.Set(reflect.ValueOf(sim.randomAddress()))
+		return
+	case typeCoinID:
+		v.Set(reflect.ValueOf(sim.randomCoin()))
+		return
+	case typePubkey:
+		v.Set(reflect.ValueOf(sim.randomPubkey()))
+		return
+	case typeCoinSymbol:
+		v.Set(reflect.ValueOf(sim.randomSymbol()))
+		return
+	case typeBigInt:
+		v.Set(reflect.ValueOf(sim.randomAmount()))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Field(i); field.CanSet() {
+				sim.randomValue(field, v.Type().Field(i).Tag.Get("rlp"))
+			}
+		}
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		sim.randomValue(v.Elem(), tag)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, sim.rng.Intn(64))
+			sim.rng.Read(b)
+			v.SetBytes(b)
+			return
+		}
+
+		// optional tail values are mostly omitted
+		length := 1 + sim.rng.Intn(3)
+		if tag == "tail" && sim.rng.Intn(4) != 0 {
+			length = 0
+		}
+
+		v.Set(reflect.MakeSlice(v.Type(), length, length))
+		for i := 0; i < length; i++ {
+			sim.randomValue(v.Index(i), "")
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			sim.randomValue(v.Index(i), "")
+		}
+	case reflect.String:
+		v.SetString(sim.randomString(nameLetters, 3, 12))
+	case reflect.Bool:
+		v.SetBool(sim.rng.Intn(2) == 0)
+	case reflect.Uint8:
+		v.SetUint(uint64(sim.rng.Intn(256)))
+	case reflect.Uint16, reflect.Uint32:
+		// crr, commission, threshold, weights and prices are small
+		v.SetUint(uint64(sim.rng.Intn(110)))
+	case reflect.Uint64:
+		// heights are near the current one
+		v.SetUint(sim.height + uint64(sim.rng.Intn(100)))
+	}
+}
+
+func (sim *Simulation) randomAddress() types.Address {
+	if sim.rng.Intn(10) == 0 {
+		var address types.Address
+		sim.rng.Read(address[:])
+		return address
+	}
+
+	return sim.accounts[sim.rng.Intn(len(sim.accounts))].Address
+}
+
+func (sim *Simulation) randomCoin() types.CoinID {
+	return types.CoinID(sim.rng.Intn(int(sim.state.App.GetNextCoinID()) + 1))
+}
+
+func (sim *Simulation) randomPubkey() types.Pubkey {
+	candidates := sim.state.Candidates.GetCandidates()
+	if len(candidates) == 0 || sim.rng.Intn(5) == 0 {
+		var pubkey types.Pubkey
+		sim.rng.Read(pubkey[:])
+		return pubkey
+	}
+
+	return candidates[sim.rng.Intn(len(candidates))].PubKey
+}
+
+func (sim *Simulation) randomSymbol() types.CoinSymbol {
+	if coin := sim.state.Coins.GetCoin(sim.randomCoin()); coin != nil && sim.rng.Intn(2) == 0 {
+		return coin.Symbol()
+	}
+
+	return types.StrToCoinSymbol(sim.randomString(symbolLetters, 3, 10))
+}
+
+// randomAmount returns zero, a few pips or an amount comparable to balances, stakes and reserves
+func (sim *Simulation) randomAmount() *big.Int {
+	switch sim.rng.Intn(10) {
+	case 0:
+		return big.NewInt(0)
+	case 1:
+		return big.NewInt(1 + sim.rng.Int63n(1000000))
+	case 2:
+		return helpers.BipToPip(big.NewInt(1 + sim.rng.Int63n(1000000000)))
+	case 3, 4:
+		return helpers.BipToPip(big.NewInt(1 + sim.rng.Int63n(100000)))
+	default:
+		return helpers.BipToPip(big.NewInt(1 + sim.rng.Int63n(1000)))
+	}
+}
+
+func (sim *Simulation) randomString(letters string, min, max int) string {
+	b := make([]byte, min+sim.rng.Intn(max-min+1))
+	for i := range b {
+		b[i] = letters[sim.rng.Intn(len(letters))]
+	}
+
+	return string(b)
+}
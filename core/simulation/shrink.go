@@ -0,0 +1,41 @@
+package simulation
+
+// Shrink looks for a minimal subset of operations of failure, which breaks the same invariant when it is replayed with
+// the same seed and config. Chunks of operations are removed while failure reproduces, chunk size is halved when no
+// chunk can be removed.
+func Shrink(failure *Failure, cfg Config) *Failure {
+	reproduce := func(ops []Operation) *Failure {
+		result := New(failure.Seed, cfg).Replay(append([]Operation(nil), ops...), failure.Height)
+		if result == nil || result.Invariant != failure.Invariant {
+			return nil
+		}
+
+		return result
+	}
+
+	smallest := failure
+	ops := failure.Operations
+	for chunk := (len(ops) + 1) / 2; chunk >= 1 && len(ops) != 0; {
+		removed := false
+		for i := 0; i < len(ops); {
+			end := i + chunk
+			if end > len(ops) {
+				end = len(ops)
+			}
+
+			candidate := append(append([]Operation(nil), ops[:i]...), ops[end:]...)
+			if result := reproduce(candidate); result != nil {
+				smallest, ops, removed = result, result.Operations, true
+				continue
+			}
+
+			i = end
+		}
+
+		if !removed {
+			chunk /= 2
+		}
+	}
+
+	return smallest
+}
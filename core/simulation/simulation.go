@@ -0,0 +1,386 @@
+// Package simulation runs random sequences of transactions against state and checks invariants of state after every
+// block. Simulation is driven by seed: the same seed and config always produce the same genesis, transactions and app
+// hashes, so a failing seed is a reproduction. Failures are shrunk to a minimal set of transactions by Shrink.
+package simulation
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	eventsdb "github.com/MinterTeam/minter-go-node/core/events"
+	"github.com/MinterTeam/minter-go-node/core/rewards"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/core/validators"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	db "github.com/tendermint/tm-db"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Config is a config of simulation
+type Config struct {
+	// StartHeight is a height of genesis, transactions introduced at upgrade blocks are available only after them
+	StartHeight uint64
+	// Blocks is amount of simulated blocks
+	Blocks int
+	// TxsPerBlock is a maximal amount of transactions in a block
+	TxsPerBlock int
+	// Accounts is amount of accounts sending transactions
+	Accounts int
+	// Validators is amount of validators in genesis, their candidates are owned by the first accounts
+	Validators int
+	// RecalculateInterval is an interval in blocks of paying rewards and recalculating stakes, it is 120 in the network
+	RecalculateInterval uint64
+	// Invariants are checked after every block
+	Invariants []Invariant
+	// KnownIssues are defects of transactions which are not fixed yet, matching operations are skipped
+	KnownIssues []KnownIssue
+}
+
+// DefaultConfig returns config of a short simulation which checks all invariants
+func DefaultConfig() Config {
+	return Config{
+		StartHeight:         upgrades.UpgradeBlock2,
+		Blocks:              100,
+		TxsPerBlock:         20,
+		Accounts:            10,
+		Validators:          4,
+		RecalculateInterval: 12,
+		Invariants:          DefaultInvariants(),
+		KnownIssues:         DefaultKnownIssues(),
+	}
+}
+
+// Account is an account sending transactions
+type Account struct {
+	Address types.Address
+	Key     *ecdsa.PrivateKey
+}
+
+// Operation is a transaction of simulation. Nonce is taken from state when operation is delivered, so any subset of
+// operations can be replayed.
+type Operation struct {
+	Height  uint64
+	Sender  int
+	GasCoin types.CoinID
+	Type    transaction.TxType
+	Data    transaction.Data
+}
+
+func (op Operation) String() string {
+	return fmt.Sprintf("height %d: account %d sends tx 0x%02X with gas coin %s, data %+v", op.Height, op.Sender, byte(op.Type), op.GasCoin, op.Data)
+}
+
+// TxStats is amount of delivered, failed and skipped as known issues transactions of a type
+type TxStats struct {
+	Delivered int
+	Failed    int
+	Skipped   int
+}
+
+// Simulation is a chain of blocks with random transactions run against state.State
+type Simulation struct {
+	seed int64
+	cfg  Config
+	rng  *rand.Rand
+
+	state    *state.State
+	events   eventsdb.IEventsDB
+	accounts []Account
+
+	height   uint64
+	hash     []byte
+	rewards  *big.Int
+	executed []Operation
+	stats    map[transaction.TxType]*TxStats
+}
+
+// New creates simulation with random genesis generated from seed
+func New(seed int64, cfg Config) *Simulation {
+	sim := &Simulation{
+		seed:   seed,
+		cfg:    cfg,
+		rng:    rand.New(rand.NewSource(seed)),
+		height: cfg.StartHeight,
+		stats:  map[transaction.TxType]*TxStats{},
+	}
+
+	sim.events = eventsdb.NewEventsStore(db.NewMemDB())
+
+	var err error
+	sim.state, err = state.NewState(0, db.NewMemDB(), sim.events, 1, 1)
+	if err != nil {
+		panic(err)
+	}
+
+	genesis := sim.genesis()
+
+	// supply of base coin is tracked since UpgradeBlock2
+	if sim.height >= upgrades.UpgradeBlock2 {
+		genesis.Emission = "0"
+		genesis.Supply = genesis.BaseCoinSupply().String()
+	}
+
+	if err := sim.state.Import(genesis); err != nil {
+		panic(err)
+	}
+
+	return sim
+}
+
+// Run simulates blocks with random transactions and returns the first failure
+func (sim *Simulation) Run() *Failure {
+	for sim.height < sim.cfg.StartHeight+uint64(sim.cfg.Blocks) {
+		if failure := sim.block(sim.randomOperations(sim.height + 1)); failure != nil {
+			return failure
+		}
+	}
+
+	return nil
+}
+
+// Replay simulates blocks up to height with given operations instead of random ones and returns the first failure
+func (sim *Simulation) Replay(ops []Operation, height uint64) *Failure {
+	sort.SliceStable(ops, func(i, j int) bool {
+		return ops[i].Height < ops[j].Height
+	})
+
+	for sim.height < height {
+		var block []Operation
+		for len(ops) != 0 && ops[0].Height <= sim.height+1 {
+			block, ops = append(block, ops[0]), ops[1:]
+		}
+
+		if failure := sim.block(block); failure != nil {
+			return failure
+		}
+	}
+
+	return nil
+}
+
+// Height returns height of the last simulated block
+func (sim *Simulation) Height() uint64 {
+	return sim.height
+}
+
+// Hash returns app hash of the last simulated block
+func (sim *Simulation) Hash() []byte {
+	return sim.hash
+}
+
+// Stats returns amount of delivered and failed transactions by types
+func (sim *Simulation) Stats() map[transaction.TxType]*TxStats {
+	return sim.stats
+}
+
+// block delivers operations as a block the way Blockchain does: frozen funds are paid at the beginning of block,
+// transactions' commissions are added to block reward, rewards are paid and stakes are recalculated every
+// RecalculateInterval blocks. Invariants are checked after commit.
+func (sim *Simulation) block(ops []Operation) (failure *Failure) {
+	sim.height++
+	height := sim.height
+
+	defer func() {
+		if r := recover(); r != nil {
+			failure = sim.fail("panic", fmt.Errorf("%v", r))
+		}
+	}()
+
+	sim.beginBlock(height)
+
+	for _, op := range ops {
+		op.Height = height
+		sim.executed = append(sim.executed, op)
+		sim.deliver(op)
+	}
+
+	sim.endBlock(height)
+
+	if err := sim.state.Check(); err != nil {
+		return sim.fail("checker", err)
+	}
+
+	hash, err := sim.state.Commit()
+	if err != nil {
+		return sim.fail("commit", err)
+	}
+	sim.hash = hash
+
+	if err := sim.events.CommitEvents(); err != nil {
+		return sim.fail("commit", err)
+	}
+
+	exported := sim.state.ExportWorking(height)
+	for _, invariant := range sim.cfg.Invariants {
+		if err := invariant.Check(sim.state, exported); err != nil {
+			return sim.fail(invariant.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (sim *Simulation) beginBlock(height uint64) {
+	sim.rewards = big.NewInt(0)
+
+	frozenFunds := sim.state.FrozenFunds.GetFrozenFunds(height)
+	if frozenFunds != nil {
+		for _, item := range frozenFunds.List {
+			sim.state.Accounts.AddBalance(item.Address, item.Coin, item.Value)
+		}
+
+		sim.state.FrozenFunds.Delete(frozenFunds.Height())
+	}
+
+	if height >= upgrades.UpgradeBlock2 {
+		sim.state.Candidates.ApplyPendingCommissions(height)
+	}
+	sim.state.Halts.Delete(height)
+}
+
+func (sim *Simulation) endBlock(height uint64) {
+	vals := sim.state.Validators.GetValidators()
+
+	hasDroppedValidators := false
+	totalPower := big.NewInt(0)
+	for _, val := range vals {
+		if val.IsToDrop() {
+			hasDroppedValidators = true
+			sim.rewards.Add(sim.rewards, val.GetAccumReward())
+			val.SetAccumReward(big.NewInt(0))
+			continue
+		}
+
+		totalPower.Add(totalPower, val.GetTotalBipStake())
+	}
+
+	if totalPower.Sign() == 0 {
+		totalPower = big.NewInt(1)
+	}
+
+	reward := rewards.GetRewardForBlock(height)
+	if sim.state.App.IsSupplyTracked() {
+		sim.state.App.AddEmission(reward)
+	} else {
+		sim.state.Checker.AddCoinVolume(types.GetBaseCoinID(), reward)
+	}
+	reward.Add(reward, sim.rewards)
+
+	remainder := big.NewInt(0).Set(reward)
+	for _, val := range vals {
+		if val.IsToDrop() {
+			continue
+		}
+
+		r := big.NewInt(0).Mul(reward, val.GetTotalBipStake())
+		r.Div(r, totalPower)
+
+		remainder.Sub(remainder, r)
+		val.AddAccumReward(r)
+	}
+	sim.state.App.AddTotalSlashed(remainder)
+
+	if height%sim.cfg.RecalculateInterval == 0 {
+		sim.state.Validators.PayRewards(height)
+	}
+
+	if height >= upgrades.UpgradeBlock2 {
+		sim.state.Checks.DeleteExpired(height)
+	}
+
+	hasChangedPublicKeys := sim.state.Candidates.IsChangedPublicKeys()
+	if hasChangedPublicKeys {
+		sim.state.Candidates.ResetIsChangedPublicKeys()
+	}
+
+	if height%sim.cfg.RecalculateInterval == 0 || hasDroppedValidators || hasChangedPublicKeys {
+		sim.state.Candidates.RecalculateStakes(height)
+		sim.state.Validators.SetNewValidators(sim.state.Candidates.GetNewCandidates(validators.GetValidatorsCountForBlock(height)))
+	}
+}
+
+// deliver signs operation by its sender and runs it against state. Panics of transactions are reported as failures.
+func (sim *Simulation) deliver(op Operation) {
+	stats, ok := sim.stats[op.Type]
+	if !ok {
+		stats = &TxStats{}
+		sim.stats[op.Type] = stats
+	}
+
+	for _, issue := range sim.cfg.KnownIssues {
+		if issue.Match(sim.state, op) {
+			stats.Skipped++
+			return
+		}
+	}
+
+	account := sim.accounts[op.Sender]
+
+	data, err := rlp.EncodeToBytes(op.Data)
+	if err != nil {
+		panic(err)
+	}
+
+	tx := transaction.Transaction{
+		Nonce:         sim.state.Accounts.GetNonce(account.Address) + 1,
+		ChainID:       types.CurrentChainID,
+		GasPrice:      1,
+		GasCoin:       op.GasCoin,
+		Type:          op.Type,
+		Data:          data,
+		SignatureType: transaction.SigTypeSingle,
+	}
+
+	if err := tx.Sign(account.Key); err != nil {
+		panic(err)
+	}
+
+	rawTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		panic(err)
+	}
+
+	response := transaction.RunTx(sim.state, rawTx, sim.rewards, op.Height, &sync.Map{}, 0)
+	if response.Code != 0 {
+		stats.Failed++
+		return
+	}
+
+	stats.Delivered++
+}
+
+func (sim *Simulation) fail(invariant string, err error) *Failure {
+	return &Failure{
+		Seed:       sim.seed,
+		Height:     sim.height,
+		Invariant:  invariant,
+		Err:        err,
+		Operations: append([]Operation(nil), sim.executed...),
+	}
+}
+
+// Failure is a broken invariant or a panic of simulation. Operations are all operations delivered up to the failure.
+type Failure struct {
+	Seed       int64
+	Height     uint64
+	Invariant  string
+	Err        error
+	Operations []Operation
+}
+
+func (f *Failure) Error() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "seed %d: %s invariant is broken at height %d: %s\n", f.Seed, f.Invariant, f.Height, f.Err)
+	_, _ = fmt.Fprintf(&b, "operations (%d):\n", len(f.Operations))
+	for _, op := range f.Operations {
+		_, _ = fmt.Fprintf(&b, "  %s\n", op)
+	}
+
+	return b.String()
+}
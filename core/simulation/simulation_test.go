@@ -0,0 +1,115 @@
+package simulation
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"math/big"
+	"testing"
+)
+
+var (
+	flagSeed   = flag.Int64("simulation.seed", 1, "first seed of simulation")
+	flagSeeds  = flag.Int("simulation.seeds", 3, "amount of simulated seeds")
+	flagBlocks = flag.Int("simulation.blocks", 0, "amount of simulated blocks, default config is used if 0")
+)
+
+func TestSimulation(t *testing.T) {
+	cfg := DefaultConfig()
+	if *flagBlocks != 0 {
+		cfg.Blocks = *flagBlocks
+	}
+
+	seeds := *flagSeeds
+	if testing.Short() {
+		seeds = 1
+	}
+
+	for seed := *flagSeed; seed < *flagSeed+int64(seeds); seed++ {
+		sim := New(seed, cfg)
+		if failure := sim.Run(); failure != nil {
+			t.Fatal(Shrink(failure, cfg))
+		}
+
+		for _, txType := range transaction.TxDecoder.RegisteredTypes() {
+			if stats := sim.Stats()[txType]; stats != nil {
+				t.Logf("seed %d: tx 0x%02X delivered %d, failed %d, skipped %d", seed, byte(txType), stats.Delivered, stats.Failed, stats.Skipped)
+			}
+		}
+	}
+}
+
+func TestSimulation_Deterministic(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Blocks = 30
+
+	first, second := New(7, cfg), New(7, cfg)
+	if failure := first.Run(); failure != nil {
+		t.Fatal(failure)
+	}
+	if failure := second.Run(); failure != nil {
+		t.Fatal(failure)
+	}
+
+	if !bytes.Equal(first.Hash(), second.Hash()) {
+		t.Fatalf("app hashes of the same seed differ: %x != %x", first.Hash(), second.Hash())
+	}
+
+	replayed := New(7, cfg)
+	if failure := replayed.Replay(first.executed, first.Height()); failure != nil {
+		t.Fatal(failure)
+	}
+
+	if !bytes.Equal(first.Hash(), replayed.Hash()) {
+		t.Fatalf("app hash of replay differs: %x != %x", replayed.Hash(), first.Hash())
+	}
+}
+
+func TestShrink(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Blocks = 30
+
+	// fails as soon as any coin is sent to the second account
+	var target types.Address
+	cfg.Invariants = []Invariant{{
+		Name: "no_sends",
+		Check: func(s *state.State, _ types.AppState) error {
+			for _, balance := range s.Accounts.GetBalances(target) {
+				if balance.Coin.ID.IsBaseCoin() && balance.Value.Cmp(genesisBalance) == 1 {
+					return errors.New("balance of the second account is increased")
+				}
+			}
+
+			return nil
+		},
+	}}
+
+	sim := New(3, cfg)
+	target = sim.accounts[1].Address
+	height := sim.Height()
+
+	ops := []Operation{
+		{Height: height + 1, Sender: 2, Type: transaction.TypeSetCandidateOnline, Data: transaction.SetCandidateOnData{}},
+		{Height: height + 2, Sender: 3, Type: transaction.TypeSend, Data: transaction.SendData{To: sim.accounts[4].Address, Value: genesisBalance}},
+		{Height: height + 2, Sender: 0, Type: transaction.TypeSend, Data: transaction.SendData{To: target, Value: helpers.BipToPip(big.NewInt(1000))}},
+		{Height: height + 3, Sender: 5, Type: transaction.TypeSend, Data: transaction.SendData{To: target, Value: helpers.BipToPip(big.NewInt(1000))}},
+		{Height: height + 5, Sender: 6, Type: transaction.TypeSetCandidateOffline, Data: transaction.SetCandidateOffData{}},
+	}
+	for i := range ops {
+		ops[i].GasCoin = types.GetBaseCoinID()
+	}
+
+	failure := sim.Replay(ops, height+10)
+	if failure == nil {
+		t.Fatal("replay does not fail")
+	}
+
+	shrunk := Shrink(failure, cfg)
+	if len(shrunk.Operations) != 1 || shrunk.Operations[0].Sender != 0 || shrunk.Height != height+2 {
+		t.Fatalf("failure is not shrunk to the first send:\n%s", shrunk)
+	}
+}
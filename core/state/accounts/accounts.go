@@ -103,6 +103,10 @@ func (a *Accounts) Commit() error {
 				} else {
 					a.iavl.Set(path, balance.Bytes())
 				}
+
+				if coinsIndex := a.bus.CoinsIndex(); coinsIndex != nil {
+					coinsIndex.SetBalance(address, coin, balance)
+				}
 			}
 
 			account.dirtyBalances = map[types.CoinID]struct{}{}
@@ -126,11 +130,18 @@ func (a *Accounts) getOrderedDirtyAccounts() []types.Address {
 }
 
 func (a *Accounts) AddBalance(address types.Address, coin types.CoinID, amount *big.Int) {
-	balance := a.GetBalance(address, coin)
+	balance := a.getBalance(address, coin)
 	a.SetBalance(address, coin, big.NewInt(0).Add(balance, amount))
 }
 
 func (a *Accounts) GetBalance(address types.Address, coin types.CoinID) *big.Int {
+	balance := a.getBalance(address, coin)
+	a.bus.Tracer().OnBalanceRead(address, coin, balance)
+
+	return balance
+}
+
+func (a *Accounts) getBalance(address types.Address, coin types.CoinID) *big.Int {
 	account := a.getOrNew(address)
 	if !account.hasCoin(coin) {
 		return big.NewInt(0)
@@ -156,14 +167,15 @@ func (a *Accounts) GetBalance(address types.Address, coin types.CoinID) *big.Int
 }
 
 func (a *Accounts) SubBalance(address types.Address, coin types.CoinID, amount *big.Int) {
-	balance := big.NewInt(0).Sub(a.GetBalance(address, coin), amount)
+	balance := big.NewInt(0).Sub(a.getBalance(address, coin), amount)
 	a.SetBalance(address, coin, balance)
 }
 
 func (a *Accounts) SetBalance(address types.Address, coin types.CoinID, amount *big.Int) {
 	account := a.getOrNew(address)
-	oldBalance := a.GetBalance(address, coin)
+	oldBalance := a.getBalance(address, coin)
 	a.bus.Checker().AddCoin(coin, big.NewInt(0).Sub(amount, oldBalance))
+	a.bus.Tracer().OnBalanceWrite(address, coin, oldBalance, amount)
 
 	account.setBalance(coin, amount)
 }
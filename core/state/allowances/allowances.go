@@ -0,0 +1,293 @@
+package allowances
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/core/state/bus"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+const mainPrefix = byte('l')
+
+type RAllowances interface {
+	Export(state *types.AppState, height uint64)
+	Get(owner types.Address, spender types.Address, coin types.CoinID) *Item
+	GetByOwner(owner types.Address) *Model
+}
+
+// Allowances stores amounts of coins which spenders are allowed to transfer from owners' accounts.
+// Allowances are not locked on owner's account, so owner's balance is checked on every transfer.
+type Allowances struct {
+	list  map[types.Address]*Model
+	dirty map[types.Address]struct{}
+
+	bus  *bus.Bus
+	iavl tree.MTree
+
+	lock sync.RWMutex
+}
+
+func NewAllowances(stateBus *bus.Bus, iavl tree.MTree) (*Allowances, error) {
+	allowances := &Allowances{
+		bus:   stateBus,
+		iavl:  iavl,
+		list:  map[types.Address]*Model{},
+		dirty: map[types.Address]struct{}{},
+	}
+	allowances.bus.SetAllowances(NewBus(allowances))
+
+	return allowances, nil
+}
+
+func (a *Allowances) Commit() error {
+	dirty := a.getOrderedDirty()
+	for _, owner := range dirty {
+		model := a.getFromMap(owner)
+
+		a.lock.Lock()
+		delete(a.dirty, owner)
+		a.lock.Unlock()
+
+		path := getPath(owner)
+
+		if len(model.List) == 0 {
+			a.lock.Lock()
+			delete(a.list, owner)
+			a.lock.Unlock()
+
+			a.iavl.Remove(path)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(model)
+		if err != nil {
+			return fmt.Errorf("can't encode object at %s: %v", owner.String(), err)
+		}
+
+		a.iavl.Set(path, data)
+	}
+
+	return nil
+}
+
+// Get returns a copy of allowance of spender to transfer owner's coin, nil if there is none
+func (a *Allowances) Get(owner types.Address, spender types.Address, coin types.CoinID) *Item {
+	model := a.get(owner)
+	if model == nil {
+		return nil
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	item := model.get(spender, coin)
+	if item == nil {
+		return nil
+	}
+
+	itemCopy := item.copy()
+	return &itemCopy
+}
+
+func (a *Allowances) GetByOwner(owner types.Address) *Model {
+	return a.get(owner)
+}
+
+// Approve sets allowance of spender to transfer owner's coin, replacing the previous one.
+// Zero value removes the allowance. The first period of limited allowance starts at given height.
+// Expired allowances of owner are removed.
+func (a *Allowances) Approve(owner types.Address, spender types.Address, coin types.CoinID, value *big.Int, expireHeight uint64, period uint64, periodLimit *big.Int, height uint64) {
+	a.removeExpired(owner, height)
+
+	if value.Sign() == 0 {
+		a.Delete(owner, spender, coin)
+		return
+	}
+
+	if period == 0 {
+		periodLimit = big.NewInt(0)
+	}
+
+	a.SetAllowance(owner, Item{
+		Spender:      spender,
+		Coin:         coin,
+		Value:        big.NewInt(0).Set(value),
+		ExpireHeight: expireHeight,
+		Period:       period,
+		PeriodLimit:  big.NewInt(0).Set(periodLimit),
+		PeriodStart:  height,
+		PeriodSpent:  big.NewInt(0),
+	})
+}
+
+// SetAllowance stores allowance as is. Used for import of genesis.
+func (a *Allowances) SetAllowance(owner types.Address, item Item) {
+	model := a.getOrNew(owner)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	model.set(item.copy())
+}
+
+// Spend decreases allowance by transferred value. Allowance is removed when it is spent completely,
+// expired allowances of owner are removed as well.
+func (a *Allowances) Spend(owner types.Address, spender types.Address, coin types.CoinID, value *big.Int, height uint64) {
+	model := a.get(owner)
+	if model == nil {
+		panic(fmt.Sprintf("allowance of %s is not found for %s", spender.String(), owner.String()))
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	model.removeExpired(height)
+
+	item := model.get(spender, coin)
+	if item == nil {
+		panic(fmt.Sprintf("allowance of %s is not found for %s", spender.String(), owner.String()))
+	}
+
+	item.spend(value, height)
+	if item.Value.Sign() != 1 {
+		model.remove(spender, coin)
+		return
+	}
+
+	model.markDirty(owner)
+}
+
+func (a *Allowances) Delete(owner types.Address, spender types.Address, coin types.CoinID) {
+	model := a.get(owner)
+	if model == nil {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if model.get(spender, coin) == nil {
+		return
+	}
+
+	model.remove(spender, coin)
+}
+
+// Export exports allowances which are not expired at given height
+func (a *Allowances) Export(state *types.AppState, height uint64) {
+	a.iavl.IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		owner := types.BytesToAddress(key[1:])
+		model := a.get(owner)
+		if model == nil {
+			return false
+		}
+
+		for _, item := range model.List {
+			if item.ExpireHeight != 0 && item.ExpireHeight <= height {
+				continue
+			}
+
+			state.Allowances = append(state.Allowances, types.Allowance{
+				Owner:        owner,
+				Spender:      item.Spender,
+				Coin:         uint64(item.Coin),
+				Value:        item.Value.String(),
+				ExpireHeight: item.ExpireHeight,
+				Period:       item.Period,
+				PeriodLimit:  item.PeriodLimit.String(),
+				PeriodStart:  item.PeriodStart,
+				PeriodSpent:  item.PeriodSpent.String(),
+			})
+		}
+
+		return false
+	})
+}
+
+func (a *Allowances) removeExpired(owner types.Address, height uint64) {
+	model := a.get(owner)
+	if model == nil {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	model.removeExpired(height)
+}
+
+func (a *Allowances) getOrNew(owner types.Address) *Model {
+	model := a.get(owner)
+	if model == nil {
+		model = &Model{owner: owner, markDirty: a.markDirty}
+		a.setToMap(owner, model)
+	}
+
+	return model
+}
+
+func (a *Allowances) get(owner types.Address) *Model {
+	if model := a.getFromMap(owner); model != nil {
+		return model
+	}
+
+	_, enc := a.iavl.Get(getPath(owner))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	model := &Model{}
+	if err := rlp.DecodeBytes(enc, model); err != nil {
+		panic(fmt.Sprintf("failed to decode allowances for address %s: %s", owner.String(), err))
+	}
+
+	model.owner = owner
+	model.markDirty = a.markDirty
+
+	a.setToMap(owner, model)
+
+	return model
+}
+
+func (a *Allowances) getFromMap(owner types.Address) *Model {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.list[owner]
+}
+
+func (a *Allowances) setToMap(owner types.Address, model *Model) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.list[owner] = model
+}
+
+func (a *Allowances) markDirty(owner types.Address) {
+	a.dirty[owner] = struct{}{}
+}
+
+func (a *Allowances) getOrderedDirty() []types.Address {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	keys := make([]types.Address, 0, len(a.dirty))
+	for k := range a.dirty {
+		keys = append(keys, k)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) == 1
+	})
+
+	return keys
+}
+
+func getPath(owner types.Address) []byte {
+	return append([]byte{mainPrefix}, owner.Bytes()...)
+}
@@ -0,0 +1,144 @@
+package allowances
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/state/bus"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+	"math/big"
+	"testing"
+)
+
+func TestAllowancesToSpend(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	a, err := NewAllowances(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner, spender, coin := types.Address{0}, types.Address{1}, types.GetBaseCoinID()
+
+	a.Approve(owner, spender, coin, big.NewInt(100), 0, 10, big.NewInt(30), 1)
+	if err := a.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err = NewAllowances(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a.Spend(owner, spender, coin, big.NewInt(20), 5)
+	item := a.Get(owner, spender, coin)
+	if item == nil {
+		t.Fatal("Allowance not found")
+	}
+
+	if item.Available(10).Cmp(big.NewInt(10)) != 0 {
+		t.Fatalf("Wrong available allowance in period: %s", item.Available(10))
+	}
+
+	if item.Available(11).Cmp(big.NewInt(30)) != 0 {
+		t.Fatalf("Wrong available allowance in next period: %s", item.Available(11))
+	}
+
+	a.Spend(owner, spender, coin, big.NewInt(30), 25)
+	item = a.Get(owner, spender, coin)
+	if item.PeriodStart != 21 || item.PeriodSpent.Cmp(big.NewInt(30)) != 0 {
+		t.Fatalf("Wrong period %d with spent %s", item.PeriodStart, item.PeriodSpent)
+	}
+
+	a.Spend(owner, spender, coin, big.NewInt(50), 100)
+	if err := a.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Get(owner, spender, coin) != nil {
+		t.Fatal("Spent allowance is not removed")
+	}
+
+	if _, value := mutableTree.Get(getPath(owner)); len(value) != 0 {
+		t.Fatal("Empty allowances list is not removed from state")
+	}
+}
+
+func TestAllowancesExport(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	a, err := NewAllowances(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner, coin := types.Address{0}, types.GetBaseCoinID()
+
+	a.Approve(owner, types.Address{1}, coin, big.NewInt(100), 0, 0, big.NewInt(0), 1)
+	a.Approve(owner, types.Address{2}, coin, big.NewInt(100), 10, 0, big.NewInt(0), 1)
+	if err := a.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := new(types.AppState)
+	a.Export(state, 10)
+
+	if len(state.Allowances) != 1 {
+		t.Fatalf("Wrong amount of exported allowances: %d", len(state.Allowances))
+	}
+
+	if state.Allowances[0].Owner != owner || state.Allowances[0].Spender != (types.Address{1}) || state.Allowances[0].Value != "100" {
+		t.Fatal("Wrong exported allowance")
+	}
+}
+
+func TestAllowancesRemoveExpired(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	a, err := NewAllowances(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner, coin := types.Address{0}, types.GetBaseCoinID()
+
+	a.Approve(owner, types.Address{1}, coin, big.NewInt(100), 10, 0, big.NewInt(0), 1)
+	a.Approve(owner, types.Address{2}, coin, big.NewInt(100), 20, 0, big.NewInt(0), 1)
+	a.Approve(owner, types.Address{3}, coin, big.NewInt(100), 0, 0, big.NewInt(0), 1)
+	if err := a.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	a.Spend(owner, types.Address{3}, coin, big.NewInt(10), 11)
+	if a.Get(owner, types.Address{1}, coin) != nil {
+		t.Fatal("Expired allowance is not removed on spend")
+	}
+
+	if a.Get(owner, types.Address{2}, coin) == nil {
+		t.Fatal("Allowance is removed before expiration")
+	}
+
+	a.Approve(owner, types.Address{3}, coin, big.NewInt(0), 0, 0, big.NewInt(0), 21)
+	if err := a.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Get(owner, types.Address{2}, coin) != nil {
+		t.Fatal("Expired allowance is not removed on approve")
+	}
+
+	if _, value := mutableTree.Get(getPath(owner)); len(value) != 0 {
+		t.Fatal("Empty allowances list is not removed from state")
+	}
+}
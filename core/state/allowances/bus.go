@@ -0,0 +1,23 @@
+package allowances
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"math/big"
+)
+
+type Bus struct {
+	allowances *Allowances
+}
+
+func (b *Bus) GetAllowance(owner types.Address, spender types.Address, coin types.CoinID, height uint64) *big.Int {
+	item := b.allowances.Get(owner, spender, coin)
+	if item == nil {
+		return big.NewInt(0)
+	}
+
+	return item.Available(height)
+}
+
+func NewBus(allowances *Allowances) *Bus {
+	return &Bus{allowances: allowances}
+}
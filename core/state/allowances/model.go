@@ -0,0 +1,141 @@
+package allowances
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"math/big"
+)
+
+// Item is an allowance of spender to transfer owner's coins
+type Item struct {
+	Spender      types.Address
+	Coin         types.CoinID
+	Value        *big.Int
+	ExpireHeight uint64 // 0 if allowance never expires
+	Period       uint64 // 0 if allowance has no per-period limit
+	PeriodLimit  *big.Int
+	PeriodStart  uint64
+	PeriodSpent  *big.Int
+}
+
+// IsExpired returns true if allowance can't be spent at given height
+func (i *Item) IsExpired(height uint64) bool {
+	return i.ExpireHeight != 0 && height > i.ExpireHeight
+}
+
+// SpentInPeriod returns amount of coins spent in the period which includes given height
+func (i *Item) SpentInPeriod(height uint64) *big.Int {
+	if i.Period == 0 || height >= i.PeriodStart+i.Period {
+		return big.NewInt(0)
+	}
+
+	return big.NewInt(0).Set(i.PeriodSpent)
+}
+
+// Available returns amount of coins which can be spent at given height
+func (i *Item) Available(height uint64) *big.Int {
+	if i.IsExpired(height) {
+		return big.NewInt(0)
+	}
+
+	available := big.NewInt(0).Set(i.Value)
+	if i.Period == 0 {
+		return available
+	}
+
+	periodAvailable := big.NewInt(0).Sub(i.PeriodLimit, i.SpentInPeriod(height))
+	if periodAvailable.Cmp(available) == -1 {
+		available = periodAvailable
+	}
+
+	if available.Sign() == -1 {
+		return big.NewInt(0)
+	}
+
+	return available
+}
+
+func (i *Item) spend(value *big.Int, height uint64) {
+	i.Value.Sub(i.Value, value)
+
+	if i.Period == 0 {
+		return
+	}
+
+	if height >= i.PeriodStart+i.Period {
+		i.PeriodStart += (height - i.PeriodStart) / i.Period * i.Period
+		i.PeriodSpent = big.NewInt(0)
+	}
+
+	i.PeriodSpent.Add(i.PeriodSpent, value)
+}
+
+func (i Item) copy() Item {
+	i.Value = big.NewInt(0).Set(i.Value)
+	i.PeriodLimit = big.NewInt(0).Set(i.PeriodLimit)
+	i.PeriodSpent = big.NewInt(0).Set(i.PeriodSpent)
+
+	return i
+}
+
+// Model is a list of allowances granted by owner
+type Model struct {
+	List []Item
+
+	owner     types.Address
+	markDirty func(owner types.Address)
+}
+
+func (m *Model) get(spender types.Address, coin types.CoinID) *Item {
+	for i := range m.List {
+		if m.List[i].Spender == spender && m.List[i].Coin == coin {
+			return &m.List[i]
+		}
+	}
+
+	return nil
+}
+
+func (m *Model) set(item Item) {
+	defer m.markDirty(m.owner)
+
+	for i := range m.List {
+		if m.List[i].Spender == item.Spender && m.List[i].Coin == item.Coin {
+			m.List[i] = item
+			return
+		}
+	}
+
+	m.List = append(m.List, item)
+}
+
+func (m *Model) remove(spender types.Address, coin types.CoinID) {
+	items := make([]Item, 0, len(m.List))
+	for _, item := range m.List {
+		if item.Spender != spender || item.Coin != coin {
+			items = append(items, item)
+		}
+	}
+
+	m.List = items
+	m.markDirty(m.owner)
+}
+
+func (m *Model) removeExpired(height uint64) {
+	items := make([]Item, 0, len(m.List))
+	for _, item := range m.List {
+		if !item.IsExpired(height) {
+			items = append(items, item)
+		}
+	}
+
+	if len(items) == len(m.List) {
+		return
+	}
+
+	m.List = items
+	m.markDirty(m.owner)
+}
+
+func (m *Model) Owner() types.Address {
+	return m.owner
+}
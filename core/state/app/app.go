@@ -10,6 +10,7 @@ import (
 )
 
 const mainPrefix = 'd'
+const supplyPrefix = 's'
 
 type RApp interface {
 	Export(state *types.AppState, height uint64)
@@ -17,6 +18,11 @@ type RApp interface {
 	GetTotalSlashed() *big.Int
 	GetCoinsCount() uint32
 	GetNextCoinID() types.CoinID
+	GetEmission() *big.Int
+	GetSupply() *big.Int
+	GetFrozenTotals() map[types.CoinID]*big.Int
+	GetWaitlistTotals() map[types.CoinID]*big.Int
+	IsSupplyTracked() bool
 }
 
 func (v *App) Tree() tree.ReadOnlyTree {
@@ -27,6 +33,9 @@ type App struct {
 	model   *Model
 	isDirty bool
 
+	supply        *Supply
+	isSupplyDirty bool
+
 	bus  *bus.Bus
 	iavl tree.MTree
 }
@@ -39,6 +48,17 @@ func NewApp(stateBus *bus.Bus, iavl tree.MTree) (*App, error) {
 }
 
 func (v *App) Commit() error {
+	if v.isSupplyDirty {
+		v.isSupplyDirty = false
+
+		data, err := rlp.EncodeToBytes(v.supply)
+		if err != nil {
+			return fmt.Errorf("can't encode supply model: %s", err)
+		}
+
+		v.iavl.Set([]byte{supplyPrefix}, data)
+	}
+
 	if !v.isDirty {
 		return nil
 	}
@@ -83,6 +103,125 @@ func (v *App) AddTotalSlashed(amount *big.Int) {
 	v.bus.Checker().AddCoin(types.GetBaseCoinID(), amount)
 }
 
+// GetEmission returns amount of base coin emitted by block rewards
+func (v *App) GetEmission() *big.Int {
+	return v.getOrNewSupply().getEmission()
+}
+
+// GetSupply returns amount of base coin in existence, including reserves of coins, stakes, waitlist, frozen funds
+// and total slashed. Slashes only move base coin to total slashed, so supply is changed by emission only.
+// Emission and supply are tracked since UpgradeBlock2, both are 0 before it.
+func (v *App) GetSupply() *big.Int {
+	return v.getOrNewSupply().getTotal()
+}
+
+// IsSupplyTracked reports whether emission and supply of base coin are initialized
+func (v *App) IsSupplyTracked() bool {
+	return v.getSupply() != nil
+}
+
+// InitSupply sets emission and supply of base coin, which are tracked by AddEmission since then. Totals of frozen and
+// waitlisted coins are empty and tracked since then too.
+func (v *App) InitSupply(emission, total *big.Int) {
+	v.supply = &Supply{
+		Emission:  big.NewInt(0).Set(emission),
+		Total:     big.NewInt(0).Set(total),
+		markDirty: v.markSupplyDirty,
+	}
+	v.isSupplyDirty = true
+}
+
+// AddEmission adds block reward to emission and supply of base coin. Emitted value is added to volume of base coin
+// in checker, so invariants check that it is exactly the amount by which balances of base coin are changed.
+// Supply should be initialized by InitSupply before.
+func (v *App) AddEmission(amount *big.Int) {
+	if amount.Sign() == 0 {
+		return
+	}
+
+	supply := v.getSupply()
+	if supply == nil {
+		panic("supply of base coin is not initialized")
+	}
+
+	supply.setEmission(big.NewInt(0).Add(supply.getEmission(), amount))
+	supply.setTotal(big.NewInt(0).Add(supply.getTotal(), amount))
+	v.bus.Checker().AddCoinVolume(types.GetBaseCoinID(), amount)
+}
+
+// AddFrozenTotal adds value to total of frozen funds of coin. Totals are tracked along with supply, since it is
+// initialized.
+func (v *App) AddFrozenTotal(coin types.CoinID, value *big.Int) {
+	if supply := v.getSupply(); supply != nil {
+		supply.addFrozen(coin, value)
+	}
+}
+
+// AddWaitlistTotal adds value to total of waitlisted coin. Totals are tracked along with supply, since it is
+// initialized.
+func (v *App) AddWaitlistTotal(coin types.CoinID, value *big.Int) {
+	if supply := v.getSupply(); supply != nil {
+		supply.addWaitlist(coin, value)
+	}
+}
+
+// GetFrozenTotals returns totals of frozen funds by coins, it's empty while supply is not tracked
+func (v *App) GetFrozenTotals() map[types.CoinID]*big.Int {
+	return coinValuesMap(v.getOrNewSupply().Frozen)
+}
+
+// GetWaitlistTotals returns totals of waitlisted coins, it's empty while supply is not tracked
+func (v *App) GetWaitlistTotals() map[types.CoinID]*big.Int {
+	return coinValuesMap(v.getOrNewSupply().Waitlist)
+}
+
+func coinValuesMap(values []CoinValue) map[types.CoinID]*big.Int {
+	result := make(map[types.CoinID]*big.Int, len(values))
+	for _, item := range values {
+		result[item.Coin] = big.NewInt(0).Set(item.Value)
+	}
+
+	return result
+}
+
+func (v *App) getSupply() *Supply {
+	if v.supply != nil {
+		return v.supply
+	}
+
+	_, enc := v.iavl.Get([]byte{supplyPrefix})
+	if len(enc) == 0 {
+		return nil
+	}
+
+	supply := &Supply{}
+	if err := rlp.DecodeBytes(enc, supply); err != nil {
+		panic(fmt.Sprintf("failed to decode supply model: %s", err))
+	}
+
+	v.supply = supply
+	v.supply.markDirty = v.markSupplyDirty
+	return v.supply
+}
+
+// getOrNewSupply returns supply, which is zero and not stored until it is initialized
+func (v *App) getOrNewSupply() *Supply {
+	supply := v.getSupply()
+	if supply == nil {
+		return &Supply{
+			Emission:  big.NewInt(0),
+			Total:     big.NewInt(0),
+			markDirty: func() {},
+		}
+	}
+
+	return supply
+}
+
+func (v *App) markSupplyDirty() {
+	v.isSupplyDirty = true
+}
+
 func (v *App) get() *Model {
 	if v.model != nil {
 		return v.model
@@ -143,4 +282,9 @@ func (v *App) Export(state *types.AppState, height uint64) {
 	state.MaxGas = v.GetMaxGas()
 	state.TotalSlashed = v.GetTotalSlashed().String()
 	state.StartHeight = height
+
+	if v.IsSupplyTracked() {
+		state.Emission = v.GetEmission().String()
+		state.Supply = v.GetSupply().String()
+	}
 }
@@ -1,6 +1,9 @@
 package app
 
-import "math/big"
+import (
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"math/big"
+)
 
 type Bus struct {
 	app *App
@@ -10,6 +13,14 @@ func (b *Bus) AddTotalSlashed(amount *big.Int) {
 	b.app.AddTotalSlashed(amount)
 }
 
+func (b *Bus) AddFrozenTotal(coin types.CoinID, value *big.Int) {
+	b.app.AddFrozenTotal(coin, value)
+}
+
+func (b *Bus) AddWaitlistTotal(coin types.CoinID, value *big.Int) {
+	b.app.AddWaitlistTotal(coin, value)
+}
+
 func NewBus(app *App) *Bus {
 	return &Bus{app: app}
 }
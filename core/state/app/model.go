@@ -1,6 +1,10 @@
 package app
 
-import "math/big"
+import (
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"math/big"
+	"sort"
+)
 
 type Model struct {
 	TotalSlashed *big.Int
@@ -47,3 +51,75 @@ func (model *Model) setCoinsCount(count uint32) {
 
 	model.CoinsCount = count
 }
+
+// Supply is emission and total supply of base coin, and totals of frozen and waitlisted coins ordered by coin id
+type Supply struct {
+	Emission *big.Int
+	Total    *big.Int
+	Frozen   []CoinValue
+	Waitlist []CoinValue
+
+	markDirty func()
+}
+
+// CoinValue is a total value of a coin
+type CoinValue struct {
+	Coin  types.CoinID
+	Value *big.Int
+}
+
+func (supply *Supply) getEmission() *big.Int {
+	return supply.Emission
+}
+
+func (supply *Supply) setEmission(emission *big.Int) {
+	if supply.Emission.Cmp(emission) != 0 {
+		supply.markDirty()
+	}
+	supply.Emission = emission
+}
+
+func (supply *Supply) getTotal() *big.Int {
+	return supply.Total
+}
+
+func (supply *Supply) setTotal(total *big.Int) {
+	if supply.Total.Cmp(total) != 0 {
+		supply.markDirty()
+	}
+	supply.Total = total
+}
+
+func (supply *Supply) addFrozen(coin types.CoinID, value *big.Int) {
+	if value.Sign() != 0 {
+		supply.Frozen = addCoinValue(supply.Frozen, coin, value)
+		supply.markDirty()
+	}
+}
+
+func (supply *Supply) addWaitlist(coin types.CoinID, value *big.Int) {
+	if value.Sign() != 0 {
+		supply.Waitlist = addCoinValue(supply.Waitlist, coin, value)
+		supply.markDirty()
+	}
+}
+
+// addCoinValue adds value to total of coin keeping totals ordered by coin id, zero totals are removed
+func addCoinValue(values []CoinValue, coin types.CoinID, value *big.Int) []CoinValue {
+	i := sort.Search(len(values), func(i int) bool {
+		return values[i].Coin >= coin
+	})
+
+	if i == len(values) || values[i].Coin != coin {
+		values = append(values, CoinValue{})
+		copy(values[i+1:], values[i:])
+		values[i] = CoinValue{Coin: coin, Value: big.NewInt(0)}
+	}
+
+	values[i].Value = big.NewInt(0).Add(values[i].Value, value)
+	if values[i].Value.Sign() == 0 {
+		values = append(values[:i], values[i+1:]...)
+	}
+
+	return values
+}
@@ -0,0 +1,10 @@
+package bus
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"math/big"
+)
+
+type Allowances interface {
+	GetAllowance(types.Address, types.Address, types.CoinID, uint64) *big.Int
+}
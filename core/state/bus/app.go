@@ -1,7 +1,12 @@
 package bus
 
-import "math/big"
+import (
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"math/big"
+)
 
 type App interface {
 	AddTotalSlashed(*big.Int)
+	AddFrozenTotal(types.CoinID, *big.Int)
+	AddWaitlistTotal(types.CoinID, *big.Int)
 }
@@ -10,8 +10,12 @@ type Bus struct {
 	frozenfunds FrozenFunds
 	halts       HaltBlocks
 	waitlist    WaitList
+	allowances  Allowances
+	names       Names
 	events      eventsdb.IEventsDB
 	checker     Checker
+	coinsIndex  CoinsIndex
+	tracer      Tracer
 }
 
 func NewBus() *Bus {
@@ -74,6 +78,22 @@ func (b *Bus) WaitList() WaitList {
 	return b.waitlist
 }
 
+func (b *Bus) SetAllowances(allowances Allowances) {
+	b.allowances = allowances
+}
+
+func (b *Bus) Allowances() Allowances {
+	return b.allowances
+}
+
+func (b *Bus) SetNames(names Names) {
+	b.names = names
+}
+
+func (b *Bus) Names() Names {
+	return b.names
+}
+
 func (b *Bus) SetEvents(events eventsdb.IEventsDB) {
 	b.events = events
 }
@@ -89,3 +109,29 @@ func (b *Bus) SetChecker(checker Checker) {
 func (b *Bus) Checker() Checker {
 	return b.checker
 }
+
+func (b *Bus) SetCoinsIndex(coinsIndex CoinsIndex) {
+	b.coinsIndex = coinsIndex
+}
+
+func (b *Bus) CoinsIndex() CoinsIndex {
+	return b.coinsIndex
+}
+
+func (b *Bus) SetTracer(tracer Tracer) {
+	b.tracer = tracer
+}
+
+// Tracer returns tracer set to the bus, or tracer which records nothing if there is none
+func (b *Bus) Tracer() Tracer {
+	if b.tracer == nil {
+		return noopTracer{}
+	}
+
+	return b.tracer
+}
+
+// IsTracing returns true if tracer is set to the bus, so modules can skip reads made only for the tracer
+func (b *Bus) IsTracing() bool {
+	return b.tracer != nil
+}
@@ -0,0 +1,13 @@
+package bus
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"math/big"
+)
+
+// CoinsIndex is an optional node-side index of coins and their holders, which is fed by state on commit
+type CoinsIndex interface {
+	SetCoin(Coin)
+	SetBalance(types.Address, types.CoinID, *big.Int)
+	SetStakes(uint32, []Stake)
+}
@@ -0,0 +1,7 @@
+package bus
+
+import "github.com/MinterTeam/minter-go-node/core/types"
+
+type Names interface {
+	ResolveAddress(string, uint64) (types.Address, bool)
+}
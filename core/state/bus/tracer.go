@@ -0,0 +1,30 @@
+package bus
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"math/big"
+)
+
+// Tracer records state reads and writes made by modules and formula calculations made by transactions.
+// Passed values may be changed after the call, so they have to be copied if kept.
+type Tracer interface {
+	OnBalanceRead(address types.Address, coin types.CoinID, value *big.Int)
+	OnBalanceWrite(address types.Address, coin types.CoinID, before, after *big.Int)
+	OnCoinRead(coin types.CoinID, volume, reserve *big.Int, crr uint32)
+	OnVolumeWrite(coin types.CoinID, before, after *big.Int)
+	OnReserveWrite(coin types.CoinID, before, after *big.Int)
+	OnStakeRead(owner types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int)
+	OnStakeWrite(owner types.Address, pubkey types.Pubkey, coin types.CoinID, before, after *big.Int)
+	OnFormula(name string, supply, reserve *big.Int, crr uint32, amount, result *big.Int)
+}
+
+type noopTracer struct{}
+
+func (noopTracer) OnBalanceRead(types.Address, types.CoinID, *big.Int)                        {}
+func (noopTracer) OnBalanceWrite(types.Address, types.CoinID, *big.Int, *big.Int)             {}
+func (noopTracer) OnCoinRead(types.CoinID, *big.Int, *big.Int, uint32)                        {}
+func (noopTracer) OnVolumeWrite(types.CoinID, *big.Int, *big.Int)                             {}
+func (noopTracer) OnReserveWrite(types.CoinID, *big.Int, *big.Int)                            {}
+func (noopTracer) OnStakeRead(types.Address, types.Pubkey, types.CoinID, *big.Int)            {}
+func (noopTracer) OnStakeWrite(types.Address, types.Pubkey, types.CoinID, *big.Int, *big.Int) {}
+func (noopTracer) OnFormula(string, *big.Int, *big.Int, uint32, *big.Int, *big.Int)           {}
@@ -465,6 +465,11 @@ func TestCandidates_GetTotalStake_fromModelAndFromDB(t *testing.T) {
 		t.Fatal(err)
 	}
 	b.SetAccounts(accounts.NewBus(accs))
+	appBus, err := app.NewApp(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetApp(appBus)
 	b.SetChecker(checker.NewChecker(b))
 	candidates, err := NewCandidates(b, mutableTree)
 	if err != nil {
@@ -870,6 +875,71 @@ func TestCandidates_SubStake(t *testing.T) {
 	}
 }
 
+func TestCandidates_GetDelegations(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	candidates, err := NewCandidates(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10)
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{5}, 10)
+	candidates.SetStakes([32]byte{4}, []types.Stake{
+		{
+			Owner:    [20]byte{1},
+			Coin:     0,
+			Value:    "100",
+			BipValue: "100",
+		},
+	}, nil)
+	candidates.Delegate([20]byte{1}, [32]byte{5}, 0, big.NewInt(100), big.NewInt(100))
+	candidates.Delegate([20]byte{2}, [32]byte{5}, 0, big.NewInt(100), big.NewInt(100))
+
+	err = candidates.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates, err = NewCandidates(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates.LoadCandidatesDeliver()
+	candidates.LoadStakes()
+
+	delegations := candidates.GetDelegations([20]byte{1})
+	if len(delegations) != 2 {
+		t.Fatalf("delegations count %d", len(delegations))
+	}
+
+	if delegations[0].PubKey != [32]byte{4} || delegations[1].PubKey != [32]byte{5} {
+		t.Fatal("delegations error")
+	}
+
+	candidates.SubStake([20]byte{1}, [32]byte{4}, 0, big.NewInt(100))
+
+	err = candidates.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	delegations = candidates.GetDelegations([20]byte{1})
+	if len(delegations) != 1 || delegations[0].PubKey != [32]byte{5} {
+		t.Fatal("delegation of zero stake is not removed")
+	}
+
+	if len(candidates.GetDelegations([20]byte{3})) != 0 {
+		t.Fatal("unexpected delegations")
+	}
+}
+
 func TestCandidates_IsNewCandidateStakeSufficient(t *testing.T) {
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
 	b := bus.NewBus()
@@ -906,6 +976,11 @@ func TestCandidates_IsDelegatorStakeSufficient(t *testing.T) {
 		t.Fatal(err)
 	}
 	b.SetWaitList(waitlist.NewBus(wl))
+	appBus, err := app.NewApp(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetApp(appBus)
 	b.SetChecker(checker.NewChecker(b))
 	accs, err := accounts.NewAccounts(b, mutableTree)
 	if err != nil {
@@ -992,6 +1067,94 @@ func TestCandidates_IsDelegatorStakeSufficient_false(t *testing.T) {
 	}
 }
 
+func TestCandidates_SimulateDelegation(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	b := bus.NewBus()
+	wl, err := waitlist.NewWaitList(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetWaitList(waitlist.NewBus(wl))
+	appBus, err := app.NewApp(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetApp(appBus)
+	b.SetChecker(checker.NewChecker(b))
+	b.SetEvents(eventsdb.NewEventsStore(db.NewMemDB()))
+	candidates, err := NewCandidates(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10)
+
+	var stakes []types.Stake
+	for i := 0; i < MaxDelegatorsPerCandidate; i++ {
+		value := strconv.Itoa(i + 2000)
+		stakes = append(stakes, types.Stake{
+			Owner:    types.StringToAddress(strconv.Itoa(i)),
+			Coin:     0,
+			Value:    value,
+			BipValue: value,
+		})
+	}
+	candidates.SetStakes([32]byte{4}, stakes, nil)
+	candidates.recalculateStakes(0)
+	err = candidates.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	simulation := candidates.SimulateDelegation([20]byte{5}, [32]byte{4}, 0, big.NewInt(1500))
+	if simulation.Enters {
+		t.Fatal("small stake should not enter")
+	}
+	if len(simulation.Kicked) != 0 {
+		t.Fatalf("kicked stakes %d, want 0", len(simulation.Kicked))
+	}
+	if simulation.MinBipValue.String() != "2000" || simulation.MinValue.String() != "2000" {
+		t.Fatalf("min value %s, want 2000", simulation.MinValue)
+	}
+
+	simulation = candidates.SimulateDelegation([20]byte{5}, [32]byte{4}, 0, big.NewInt(2500))
+	if !simulation.Enters {
+		t.Fatal("stake should enter")
+	}
+	if len(simulation.Kicked) != 1 || simulation.Kicked[0].Owner != types.StringToAddress("0") {
+		t.Fatalf("kicked stakes %v, want the smallest one", simulation.Kicked)
+	}
+
+	simulation = candidates.SimulateDelegation(types.StringToAddress("0"), [32]byte{4}, 0, big.NewInt(1))
+	if !simulation.Enters || simulation.BipValue.String() != "2001" || simulation.MinValue.Sign() != 0 {
+		t.Fatal("stake of existing delegator should be increased")
+	}
+
+	if len(candidates.GetStakes([32]byte{4})) != MaxDelegatorsPerCandidate || candidates.GetStakeOfAddress([32]byte{4}, types.StringToAddress("0"), 0) == nil {
+		t.Fatal("simulation should not change stakes")
+	}
+	if candidates.GetStakeValueOfAddress([32]byte{4}, types.StringToAddress("0"), 0).String() != "2000" {
+		t.Fatal("simulation should not change stake value")
+	}
+
+	// simulation predicts the next recalculation of stakes with pending updates
+	candidates.Delegate([20]byte{6}, [32]byte{4}, 0, big.NewInt(2600), big.NewInt(0))
+	simulation = candidates.SimulateDelegation([20]byte{5}, [32]byte{4}, 0, big.NewInt(2500))
+	candidates.Delegate([20]byte{5}, [32]byte{4}, 0, big.NewInt(2500), big.NewInt(0))
+	candidates.RecalculateStakes(1)
+
+	if entered := candidates.GetStakeOfAddress([32]byte{4}, [20]byte{5}, 0) != nil; entered != simulation.Enters {
+		t.Fatalf("simulated entering %t, but stake entered %t", simulation.Enters, entered)
+	}
+	// the smallest stake is kicked by the pending update, not by the delegation
+	if len(simulation.Kicked) != 1 || simulation.Kicked[0].Owner != types.StringToAddress("1") {
+		t.Fatalf("kicked stakes %v, want the second smallest one", simulation.Kicked)
+	}
+	if wl.Get(types.StringToAddress("1"), [32]byte{4}, 0) == nil || wl.Get(types.StringToAddress("0"), [32]byte{4}, 0) == nil {
+		t.Fatal("kicked stakes are not moved to waitlist")
+	}
+}
+
 func TestCandidates_GetNewCandidates(t *testing.T) {
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
 	b := bus.NewBus()
@@ -1241,3 +1404,89 @@ func TestCandidates_CalculateBipValue_RecalculateStakes_GetTotalStake(t *testing
 		t.Fatalf("total stake %s", totalStake.String())
 	}
 }
+
+func TestCandidates_Commit_editCommission(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	candidates, err := NewCandidates(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10)
+	candidates.EditCommission([32]byte{4}, 15, 100)
+
+	err = candidates.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates, err = NewCandidates(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidates.LoadCandidates()
+
+	candidate := candidates.GetCandidate([32]byte{4})
+	commission, height, ok := candidate.PendingCommission()
+	if !ok || commission != 15 || height != 100+EditCommissionDelay {
+		t.Fatalf("pending commission is not correct: %d at %d", commission, height)
+	}
+	if candidate.LastEditCommissionHeight() != 100 {
+		t.Fatalf("last edit height is not correct: %d", candidate.LastEditCommissionHeight())
+	}
+
+	candidates.ApplyPendingCommissions(100 + EditCommissionDelay - 1)
+	if candidate.Commission != 10 {
+		t.Fatalf("commission is applied before delay: %d", candidate.Commission)
+	}
+
+	candidates.ApplyPendingCommissions(100 + EditCommissionDelay)
+	if candidate.Commission != 15 {
+		t.Fatalf("commission is not applied: %d", candidate.Commission)
+	}
+	if _, _, ok := candidate.PendingCommission(); ok {
+		t.Fatal("pending commission is not cleared")
+	}
+	if candidate.LastEditCommissionHeight() != 100 {
+		t.Fatalf("last edit height is not correct: %d", candidate.LastEditCommissionHeight())
+	}
+}
+
+func TestCandidates_GetStakedCoins(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	candidates, err := NewCandidates(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10)
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{5}, 10)
+
+	candidates.SetStakes([32]byte{4}, []types.Stake{
+		{Owner: [20]byte{1}, Coin: 0, Value: "100", BipValue: "100"},
+		{Owner: [20]byte{2}, Coin: 1, Value: "10", BipValue: "0"},
+	}, []types.Stake{
+		{Owner: [20]byte{3}, Coin: 0, Value: "5", BipValue: "5"},
+	})
+	candidates.SetStakes([32]byte{5}, []types.Stake{
+		{Owner: [20]byte{1}, Coin: 1, Value: "20", BipValue: "0"},
+	}, nil)
+
+	staked := candidates.GetStakedCoins()
+	if len(staked) != 2 {
+		t.Fatalf("staked coins %d, want 2", len(staked))
+	}
+	if staked[0].String() != "105" {
+		t.Fatalf("staked base coin %s, want 105", staked[0])
+	}
+	if staked[1].String() != "30" {
+		t.Fatalf("staked coin %s, want 30", staked[1])
+	}
+}
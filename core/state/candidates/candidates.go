@@ -24,6 +24,13 @@ const (
 
 	UnbondPeriod              = 518400
 	MaxDelegatorsPerCandidate = 1000
+
+	// EditCommissionDelay is the amount of blocks after which edited commission of a candidate is applied
+	EditCommissionDelay = 120960
+	// EditCommissionPeriod is the minimal amount of blocks between two commission edits of a candidate
+	EditCommissionPeriod = 518400
+	// MaxCommissionEditDelta is the maximal change of candidate's commission for a single edit
+	MaxCommissionEditDelta = 10
 )
 
 const (
@@ -34,6 +41,7 @@ const (
 	stakesPrefix     = 's'
 	totalStakePrefix = 't'
 	updatesPrefix    = 'u'
+	commissionPrefix = 'm'
 )
 
 var (
@@ -50,6 +58,7 @@ type RCandidates interface {
 	IsNewCandidateStakeSufficient(coin types.CoinID, stake *big.Int, limit int) bool
 	IsDelegatorStakeSufficient(address types.Address, pubkey types.Pubkey, coin types.CoinID, amount *big.Int) bool
 	GetStakeValueOfAddress(pubkey types.Pubkey, address types.Address, coin types.CoinID) *big.Int
+	GetStakeOfAddress(pubkey types.Pubkey, address types.Address, coin types.CoinID) *stake
 	GetCandidateOwner(pubkey types.Pubkey) types.Address
 	GetCandidateControl(pubkey types.Pubkey) types.Address
 	GetTotalStake(pubkey types.Pubkey) *big.Int
@@ -59,6 +68,9 @@ type RCandidates interface {
 	LoadStakes()
 	GetCandidates() []*Candidate
 	GetStakes(pubkey types.Pubkey) []*stake
+	GetDelegations(address types.Address) []Delegation
+	GetStakedCoins() map[types.CoinID]*big.Int
+	SimulateDelegation(address types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int) *DelegationSimulation
 }
 
 // Candidates struct is a store of Candidates state
@@ -70,6 +82,13 @@ type Candidates struct {
 	pubKeyIDs map[types.Pubkey]uint32
 	maxID     uint32
 
+	// delegator's index, it isn't stored in iavl and is built from loaded stakes and updates
+	delegations      map[types.Address][]delegation
+	dirtyDelegations map[types.Address]struct{}
+
+	// ids of candidates by heights at which their scheduled commissions are applied, built from commission edits
+	pendingCommissions map[uint64][]uint32
+
 	iavl tree.MTree
 	bus  *bus.Bus
 
@@ -93,6 +112,11 @@ func NewCandidates(bus *bus.Bus, iavl tree.MTree) (*Candidates, error) {
 		blockList: map[types.Pubkey]struct{}{},
 		pubKeyIDs: map[types.Pubkey]uint32{},
 		list:      map[uint32]*Candidate{},
+
+		delegations:      map[types.Address][]delegation{},
+		dirtyDelegations: map[types.Address]struct{}{},
+
+		pendingCommissions: map[uint64][]uint32{},
 	}
 	candidates.bus.SetCandidates(NewBus(candidates))
 
@@ -163,6 +187,7 @@ func (c *Candidates) Commit() error {
 	for _, pubkey := range keys {
 		candidate := c.getFromMap(pubkey)
 		candidate.isDirty = false
+		hasDirtyStakes := candidate.hasDirtyStakes()
 
 		if candidate.isTotalStakeDirty {
 			path := []byte{mainPrefix}
@@ -210,11 +235,57 @@ func (c *Candidates) Commit() error {
 			c.iavl.Set(path, data)
 			candidate.isUpdatesDirty = false
 		}
+
+		if candidate.isCommissionDirty {
+			data, err := rlp.EncodeToBytes(candidate.commission)
+			if err != nil {
+				return fmt.Errorf("can't encode candidate commission edit: %v", err)
+			}
+
+			path := []byte{mainPrefix}
+			path = append(path, candidate.idBytes()...)
+			path = append(path, commissionPrefix)
+			c.iavl.Set(path, data)
+			candidate.isCommissionDirty = false
+		}
+
+		if coinsIndex := c.bus.CoinsIndex(); coinsIndex != nil && hasDirtyStakes {
+			coinsIndex.SetStakes(candidate.ID, c.bus.Candidates().GetStakes(candidate.PubKey))
+		}
+	}
+
+	for _, owner := range c.getOrderedDirtyDelegations() {
+		c.pruneDelegations(owner)
 	}
 
 	return nil
 }
 
+// pruneDelegations drops entries of delegator's index which have no stakes or updates left
+func (c *Candidates) pruneDelegations(owner types.Address) {
+	var actual []delegation
+	for _, item := range c.getDelegations(owner) {
+		c.lock.RLock()
+		candidate := c.list[item.CandidateID]
+		c.lock.RUnlock()
+
+		if candidate != nil && candidate.hasStakeOf(owner, item.Coin) {
+			actual = append(actual, item)
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.dirtyDelegations, owner)
+	if len(actual) == 0 {
+		delete(c.delegations, owner)
+		return
+	}
+
+	c.delegations[owner] = actual
+}
+
 // GetNewCandidates returns list of candidates that can be the new validators
 // Skips offline candidates and candidates with stake less than minValidatorBipStake
 // Result is sorted by candidates stakes and limited to valCount
@@ -311,6 +382,7 @@ func (c *Candidates) PunishByzantineCandidate(height uint64, tmAddress types.TmA
 		c.bus.Checker().AddCoin(stake.Coin, big.NewInt(0).Neg(newValue))
 		c.bus.FrozenFunds().AddFrozenFund(height+UnbondPeriod, stake.Owner, candidate.PubKey, candidate.ID, stake.Coin, newValue)
 		stake.setValue(big.NewInt(0))
+		c.markDelegationsDirty(stake.Owner)
 	}
 }
 
@@ -329,80 +401,101 @@ func (c *Candidates) GetCandidateByTendermintAddress(address types.TmAddress) *C
 // RecalculateStakes recalculate stakes of all candidates:
 // 1. Updates bip-values of each stake
 // 2. Applies updates
-func (c *Candidates) RecalculateStakes(height uint64) {
-	c.recalculateStakes(height)
+// Returns amount of stakes moved to waitlist.
+func (c *Candidates) RecalculateStakes(height uint64) int {
+	return c.recalculateStakes(height)
 }
 
-func (c *Candidates) recalculateStakes(height uint64) {
+func (c *Candidates) recalculateStakes(height uint64) (kicks int) {
 	coinsCache := newCoinsCache()
 
 	for _, pubkey := range c.getOrderedCandidates() {
 		candidate := c.getFromMap(pubkey)
 		stakes := &candidate.stakes
+
+		recalculateCandidateStakes(stakes, candidate.updates, func(coin types.CoinID, value *big.Int) *big.Int {
+			return c.calculateBipValue(coin, value, false, true, coinsCache)
+		}, func(index int, update *stake) {
+			candidate.setStakeAtIndex(index, update, true)
+		}, func(stake *stake) {
+			c.stakeKick(stake.Owner, stake.Value, stake.Coin, candidate.PubKey, height)
+			kicks++
+		})
+
+		candidate.clearUpdates()
+
+		totalBipValue := big.NewInt(0)
 		for _, stake := range stakes {
 			if stake == nil {
 				continue
 			}
-			stake.setBipValue(c.calculateBipValue(stake.Coin, stake.Value, false, true, coinsCache))
+			totalBipValue.Add(totalBipValue, stake.BipValue)
 		}
 
-		// apply updates for existing stakes
-		for _, update := range candidate.updates {
-			stake := c.GetStakeOfAddress(candidate.PubKey, update.Owner, update.Coin)
-			if stake != nil {
+		candidate.setTotalBipStake(totalBipValue)
+	}
+
+	return kicks
+}
+
+// recalculateCandidateStakes updates bip values of stakes of a candidate, adds updates to existing stakes of the same
+// owner and coin, and places the rest of updates, merged and sorted by bip value, instead of the smallest stakes.
+// place has to set update at index of stakes, stakes and updates which don't fit are passed to kick before the value
+// of a kicked update is zeroed. Recalculation of stakes and SimulateDelegation both run it, the latter on copies.
+func recalculateCandidateStakes(stakes *[MaxDelegatorsPerCandidate]*stake, updates []*stake, bipValue func(coin types.CoinID, value *big.Int) *big.Int, place func(index int, update *stake), kick func(stake *stake)) {
+	for _, stake := range stakes {
+		if stake == nil {
+			continue
+		}
+		stake.setBipValue(bipValue(stake.Coin, stake.Value))
+	}
+
+	// apply updates for existing stakes
+	for _, update := range updates {
+		for _, stake := range stakes {
+			if stake != nil && stake.Owner == update.Owner && stake.Coin == update.Coin {
 				stake.addValue(update.Value)
 				update.setValue(big.NewInt(0))
-				stake.setBipValue(c.calculateBipValue(stake.Coin, stake.Value, false, true, coinsCache))
+				stake.setBipValue(bipValue(stake.Coin, stake.Value))
+				break
 			}
 		}
+	}
 
-		candidate.filterUpdates()
-		for _, update := range candidate.updates {
-			update.setBipValue(c.calculateBipValue(update.Coin, update.Value, false, true, coinsCache))
-		}
-
-		for _, update := range candidate.updates {
-			// find and replace smallest stake
-			index := -1
-			smallestStake := big.NewInt(0)
-
-			for i, stake := range stakes {
-				if stake == nil {
-					index = i
-					smallestStake = big.NewInt(0)
-					break
-				}
+	updates = filterUpdates(updates)
+	for _, update := range updates {
+		update.setBipValue(bipValue(update.Coin, update.Value))
+	}
 
-				if index == -1 || smallestStake.Cmp(stake.BipValue) == 1 {
-					smallestStake = big.NewInt(0).Set(stake.BipValue)
-					index = i
-				}
-			}
+	for _, update := range updates {
+		// find and replace smallest stake
+		index := -1
+		smallestStake := big.NewInt(0)
 
-			if smallestStake.Cmp(update.BipValue) == 1 {
-				c.stakeKick(update.Owner, update.Value, update.Coin, candidate.PubKey, height)
-				update.setValue(big.NewInt(0))
-				continue
+		for i, stake := range stakes {
+			if stake == nil {
+				index = i
+				smallestStake = big.NewInt(0)
+				break
 			}
 
-			if stakes[index] != nil {
-				c.stakeKick(stakes[index].Owner, stakes[index].Value, stakes[index].Coin, candidate.PubKey, height)
+			if index == -1 || smallestStake.Cmp(stake.BipValue) == 1 {
+				smallestStake = big.NewInt(0).Set(stake.BipValue)
+				index = i
 			}
-
-			candidate.setStakeAtIndex(index, update, true)
 		}
 
-		candidate.clearUpdates()
+		if smallestStake.Cmp(update.BipValue) == 1 {
+			kick(update)
+			update.setValue(big.NewInt(0))
+			continue
+		}
 
-		totalBipValue := big.NewInt(0)
-		for _, stake := range stakes {
-			if stake == nil {
-				continue
-			}
-			totalBipValue.Add(totalBipValue, stake.BipValue)
+		if stakes[index] != nil {
+			kick(stakes[index])
 		}
 
-		candidate.setTotalBipStake(totalBipValue)
+		place(index, update)
 	}
 }
 
@@ -415,6 +508,7 @@ func (c *Candidates) stakeKick(owner types.Address, value *big.Int, coin types.C
 		ValidatorPubKey: pubKey,
 	})
 	c.bus.Checker().AddCoin(coin, big.NewInt(0).Neg(value))
+	c.markDelegationsDirty(owner)
 }
 
 // Exists returns wherever a candidate with given public key exists
@@ -507,8 +601,20 @@ func (c *Candidates) Delegate(address types.Address, pubkey types.Pubkey, coin t
 		Value:    big.NewInt(0).Set(value),
 		BipValue: big.NewInt(0).Set(bipValue),
 	})
+	c.addDelegation(address, candidate.ID, coin)
 
 	c.bus.Checker().AddCoin(coin, value)
+
+	if !c.bus.IsTracing() {
+		return
+	}
+
+	// delegated value joins the stake on the next recalculation of stakes
+	before := big.NewInt(0)
+	if stake := c.GetStakeOfAddress(pubkey, address, coin); stake != nil {
+		before.Set(stake.Value)
+	}
+	c.bus.Tracer().OnStakeWrite(address, pubkey, coin, before, big.NewInt(0).Add(before, value))
 }
 
 // Edit edits a candidate
@@ -519,6 +625,50 @@ func (c *Candidates) Edit(pubkey types.Pubkey, rewardAddress types.Address, owne
 	candidate.setControl(controlAddress)
 }
 
+// EditCommission schedules a change of candidate's commission.
+// New commission is applied at height + EditCommissionDelay by ApplyPendingCommissions.
+func (c *Candidates) EditCommission(pubkey types.Pubkey, commission uint32, height uint64) {
+	c.SetCommissionEdit(pubkey, commission, height+EditCommissionDelay, height)
+}
+
+// SetCommissionEdit sets scheduled commission change of a candidate. Used in Import.
+func (c *Candidates) SetCommissionEdit(pubkey types.Pubkey, commission uint32, pendingHeight uint64, lastEditHeight uint64) {
+	candidate := c.getFromMap(pubkey)
+	candidate.setPendingCommission(commission, pendingHeight, lastEditHeight)
+	c.addPendingCommission(candidate)
+}
+
+// ApplyPendingCommissions applies scheduled commissions of candidates which are due at given height
+func (c *Candidates) ApplyPendingCommissions(height uint64) {
+	c.lock.Lock()
+	ids := c.pendingCommissions[height]
+	delete(c.pendingCommissions, height)
+	c.lock.Unlock()
+
+	for _, id := range ids {
+		candidate := c.getFromMapByID(id)
+		if candidate == nil {
+			continue
+		}
+
+		if _, pendingHeight, ok := candidate.PendingCommission(); ok && pendingHeight == height {
+			candidate.applyPendingCommission()
+		}
+	}
+}
+
+func (c *Candidates) addPendingCommission(candidate *Candidate) {
+	_, height, ok := candidate.PendingCommission()
+	if !ok {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.pendingCommissions[height] = append(c.pendingCommissions[height], candidate.ID)
+}
+
 // SetOnline sets candidate status to CandidateStatusOnline
 func (c *Candidates) SetOnline(pubkey types.Pubkey) {
 	c.getFromMap(pubkey).setStatus(CandidateStatusOnline)
@@ -531,7 +681,11 @@ func (c *Candidates) SetOffline(pubkey types.Pubkey) {
 
 // SubStake subs given value from delegator's stake
 func (c *Candidates) SubStake(address types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int) {
-	c.GetStakeOfAddress(pubkey, address, coin).subValue(value)
+	stake := c.GetStakeOfAddress(pubkey, address, coin)
+	before := stake.Value
+	stake.subValue(value)
+	c.bus.Tracer().OnStakeWrite(address, pubkey, coin, before, stake.Value)
+	c.markDelegationsDirty(address)
 	c.bus.Checker().AddCoin(coin, big.NewInt(0).Neg(value))
 }
 
@@ -603,9 +757,55 @@ func (c *Candidates) GetStakeValueOfAddress(pubkey types.Pubkey, address types.A
 		return nil
 	}
 
+	c.bus.Tracer().OnStakeRead(address, pubkey, coin, stake.Value)
+
 	return stake.Value
 }
 
+// GetDelegations returns candidates and coins in which address has stakes or pending delegations.
+// Index of delegations is built from loaded stakes, so stakes of all candidates should be loaded before the call.
+func (c *Candidates) GetDelegations(address types.Address) []Delegation {
+	list := c.getDelegations(address)
+
+	delegations := make([]Delegation, 0, len(list))
+	for _, item := range list {
+		delegations = append(delegations, Delegation{
+			PubKey: c.PubKey(item.CandidateID),
+			Coin:   item.Coin,
+		})
+	}
+
+	return delegations
+}
+
+// GetStakedCoins returns total values of coins staked to all candidates, including pending delegations.
+// Stakes should be loaded before the call.
+func (c *Candidates) GetStakedCoins() map[types.CoinID]*big.Int {
+	staked := map[types.CoinID]*big.Int{}
+	add := func(s *stake) {
+		if s == nil {
+			return
+		}
+
+		if staked[s.Coin] == nil {
+			staked[s.Coin] = big.NewInt(0)
+		}
+		staked[s.Coin].Add(staked[s.Coin], s.Value)
+	}
+
+	for _, candidate := range c.GetCandidates() {
+		for _, s := range candidate.stakes {
+			add(s)
+		}
+
+		for _, u := range candidate.updates {
+			add(u)
+		}
+	}
+
+	return staked
+}
+
 // GetCandidateOwner returns candidate's owner address
 func (c *Candidates) GetCandidateOwner(pubkey types.Pubkey) types.Address {
 	return c.getFromMap(pubkey).OwnerAddress
@@ -691,8 +891,21 @@ func (c *Candidates) loadCandidatesList() (maxID uint32) {
 				candidate.totalBipStake = big.NewInt(0).SetBytes(enc)
 			}
 
+			// load commission edit
+			path = append([]byte{mainPrefix}, candidate.idBytes()...)
+			path = append(path, commissionPrefix)
+			_, enc = c.iavl.Get(path)
+			if len(enc) != 0 {
+				commission := &commissionEdit{}
+				if err := rlp.DecodeBytes(enc, commission); err != nil {
+					panic(fmt.Sprintf("failed to decode candidate commission edit: %s", err))
+				}
+				candidate.commission = commission
+			}
+
 			candidate.setTmAddress()
 			c.setToMap(candidate.PubKey, candidate)
+			c.addPendingCommission(candidate)
 		}
 	}
 
@@ -822,6 +1035,7 @@ func (c *Candidates) SetStakes(pubkey types.Pubkey, stakes []types.Stake, update
 			Value:    helpers.StringToBigInt(u.Value),
 			BipValue: helpers.StringToBigInt(u.BipValue),
 		})
+		c.addDelegation(u.Owner, candidate.ID, types.CoinID(u.Coin))
 	}
 
 	count := len(stakes)
@@ -835,6 +1049,7 @@ func (c *Candidates) SetStakes(pubkey types.Pubkey, stakes []types.Stake, update
 				Value:    helpers.StringToBigInt(u.Value),
 				BipValue: helpers.StringToBigInt(u.BipValue),
 			})
+			c.addDelegation(u.Owner, candidate.ID, types.CoinID(u.Coin))
 		}
 	}
 
@@ -851,6 +1066,7 @@ func (c *Candidates) SetStakes(pubkey types.Pubkey, stakes []types.Stake, update
 		}
 
 		candidate.stakes[i].markDirty(i)
+		c.addDelegation(s.Owner, candidate.ID, types.CoinID(s.Coin))
 	}
 }
 
@@ -883,17 +1099,27 @@ func (c *Candidates) Export(state *types.AppState) {
 			}
 		}
 
+		var pendingCommission *types.PendingCommission
+		if commission, height, ok := candidate.PendingCommission(); ok {
+			pendingCommission = &types.PendingCommission{
+				Commission: uint64(commission),
+				Height:     height,
+			}
+		}
+
 		state.Candidates = append(state.Candidates, types.Candidate{
-			ID:             uint64(candidate.ID),
-			RewardAddress:  candidate.RewardAddress,
-			OwnerAddress:   candidate.OwnerAddress,
-			ControlAddress: candidate.ControlAddress,
-			TotalBipStake:  candidate.GetTotalBipStake().String(),
-			PubKey:         candidate.PubKey,
-			Commission:     uint64(candidate.Commission),
-			Status:         uint64(candidate.Status),
-			Updates:        updates,
-			Stakes:         stakes,
+			ID:                       uint64(candidate.ID),
+			RewardAddress:            candidate.RewardAddress,
+			OwnerAddress:             candidate.OwnerAddress,
+			ControlAddress:           candidate.ControlAddress,
+			TotalBipStake:            candidate.GetTotalBipStake().String(),
+			PubKey:                   candidate.PubKey,
+			Commission:               uint64(candidate.Commission),
+			PendingCommission:        pendingCommission,
+			LastEditCommissionHeight: candidate.LastEditCommissionHeight(),
+			Status:                   uint64(candidate.Status),
+			Updates:                  updates,
+			Stakes:                   stakes,
 		})
 	}
 
@@ -928,6 +1154,13 @@ func (c *Candidates) getFromMap(pubkey types.Pubkey) *Candidate {
 	return c.list[c.id(pubkey)]
 }
 
+func (c *Candidates) getFromMapByID(id uint32) *Candidate {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.list[id]
+}
+
 func (c *Candidates) setToMap(pubkey types.Pubkey, model *Candidate) {
 	id := model.ID
 	if id == 0 {
@@ -983,6 +1216,7 @@ func (c *Candidates) LoadStakesOfCandidate(pubkey types.Pubkey) {
 		}
 
 		candidate.setStakeAtIndex(index, stake, false)
+		c.addDelegation(stake.Owner, candidate.ID, stake.Coin)
 
 		stakesCount++
 	}
@@ -1008,6 +1242,7 @@ func (c *Candidates) LoadStakesOfCandidate(pubkey types.Pubkey) {
 					candidate.isUpdatesDirty = true
 				}
 			})(candidate)
+			c.addDelegation(update.Owner, candidate.ID, update.Coin)
 		}
 
 		candidate.updates = updates
@@ -1112,6 +1347,51 @@ func (c *Candidates) AddToBlockPubKey(p types.Pubkey) {
 	c.setBlockPubKey(p)
 }
 
+func (c *Candidates) getDelegations(owner types.Address) []delegation {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.delegations[owner]
+}
+
+func (c *Candidates) addDelegation(owner types.Address, candidateID uint32, coin types.CoinID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	list := c.delegations[owner]
+	for _, item := range list {
+		if item.CandidateID == candidateID && item.Coin == coin {
+			return
+		}
+	}
+
+	c.delegations[owner] = append(list, delegation{CandidateID: candidateID, Coin: coin})
+}
+
+// markDelegationsDirty schedules check of delegator's index on commit, after some of address' stakes were removed
+func (c *Candidates) markDelegationsDirty(owner types.Address) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.dirtyDelegations[owner] = struct{}{}
+}
+
+func (c *Candidates) getOrderedDirtyDelegations() []types.Address {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	keys := make([]types.Address, 0, len(c.dirtyDelegations))
+	for owner := range c.dirtyDelegations {
+		keys = append(keys, owner)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) == 1
+	})
+
+	return keys
+}
+
 func (c *Candidates) maxIDBytes() []byte {
 	bs := make([]byte, 4)
 	binary.LittleEndian.PutUint32(bs, c.maxID)
@@ -13,6 +13,18 @@ type pubkeyID struct {
 	ID     uint32
 }
 
+// Delegation is a candidate and a coin in which an address has a stake or a pending delegation
+type Delegation struct {
+	PubKey types.Pubkey
+	Coin   types.CoinID
+}
+
+// delegation is an entry of delegator's index
+type delegation struct {
+	CandidateID uint32
+	Coin        types.CoinID
+}
+
 // Candidate represents candidate object which is stored on disk
 type Candidate struct {
 	PubKey         types.Pubkey
@@ -28,13 +40,58 @@ type Candidate struct {
 	stakes        [MaxDelegatorsPerCandidate]*stake
 	updates       []*stake
 	tmAddress     *types.TmAddress
+	commission    *commissionEdit
 
 	isDirty           bool
 	isTotalStakeDirty bool
 	isUpdatesDirty    bool
+	isCommissionDirty bool
 	dirtyStakes       [MaxDelegatorsPerCandidate]bool
 }
 
+// commissionEdit holds a scheduled change of candidate's commission
+type commissionEdit struct {
+	PendingCommission uint32
+	PendingHeight     uint64 // height at which PendingCommission is applied, 0 if nothing is scheduled
+	LastEditHeight    uint64
+}
+
+// PendingCommission returns scheduled commission of a candidate and the height at which it will be applied.
+// Returns false if there is no scheduled change.
+func (candidate *Candidate) PendingCommission() (commission uint32, height uint64, ok bool) {
+	if candidate.commission == nil || candidate.commission.PendingHeight == 0 {
+		return 0, 0, false
+	}
+
+	return candidate.commission.PendingCommission, candidate.commission.PendingHeight, true
+}
+
+// LastEditCommissionHeight returns height of the last commission edit of a candidate, 0 if there were no edits
+func (candidate *Candidate) LastEditCommissionHeight() uint64 {
+	if candidate.commission == nil {
+		return 0
+	}
+
+	return candidate.commission.LastEditHeight
+}
+
+func (candidate *Candidate) setPendingCommission(commission uint32, height uint64, editHeight uint64) {
+	candidate.isCommissionDirty = true
+	candidate.commission = &commissionEdit{
+		PendingCommission: commission,
+		PendingHeight:     height,
+		LastEditHeight:    editHeight,
+	}
+}
+
+func (candidate *Candidate) applyPendingCommission() {
+	candidate.isDirty = true
+	candidate.isCommissionDirty = true
+	candidate.Commission = candidate.commission.PendingCommission
+	candidate.commission.PendingCommission = 0
+	candidate.commission.PendingHeight = 0
+}
+
 func (candidate *Candidate) idBytes() []byte {
 	bs := make([]byte, 4)
 	binary.LittleEndian.PutUint32(bs, candidate.ID)
@@ -108,9 +165,9 @@ func (candidate *Candidate) setTmAddress() {
 }
 
 // getFilteredUpdates returns updates which is > 0 in their value + merge similar updates
-func (candidate *Candidate) getFilteredUpdates() []*stake {
+func getFilteredUpdates(candidateUpdates []*stake) []*stake {
 	var updates []*stake
-	for _, update := range candidate.updates {
+	for _, update := range candidateUpdates {
 		// skip updates with 0 stakes
 		if update.Value.Cmp(big.NewInt(0)) != 1 {
 			continue
@@ -140,14 +197,46 @@ func (candidate *Candidate) filterUpdates() {
 		return
 	}
 
-	updates := candidate.getFilteredUpdates()
+	candidate.updates = filterUpdates(candidate.updates)
+	candidate.isUpdatesDirty = true
+}
+
+// filterUpdates returns filtered updates sorted by bip value in descending order
+func filterUpdates(candidateUpdates []*stake) []*stake {
+	updates := getFilteredUpdates(candidateUpdates)
 
 	sort.SliceStable(updates, func(i, j int) bool {
 		return updates[i].BipValue.Cmp(updates[j].BipValue) == 1
 	})
 
-	candidate.updates = updates
-	candidate.isUpdatesDirty = true
+	return updates
+}
+
+func (candidate *Candidate) hasDirtyStakes() bool {
+	for _, isDirty := range candidate.dirtyStakes {
+		if isDirty {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasStakeOf returns true if owner has non-zero stake or update in given coin
+func (candidate *Candidate) hasStakeOf(owner types.Address, coin types.CoinID) bool {
+	for _, stake := range candidate.stakes {
+		if stake != nil && stake.Owner == owner && stake.Coin == coin && stake.Value.Sign() == 1 {
+			return true
+		}
+	}
+
+	for _, update := range candidate.updates {
+		if update.Owner == owner && update.Coin == coin && update.Value.Sign() == 1 {
+			return true
+		}
+	}
+
+	return false
 }
 
 // GetTotalBipStake returns total stake value of a candidate
@@ -0,0 +1,160 @@
+package candidates
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/core/types"
+)
+
+// KickedStake is a stake which is moved to waitlist by recalculation of stakes
+type KickedStake struct {
+	Owner    types.Address
+	Coin     types.CoinID
+	Value    *big.Int
+	BipValue *big.Int
+}
+
+// DelegationSimulation is a result of SimulateDelegation
+type DelegationSimulation struct {
+	// Enters is true if delegator's stake stays in candidate's stakes after recalculation
+	Enters bool
+	// BipValue is bip value of delegator's stake in the coin after recalculation, including previously delegated value
+	BipValue *big.Int
+	// Kicked are stakes of other delegators which are moved to waitlist because of the delegation
+	Kicked []KickedStake
+	// MinBipValue is bip value of the smallest stake which the delegation has to outbid, 0 if it doesn't have to
+	MinBipValue *big.Int
+	// MinValue is MinBipValue in delegated coin
+	MinValue *big.Int
+}
+
+type simulatedStakes struct {
+	stakes [MaxDelegatorsPerCandidate]*stake
+	kicked []*stake
+	cache  *coinsCache
+}
+
+// SimulateDelegation simulates the next recalculation of stakes of candidate as if address delegated value of coin
+// to it, without changing the state. Stakes of candidate have to be loaded.
+func (c *Candidates) SimulateDelegation(address types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int) *DelegationSimulation {
+	candidate := c.GetCandidate(pubkey)
+
+	before := c.simulateRecalculation(candidate, nil)
+	// bip value of a delegation is zero until recalculation, the same as Delegate sets it
+	after := c.simulateRecalculation(candidate, &stake{Owner: address, Coin: coin, Value: value, BipValue: big.NewInt(0)})
+
+	result := &DelegationSimulation{
+		BipValue:    big.NewInt(0),
+		MinBipValue: big.NewInt(0),
+		MinValue:    big.NewInt(0),
+	}
+
+	for _, s := range after.stakes {
+		if s != nil && s.Owner == address && s.Coin == coin {
+			result.Enters = true
+			result.BipValue.Set(s.BipValue)
+		}
+	}
+
+	kickedBefore := map[types.Address]map[types.CoinID]bool{}
+	for _, s := range before.kicked {
+		if kickedBefore[s.Owner] == nil {
+			kickedBefore[s.Owner] = map[types.CoinID]bool{}
+		}
+		kickedBefore[s.Owner][s.Coin] = true
+	}
+
+	for _, s := range after.kicked {
+		if s.Owner == address && s.Coin == coin {
+			result.BipValue.Set(s.BipValue)
+			continue
+		}
+
+		if kickedBefore[s.Owner][s.Coin] {
+			continue
+		}
+
+		result.Kicked = append(result.Kicked, KickedStake{
+			Owner:    s.Owner,
+			Coin:     s.Coin,
+			Value:    big.NewInt(0).Set(s.Value),
+			BipValue: big.NewInt(0).Set(s.BipValue),
+		})
+	}
+
+	// stake of the same delegator in the same coin is increased instead of taking a new slot
+	var smallest *stake
+	for _, s := range before.stakes {
+		if s == nil {
+			return result
+		}
+
+		if s.Owner == address && s.Coin == coin {
+			return result
+		}
+
+		if smallest == nil || smallest.BipValue.Cmp(s.BipValue) == 1 {
+			smallest = s
+		}
+	}
+
+	result.MinBipValue.Set(smallest.BipValue)
+	result.MinValue.Set(smallest.BipValue)
+	if !coin.IsBaseCoin() && after.cache.Exists(coin) {
+		totalBasecoin, totalAmount := after.cache.Get(coin)
+		if totalBasecoin.Sign() == 1 {
+			// inverse of calculateBipValue rounded up
+			result.MinValue.Mul(result.MinValue, totalAmount)
+			result.MinValue.Add(result.MinValue, big.NewInt(0).Sub(totalBasecoin, big.NewInt(1)))
+			result.MinValue.Div(result.MinValue, totalBasecoin)
+		}
+	}
+
+	return result
+}
+
+// simulateRecalculation runs recalculation of stakes of a single candidate with additional update on copies of its
+// stakes and updates
+func (c *Candidates) simulateRecalculation(candidate *Candidate, delegation *stake) *simulatedStakes {
+	result := &simulatedStakes{cache: newCoinsCache()}
+
+	if delegation != nil {
+		// delegated value is not in the state yet, so it is added to totals of the coin in cache
+		c.calculateBipValue(delegation.Coin, delegation.Value, true, true, result.cache)
+	}
+
+	for i, s := range candidate.stakes {
+		if s != nil {
+			result.stakes[i] = copyStake(s)
+		}
+	}
+
+	updates := make([]*stake, 0, len(candidate.updates)+1)
+	for _, u := range candidate.updates {
+		updates = append(updates, copyStake(u))
+	}
+	if delegation != nil {
+		updates = append(updates, copyStake(delegation))
+	}
+
+	recalculateCandidateStakes(&result.stakes, updates, func(coin types.CoinID, value *big.Int) *big.Int {
+		return c.calculateBipValue(coin, value, false, true, result.cache)
+	}, func(index int, update *stake) {
+		result.stakes[index] = update
+	}, func(s *stake) {
+		result.kicked = append(result.kicked, copyStake(s))
+	})
+
+	return result
+}
+
+// copyStake returns copy of stake which changes nothing in the state
+func copyStake(s *stake) *stake {
+	return &stake{
+		Owner:     s.Owner,
+		Coin:      s.Coin,
+		Value:     big.NewInt(0).Set(s.Value),
+		BipValue:  big.NewInt(0).Set(s.BipValue),
+		markDirty: func(int) {},
+	}
+}
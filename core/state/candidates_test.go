@@ -623,3 +623,5 @@ type emptyEvents struct{}
 func (e emptyEvents) AddEvent(height uint32, event eventsdb.Event) {}
 func (e emptyEvents) LoadEvents(height uint32) eventsdb.Events     { return eventsdb.Events{} }
 func (e emptyEvents) CommitEvents() error                          { return nil }
+func (e emptyEvents) DeleteEvents(height uint32) error             { return nil }
+func (e emptyEvents) Close() error                                 { return nil }
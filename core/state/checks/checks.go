@@ -2,81 +2,310 @@ package checks
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"github.com/MinterTeam/minter-go-node/core/check"
 	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
 	"github.com/MinterTeam/minter-go-node/tree"
+	"math/big"
 	"sort"
 	"sync"
 )
 
-const mainPrefix = byte('t')
+const (
+	mainPrefix       = byte('t')
+	expirationPrefix = byte('e')
+	partialPrefix    = byte('p')
+)
+
+const (
+	checkUsed    byte = 0x1
+	checkRevoked byte = 0x2
+)
 
 type RChecks interface {
-	Export(state *types.AppState)
+	Export(state *types.AppState, height uint64)
 	IsCheckUsed(check *check.Check) bool
+	IsCheckRevoked(check *check.Check) bool
+	IsCheckHashUsed(hash types.Hash) bool
+	IsCheckHashRevoked(hash types.Hash) bool
+	GetPartialCheckRemainder(hash types.Hash) *big.Int
 }
 
+// Checks stores hashes of used checks.
+// Revoked checks are stored as used ones, but are marked to be distinguished from redeemed.
+//
+// Expirable checks are stored together with their due block and are listed by it,
+// so they can be deleted by DeleteExpired when they can't be redeemed anymore.
+// Checks redeemed before upgrades.UpgradeBlock2 are stored by UseCheck without due block. State has only hashes of
+// them, so their due blocks can't be restored by a migration, and these records are never deleted.
+//
+// Partial checks are not marked as used, instead their remainders are stored until due block.
 type Checks struct {
-	usedChecks map[types.Hash]struct{}
+	usedChecks map[types.Hash]usedCheck
+	remainders map[types.Hash]partialCheck
+	expired    map[uint64]struct{}
 
 	iavl tree.MTree
 
 	lock sync.RWMutex
 }
 
+type usedCheck struct {
+	value    byte
+	dueBlock uint64 // 0 if check never expires
+}
+
+type partialCheck struct {
+	remainder *big.Int
+	dueBlock  uint64
+}
+
 func NewChecks(iavl tree.MTree) (*Checks, error) {
-	return &Checks{iavl: iavl, usedChecks: map[types.Hash]struct{}{}}, nil
+	return &Checks{
+		iavl:       iavl,
+		usedChecks: map[types.Hash]usedCheck{},
+		remainders: map[types.Hash]partialCheck{},
+		expired:    map[uint64]struct{}{},
+	}, nil
 }
 
 func (c *Checks) Commit() error {
+	for _, height := range c.getOrderedExpired() {
+		c.lock.Lock()
+		delete(c.expired, height)
+		c.lock.Unlock()
+
+		for _, hash := range c.getExpirationList(height) {
+			c.iavl.Remove(getPath(hash))
+			c.iavl.Remove(getPartialPath(hash))
+		}
+		c.iavl.Remove(getExpirationPath(height))
+	}
+
+	expirationLists := map[uint64][]types.Hash{}
 	for _, hash := range c.getOrderedHashes() {
 		c.lock.Lock()
+		item := c.usedChecks[hash]
 		delete(c.usedChecks, hash)
 		c.lock.Unlock()
 
-		trieHash := append([]byte{mainPrefix}, hash.Bytes()...)
-		c.iavl.Set(trieHash, []byte{0x1})
+		data := []byte{item.value}
+		if item.dueBlock != 0 {
+			data = append(data, uint64ToBytes(item.dueBlock)...)
+			expirationLists[item.dueBlock] = append(expirationLists[item.dueBlock], hash)
+		}
+
+		c.iavl.Set(getPath(hash), data)
+	}
+
+	for _, hash := range c.getOrderedRemainders() {
+		c.lock.Lock()
+		item := c.remainders[hash]
+		delete(c.remainders, hash)
+		c.lock.Unlock()
+
+		path := getPartialPath(hash)
+		if _, enc := c.iavl.Get(path); len(enc) == 0 {
+			expirationLists[item.dueBlock] = append(expirationLists[item.dueBlock], hash)
+		}
+
+		c.iavl.Set(path, append(uint64ToBytes(item.dueBlock), item.remainder.Bytes()...))
+	}
+
+	heights := make([]uint64, 0, len(expirationLists))
+	for height := range expirationLists {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	for _, height := range heights {
+		data, err := rlp.EncodeToBytes(append(c.getExpirationList(height), expirationLists[height]...))
+		if err != nil {
+			return fmt.Errorf("can't encode expiration list at %d: %v", height, err)
+		}
+
+		c.iavl.Set(getExpirationPath(height), data)
 	}
 
 	return nil
 }
 
+// IsCheckUsed returns true if check is redeemed or revoked
 func (c *Checks) IsCheckUsed(check *check.Check) bool {
+	return c.IsCheckHashUsed(check.Hash())
+}
+
+// IsCheckRevoked returns true if check is revoked by its issuer
+func (c *Checks) IsCheckRevoked(check *check.Check) bool {
+	return c.IsCheckHashRevoked(check.Hash())
+}
+
+func (c *Checks) IsCheckHashUsed(hash types.Hash) bool {
+	return c.get(hash) != 0
+}
+
+func (c *Checks) IsCheckHashRevoked(hash types.Hash) bool {
+	return c.get(hash) == checkRevoked
+}
+
+// GetPartialCheckRemainder returns amount of coins left in partial check, nil if the check has not been redeemed yet
+func (c *Checks) GetPartialCheckRemainder(hash types.Hash) *big.Int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if item, has := c.remainders[hash]; has {
+		return big.NewInt(0).Set(item.remainder)
+	}
+
+	_, data := c.iavl.Get(getPartialPath(hash))
+	if len(data) == 0 {
+		return nil
+	}
+
+	return big.NewInt(0).SetBytes(data[8:])
+}
+
+// SetPartialCheckRemainder sets amount of coins left in partial check. The record is kept until due block.
+func (c *Checks) SetPartialCheckRemainder(hash types.Hash, dueBlock uint64, remainder *big.Int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.remainders[hash] = partialCheck{remainder: big.NewInt(0).Set(remainder), dueBlock: dueBlock}
+}
+
+func (c *Checks) get(hash types.Hash) byte {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 
-	if _, has := c.usedChecks[check.Hash()]; has {
-		return true
+	if item, has := c.usedChecks[hash]; has {
+		return item.value
 	}
 
-	_, data := c.iavl.Get(append([]byte{mainPrefix}, check.Hash().Bytes()...))
+	_, data := c.iavl.Get(getPath(hash))
+	if len(data) == 0 {
+		return 0
+	}
 
-	return len(data) != 0
+	return data[0]
 }
 
+// UseCheck marks check as used. The record is kept forever, it is used for checks redeemed before UpgradeBlock2.
 func (c *Checks) UseCheck(check *check.Check) {
 	c.UseCheckHash(check.Hash())
 }
 
 func (c *Checks) UseCheckHash(hash types.Hash) {
+	c.set(hash, usedCheck{value: checkUsed})
+}
+
+// UseExpirableCheck marks check as used until its due block
+func (c *Checks) UseExpirableCheck(check *check.Check) {
+	c.UseExpirableCheckHash(check.Hash(), check.DueBlock)
+}
+
+func (c *Checks) UseExpirableCheckHash(hash types.Hash, dueBlock uint64) {
+	c.set(hash, usedCheck{value: checkUsed, dueBlock: dueBlock})
+}
+
+// RevokeCheck marks check as used until its due block, so it can't be redeemed anymore
+func (c *Checks) RevokeCheck(check *check.Check) {
+	c.RevokeCheckHash(check.Hash(), check.DueBlock)
+}
+
+func (c *Checks) RevokeCheckHash(hash types.Hash, dueBlock uint64) {
+	c.set(hash, usedCheck{value: checkRevoked, dueBlock: dueBlock})
+}
+
+func (c *Checks) set(hash types.Hash, item usedCheck) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	c.usedChecks[hash] = struct{}{}
+	c.usedChecks[hash] = item
 }
 
-func (c *Checks) Export(state *types.AppState) {
+// DeleteExpired deletes records of expirable checks with due block at given height.
+// Such checks can't be redeemed after the height anyway.
+func (c *Checks) DeleteExpired(height uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for hash, item := range c.usedChecks {
+		if item.dueBlock != 0 && item.dueBlock <= height {
+			delete(c.usedChecks, hash)
+		}
+	}
+
+	for hash, item := range c.remainders {
+		if item.dueBlock <= height {
+			delete(c.remainders, hash)
+		}
+	}
+
+	c.expired[height] = struct{}{}
+}
+
+// Export exports used checks which are not expired at given height
+func (c *Checks) Export(state *types.AppState, height uint64) {
 	// todo: iterate range?
 	c.iavl.Iterate(func(key []byte, value []byte) bool {
-		if key[0] == mainPrefix {
-			state.UsedChecks = append(state.UsedChecks, types.UsedCheck(fmt.Sprintf("%x", key[1:])))
+		if key[0] == partialPrefix && len(key) == types.HashLength+1 {
+			dueBlock := binary.BigEndian.Uint64(value[:8])
+			if dueBlock <= height {
+				return false
+			}
+
+			state.PartialChecks = append(state.PartialChecks, types.PartialCheck{
+				Hash:      types.UsedCheck(fmt.Sprintf("%x", key[1:])),
+				DueBlock:  dueBlock,
+				Remainder: big.NewInt(0).SetBytes(value[8:]).String(),
+			})
+
+			return false
 		}
 
+		if key[0] != mainPrefix || len(value) == 0 {
+			return false
+		}
+
+		hash := types.UsedCheck(fmt.Sprintf("%x", key[1:]))
+		if len(value) == 1 {
+			state.UsedChecks = append(state.UsedChecks, hash)
+			return false
+		}
+
+		dueBlock := binary.BigEndian.Uint64(value[1:])
+		if dueBlock <= height {
+			return false
+		}
+
+		state.ExpirableChecks = append(state.ExpirableChecks, types.ExpirableCheck{
+			Hash:     hash,
+			DueBlock: dueBlock,
+			Revoked:  value[0] == checkRevoked,
+		})
+
 		return false
 	})
 }
 
+func (c *Checks) getExpirationList(height uint64) []types.Hash {
+	_, enc := c.iavl.Get(getExpirationPath(height))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	var hashes []types.Hash
+	if err := rlp.DecodeBytes(enc, &hashes); err != nil {
+		panic(fmt.Sprintf("failed to decode expiration list at height %d: %s", height, err))
+	}
+
+	return hashes
+}
+
 func (c *Checks) getOrderedHashes() []types.Hash {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -92,3 +321,54 @@ func (c *Checks) getOrderedHashes() []types.Hash {
 
 	return keys
 }
+
+func (c *Checks) getOrderedRemainders() []types.Hash {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var keys []types.Hash
+	for hash := range c.remainders {
+		keys = append(keys, hash)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) == 1
+	})
+
+	return keys
+}
+
+func (c *Checks) getOrderedExpired() []uint64 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	heights := make([]uint64, 0, len(c.expired))
+	for height := range c.expired {
+		heights = append(heights, height)
+	}
+
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	return heights
+}
+
+func getPath(hash types.Hash) []byte {
+	return append([]byte{mainPrefix}, hash.Bytes()...)
+}
+
+func getPartialPath(hash types.Hash) []byte {
+	return append([]byte{partialPrefix}, hash.Bytes()...)
+}
+
+func getExpirationPath(height uint64) []byte {
+	return append([]byte{expirationPrefix}, uint64ToBytes(height)...)
+}
+
+func uint64ToBytes(value uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, value)
+
+	return b
+}
@@ -0,0 +1,145 @@
+package checks
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/check"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+	"math/big"
+	"testing"
+)
+
+func TestChecksToDeleteExpired(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	c, err := NewChecks(mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiring := &check.Check{Nonce: []byte{1}, DueBlock: 10}
+	revoked := &check.Check{Nonce: []byte{2}, DueBlock: 10}
+	live := &check.Check{Nonce: []byte{3}, DueBlock: 20}
+	legacy := &check.Check{Nonce: []byte{4}, DueBlock: 5}
+
+	c.UseExpirableCheck(expiring)
+	c.RevokeCheck(revoked)
+	c.UseExpirableCheck(live)
+	c.UseCheck(legacy)
+	if err := c.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := new(types.AppState)
+	c.Export(state, 10)
+	if len(state.UsedChecks) != 1 || len(state.ExpirableChecks) != 1 || state.ExpirableChecks[0].DueBlock != 20 {
+		t.Fatalf("Wrong exported checks: %v %v", state.UsedChecks, state.ExpirableChecks)
+	}
+
+	if !c.IsCheckRevoked(revoked) || c.IsCheckRevoked(expiring) {
+		t.Fatal("Revoked check is not marked")
+	}
+
+	c.DeleteExpired(9)
+	c.DeleteExpired(10)
+	if err := c.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.IsCheckUsed(expiring) || c.IsCheckUsed(revoked) {
+		t.Fatal("Expired checks are not deleted")
+	}
+
+	if !c.IsCheckUsed(live) || !c.IsCheckUsed(legacy) {
+		t.Fatal("Live checks are deleted")
+	}
+
+	if _, data := mutableTree.Get(getExpirationPath(10)); len(data) != 0 {
+		t.Fatal("Expiration list is not deleted")
+	}
+}
+
+func TestChecksToDeleteExpiredNotCommitted(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	c, err := NewChecks(mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiring := &check.Check{Nonce: []byte{1}, DueBlock: 10}
+
+	c.UseExpirableCheck(expiring)
+	c.DeleteExpired(10)
+	if err := c.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.IsCheckUsed(expiring) {
+		t.Fatal("Expired check is not deleted")
+	}
+}
+
+func TestChecksPartialCheckRemainder(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	c, err := NewChecks(mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := types.Hash{1}
+	if c.GetPartialCheckRemainder(hash) != nil {
+		t.Fatal("Remainder of not redeemed check is not nil")
+	}
+
+	c.SetPartialCheckRemainder(hash, 10, big.NewInt(5))
+	if err := c.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetPartialCheckRemainder(hash, 10, big.NewInt(3))
+	if err := c.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if remainder := c.GetPartialCheckRemainder(hash); remainder == nil || remainder.Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("Wrong remainder: %s", remainder)
+	}
+
+	if hashes := c.getExpirationList(10); len(hashes) != 1 {
+		t.Fatalf("Wrong expiration list size: %d", len(hashes))
+	}
+
+	state := new(types.AppState)
+	c.Export(state, 9)
+	if len(state.PartialChecks) != 1 || state.PartialChecks[0].Remainder != "3" || state.PartialChecks[0].DueBlock != 10 {
+		t.Fatalf("Wrong exported partial checks: %v", state.PartialChecks)
+	}
+
+	c.DeleteExpired(10)
+	if err := c.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.GetPartialCheckRemainder(hash) != nil {
+		t.Fatal("Remainder of expired check is not deleted")
+	}
+}
@@ -67,6 +67,10 @@ type RCoins interface {
 // coins that refer to this ticker (getBySymbol). Finds the current current version there, changes
 // it to the new version. And the new coin is assigned version 0. The new coin is also added to symbolsList [ticker].
 //
+// When a token is created with a CreateTokenTx transaction, the same model is created
+// with zero Crr and zero reserve. Its volume is changed only by MintTokenTx and
+// BurnTokenTx transactions.
+//
 // When changing the owner with a ChangeOwnerTx transaction, the state gets the current owner
 // getSymbolInfo (ticker) and changes the owner there and saves it back.
 type Coins struct {
@@ -142,13 +146,30 @@ func (c *Coins) Commit() error {
 			c.iavl.Set(getSymbolInfoPath(coin.Symbol()), data)
 			coin.symbolInfo.isDirty = false
 		}
+
+		if coinsIndex := c.bus.CoinsIndex(); coinsIndex != nil {
+			coinsIndex.SetCoin(bus.Coin{
+				ID:      coin.ID(),
+				Name:    coin.Name(),
+				Crr:     coin.Crr(),
+				Symbol:  coin.Symbol(),
+				Version: coin.Version(),
+				Volume:  coin.Volume(),
+				Reserve: coin.Reserve(),
+			})
+		}
 	}
 
 	return nil
 }
 
 func (c *Coins) GetCoin(id types.CoinID) *Model {
-	return c.get(id)
+	coin := c.get(id)
+	if coin != nil {
+		c.bus.Tracer().OnCoinRead(id, coin.info.Volume, coin.info.Reserve, coin.CCrr)
+	}
+
+	return coin
 }
 
 func (c *Coins) GetSymbolInfo(symbol types.CoinSymbol) *SymbolInfo {
@@ -196,7 +217,10 @@ func (c *Coins) SubVolume(id types.CoinID, amount *big.Int) {
 		return
 	}
 
-	c.get(id).SubVolume(amount)
+	coin := c.get(id)
+	before := coin.Volume()
+	coin.SubVolume(amount)
+	c.bus.Tracer().OnVolumeWrite(id, before, coin.info.Volume)
 	c.bus.Checker().AddCoinVolume(id, big.NewInt(0).Neg(amount))
 }
 
@@ -205,7 +229,10 @@ func (c *Coins) AddVolume(id types.CoinID, amount *big.Int) {
 		return
 	}
 
-	c.get(id).AddVolume(amount)
+	coin := c.get(id)
+	before := coin.Volume()
+	coin.AddVolume(amount)
+	c.bus.Tracer().OnVolumeWrite(id, before, coin.info.Volume)
 	c.bus.Checker().AddCoinVolume(id, amount)
 }
 
@@ -214,7 +241,10 @@ func (c *Coins) SubReserve(id types.CoinID, amount *big.Int) {
 		return
 	}
 
-	c.get(id).SubReserve(amount)
+	coin := c.get(id)
+	before := coin.Reserve()
+	coin.SubReserve(amount)
+	c.bus.Tracer().OnReserveWrite(id, before, coin.info.Reserve)
 	c.bus.Checker().AddCoin(types.GetBaseCoinID(), big.NewInt(0).Neg(amount))
 }
 
@@ -223,7 +253,10 @@ func (c *Coins) AddReserve(id types.CoinID, amount *big.Int) {
 		return
 	}
 
-	c.get(id).AddReserve(amount)
+	coin := c.get(id)
+	before := coin.Reserve()
+	coin.AddReserve(amount)
+	c.bus.Tracer().OnReserveWrite(id, before, coin.info.Reserve)
 	c.bus.Checker().AddCoin(types.GetBaseCoinID(), amount)
 }
 
@@ -270,6 +303,14 @@ func (c *Coins) Create(id types.CoinID, symbol types.CoinSymbol, name string,
 	c.bus.Checker().AddCoinVolume(coin.id, volume)
 }
 
+// CreateToken creates a reserveless coin. Tokens are stored as regular coins
+// with zero CRR and zero reserve.
+func (c *Coins) CreateToken(id types.CoinID, symbol types.CoinSymbol, name string,
+	volume *big.Int, maxSupply *big.Int, owner *types.Address,
+) {
+	c.Create(id, symbol, name, volume, 0, big.NewInt(0), maxSupply, owner)
+}
+
 func (c *Coins) Recreate(newID types.CoinID, name string, symbol types.CoinSymbol,
 	volume *big.Int, crr uint32, reserve *big.Int, maxSupply *big.Int,
 ) {
@@ -50,6 +50,13 @@ func (m Model) Reserve() *big.Int {
 	return big.NewInt(0).Set(m.info.Reserve)
 }
 
+// IsToken reports whether the coin is a reserveless token. Such coins are
+// created without a reserve and CRR, so their supply can be changed only by
+// minting and burning.
+func (m Model) IsToken() bool {
+	return !m.id.IsBaseCoin() && m.CCrr == 0
+}
+
 func (m Model) Version() uint16 {
 	return m.CVersion
 }
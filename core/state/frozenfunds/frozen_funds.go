@@ -102,6 +102,7 @@ func (f *FrozenFunds) PunishFrozenFundsWithID(fromHeight uint64, toHeight uint64
 				}
 
 				f.bus.Checker().AddCoin(item.Coin, new(big.Int).Neg(slashed))
+				f.bus.App().AddFrozenTotal(item.Coin, new(big.Int).Neg(slashed))
 
 				f.bus.Events().AddEvent(uint32(fromHeight), &eventsdb.SlashEvent{
 					Address:         item.Address,
@@ -178,6 +179,7 @@ func (f *FrozenFunds) getOrderedDirty() []uint64 {
 func (f *FrozenFunds) AddFund(height uint64, address types.Address, pubkey types.Pubkey, candidateId uint32, coin types.CoinID, value *big.Int) {
 	f.GetOrNew(height).addFund(address, pubkey, candidateId, coin, value)
 	f.bus.Checker().AddCoin(coin, value)
+	f.bus.App().AddFrozenTotal(coin, value)
 }
 
 func (f *FrozenFunds) Delete(height uint64) {
@@ -190,19 +192,23 @@ func (f *FrozenFunds) Delete(height uint64) {
 
 	for _, fund := range ff.List {
 		f.bus.Checker().AddCoin(fund.Coin, big.NewInt(0).Neg(fund.Value))
+		f.bus.App().AddFrozenTotal(fund.Coin, big.NewInt(0).Neg(fund.Value))
 	}
 }
 
 func (f *FrozenFunds) Export(state *types.AppState, height uint64) {
-	for i := height; i <= height+candidates.UnbondPeriod; i++ {
-		frozenFunds := f.get(i)
+	// only heights which have frozen funds are stored, iterate over them instead of every height of unbond period
+	f.iavl.IterateRange(getPath(height), getPath(height+candidates.UnbondPeriod+1), true, func(key []byte, value []byte) bool {
+		fundsHeight := binary.BigEndian.Uint64(key[1:])
+
+		frozenFunds := f.get(fundsHeight)
 		if frozenFunds == nil {
-			continue
+			return false
 		}
 
 		for _, frozenFund := range frozenFunds.List {
 			state.FrozenFunds = append(state.FrozenFunds, types.FrozenFund{
-				Height:       i,
+				Height:       fundsHeight,
 				Address:      frozenFund.Address,
 				CandidateKey: frozenFund.CandidateKey,
 				CandidateID:  uint64(frozenFund.CandidateID),
@@ -210,7 +216,9 @@ func (f *FrozenFunds) Export(state *types.AppState, height uint64) {
 				Value:        frozenFund.Value.String(),
 			})
 		}
-	}
+
+		return false
+	})
 }
 
 func (f *FrozenFunds) getFromMap(height uint64) *Model {
@@ -1,7 +1,9 @@
 package frozenfunds
 
 import (
+	"github.com/MinterTeam/minter-go-node/core/state/app"
 	"github.com/MinterTeam/minter-go-node/core/state/bus"
+	"github.com/MinterTeam/minter-go-node/core/state/candidates"
 	"github.com/MinterTeam/minter-go-node/core/state/checker"
 	"github.com/MinterTeam/minter-go-node/core/state/coins"
 	"github.com/MinterTeam/minter-go-node/core/types"
@@ -14,6 +16,11 @@ import (
 func TestFrozenFundsToAddModel(t *testing.T) {
 	b := bus.NewBus()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	appBus, err := app.NewApp(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetApp(appBus)
 
 	ff, err := NewFrozenFunds(b, mutableTree)
 	if err != nil {
@@ -62,6 +69,11 @@ func TestFrozenFundsToAddModel(t *testing.T) {
 func TestFrozenFundsToDeleteModel(t *testing.T) {
 	b := bus.NewBus()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	appBus, err := app.NewApp(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetApp(appBus)
 	ff, err := NewFrozenFunds(b, mutableTree)
 	if err != nil {
 		t.Fatal(err)
@@ -110,6 +122,11 @@ func TestFrozenFundsToDeleteModel(t *testing.T) {
 func TestFrozenFundsToDeleteNotExistingFund(t *testing.T) {
 	b := bus.NewBus()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	appBus, err := app.NewApp(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetApp(appBus)
 	ff, err := NewFrozenFunds(b, mutableTree)
 	if err != nil {
 		t.Fatal(err)
@@ -117,3 +134,51 @@ func TestFrozenFundsToDeleteNotExistingFund(t *testing.T) {
 
 	ff.Delete(0)
 }
+
+func TestFrozenFundsExport(t *testing.T) {
+	b := bus.NewBus()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	appBus, err := app.NewApp(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetApp(appBus)
+
+	ff, err := NewFrozenFunds(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.SetChecker(checker.NewChecker(b))
+	coinsState, err := coins.NewCoins(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.SetCoins(coins.NewBus(coinsState))
+
+	height := uint64(10)
+	heights := []uint64{height - 1, height, height + candidates.UnbondPeriod, height + candidates.UnbondPeriod + 1}
+	for _, h := range heights {
+		ff.AddFund(h, types.Address{0}, types.Pubkey{0}, 1, types.GetBaseCoinID(), big.NewInt(1e18))
+	}
+	if err := ff.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := new(types.AppState)
+	ff.Export(state, height)
+
+	if len(state.FrozenFunds) != 2 {
+		t.Fatalf("Incorrect amount of exported funds: %d", len(state.FrozenFunds))
+	}
+
+	if state.FrozenFunds[0].Height != height || state.FrozenFunds[1].Height != height+candidates.UnbondPeriod {
+		t.Fatal("Funds out of unbond period are exported")
+	}
+}
@@ -0,0 +1,17 @@
+package names
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/types"
+)
+
+type Bus struct {
+	names *Names
+}
+
+func (b *Bus) ResolveAddress(name string, height uint64) (types.Address, bool) {
+	return b.names.ResolveAddress(name, height)
+}
+
+func NewBus(names *Names) *Bus {
+	return &Bus{names: names}
+}
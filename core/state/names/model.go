@@ -0,0 +1,41 @@
+package names
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/types"
+)
+
+// Model is a name registered by owner. The name resolves to owner's address and,
+// if set, to public key of a candidate.
+type Model struct {
+	Owner        types.Address
+	PubKey       types.Pubkey
+	ExpireHeight uint64
+
+	name      string
+	markDirty func(name string)
+}
+
+func (m *Model) Name() string {
+	return m.name
+}
+
+// HasPubKey returns true if the name resolves to candidate's public key
+func (m *Model) HasPubKey() bool {
+	return m.PubKey != types.Pubkey{}
+}
+
+// IsExpired returns true if the name can't be resolved at given height and can be registered again
+func (m *Model) IsExpired(height uint64) bool {
+	return height > m.ExpireHeight
+}
+
+func (m *Model) renew(expireHeight uint64) {
+	m.ExpireHeight = expireHeight
+	m.markDirty(m.name)
+}
+
+func (m *Model) transfer(owner types.Address) {
+	m.Owner = owner
+	m.PubKey = types.Pubkey{}
+	m.markDirty(m.name)
+}
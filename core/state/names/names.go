@@ -0,0 +1,290 @@
+package names
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/core/state/bus"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/tree"
+	"sort"
+	"sync"
+)
+
+// RegistrationPeriod is amount of blocks for which name is registered or renewed
+const RegistrationPeriod = types.BlocksPerYear
+
+const (
+	mainPrefix    = byte('n')
+	namePrefix    = byte('n')
+	reversePrefix = byte('r')
+)
+
+type RNames interface {
+	Export(state *types.AppState, height uint64)
+	GetName(name string) *Model
+	ResolveAddress(name string, height uint64) (types.Address, bool)
+	ResolvePubKey(name string, height uint64) (types.Pubkey, bool)
+	ReverseLookup(address types.Address, height uint64) string
+}
+
+// Names is a registry of human readable names for addresses and public keys of candidates.
+// Every address has a reverse record, which is the last name registered or transferred to it.
+// Expired names are kept in state until they are registered again, but can't be resolved.
+type Names struct {
+	list         map[string]*Model
+	dirty        map[string]struct{}
+	reverse      map[types.Address]string
+	reverseDirty map[types.Address]struct{}
+
+	bus  *bus.Bus
+	iavl tree.MTree
+
+	lock sync.RWMutex
+}
+
+func NewNames(stateBus *bus.Bus, iavl tree.MTree) (*Names, error) {
+	names := &Names{
+		bus:          stateBus,
+		iavl:         iavl,
+		list:         map[string]*Model{},
+		dirty:        map[string]struct{}{},
+		reverse:      map[types.Address]string{},
+		reverseDirty: map[types.Address]struct{}{},
+	}
+	names.bus.SetNames(NewBus(names))
+
+	return names, nil
+}
+
+func (n *Names) Commit() error {
+	for _, name := range n.getOrderedDirty() {
+		model := n.getFromMap(name)
+
+		n.lock.Lock()
+		delete(n.dirty, name)
+		n.lock.Unlock()
+
+		data, err := rlp.EncodeToBytes(model)
+		if err != nil {
+			return fmt.Errorf("can't encode object at %s: %v", name, err)
+		}
+
+		n.iavl.Set(getPath(name), data)
+	}
+
+	for _, address := range n.getOrderedReverseDirty() {
+		n.lock.Lock()
+		name := n.reverse[address]
+		delete(n.reverseDirty, address)
+		n.lock.Unlock()
+
+		n.iavl.Set(getReversePath(address), []byte(name))
+	}
+
+	return nil
+}
+
+// GetName returns registered name, nil if the name has never been registered
+func (n *Names) GetName(name string) *Model {
+	return n.get(name)
+}
+
+// ResolveAddress returns address of the name owner, false if the name is not registered or expired
+func (n *Names) ResolveAddress(name string, height uint64) (types.Address, bool) {
+	model := n.get(name)
+	if model == nil || model.IsExpired(height) {
+		return types.Address{}, false
+	}
+
+	return model.Owner, true
+}
+
+// ResolvePubKey returns public key of candidate, false if the name is not registered, expired or has no public key
+func (n *Names) ResolvePubKey(name string, height uint64) (types.Pubkey, bool) {
+	model := n.get(name)
+	if model == nil || model.IsExpired(height) || !model.HasPubKey() {
+		return types.Pubkey{}, false
+	}
+
+	return model.PubKey, true
+}
+
+// ReverseLookup returns name of address, empty string if the address has no actual name
+func (n *Names) ReverseLookup(address types.Address, height uint64) string {
+	name := n.getReverse(address)
+	if name == "" {
+		return ""
+	}
+
+	if owner, ok := n.ResolveAddress(name, height); !ok || owner != address {
+		return ""
+	}
+
+	return name
+}
+
+// Register registers name for owner until expireHeight, replacing previous expired registration
+func (n *Names) Register(name string, owner types.Address, pubkey types.Pubkey, expireHeight uint64) {
+	model := &Model{
+		Owner:        owner,
+		PubKey:       pubkey,
+		ExpireHeight: expireHeight,
+		name:         name,
+		markDirty:    n.markDirty,
+	}
+	n.setToMap(name, model)
+	model.markDirty(name)
+
+	n.setReverse(owner, name)
+}
+
+func (n *Names) Renew(name string, expireHeight uint64) {
+	model := n.get(name)
+	if model == nil {
+		panic(fmt.Sprintf("name %s is not registered", name))
+	}
+
+	model.renew(expireHeight)
+}
+
+// Transfer changes owner of the name. Public key of the name is reset.
+func (n *Names) Transfer(name string, owner types.Address) {
+	model := n.get(name)
+	if model == nil {
+		panic(fmt.Sprintf("name %s is not registered", name))
+	}
+
+	model.transfer(owner)
+	n.setReverse(owner, name)
+}
+
+// SetReverse sets name as reverse record of address. Used for import of genesis.
+func (n *Names) SetReverse(address types.Address, name string) {
+	n.setReverse(address, name)
+}
+
+// Export exports names which are not expired at given height
+func (n *Names) Export(state *types.AppState, height uint64) {
+	n.iavl.IterateRange([]byte{mainPrefix, namePrefix}, []byte{mainPrefix, namePrefix + 1}, true, func(key []byte, value []byte) bool {
+		name := string(key[2:])
+		model := n.get(name)
+		if model == nil || model.IsExpired(height) {
+			return false
+		}
+
+		var pubkey *types.Pubkey
+		if model.HasPubKey() {
+			pubkey = &model.PubKey
+		}
+
+		state.Names = append(state.Names, types.Name{
+			Name:         name,
+			Owner:        model.Owner,
+			PubKey:       pubkey,
+			ExpireHeight: model.ExpireHeight,
+			Primary:      n.getReverse(model.Owner) == name,
+		})
+
+		return false
+	})
+}
+
+func (n *Names) get(name string) *Model {
+	if model := n.getFromMap(name); model != nil {
+		return model
+	}
+
+	_, enc := n.iavl.Get(getPath(name))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	model := &Model{}
+	if err := rlp.DecodeBytes(enc, model); err != nil {
+		panic(fmt.Sprintf("failed to decode name %s: %s", name, err))
+	}
+
+	model.name = name
+	model.markDirty = n.markDirty
+
+	n.setToMap(name, model)
+
+	return model
+}
+
+func (n *Names) getReverse(address types.Address) string {
+	n.lock.RLock()
+	name, ok := n.reverse[address]
+	n.lock.RUnlock()
+	if ok {
+		return name
+	}
+
+	_, value := n.iavl.Get(getReversePath(address))
+	return string(value)
+}
+
+func (n *Names) setReverse(address types.Address, name string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.reverse[address] = name
+	n.reverseDirty[address] = struct{}{}
+}
+
+func (n *Names) getFromMap(name string) *Model {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.list[name]
+}
+
+func (n *Names) setToMap(name string, model *Model) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.list[name] = model
+}
+
+func (n *Names) markDirty(name string) {
+	n.dirty[name] = struct{}{}
+}
+
+func (n *Names) getOrderedDirty() []string {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	keys := make([]string, 0, len(n.dirty))
+	for k := range n.dirty {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (n *Names) getOrderedReverseDirty() []types.Address {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	keys := make([]types.Address, 0, len(n.reverseDirty))
+	for k := range n.reverseDirty {
+		keys = append(keys, k)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) == 1
+	})
+
+	return keys
+}
+
+func getPath(name string) []byte {
+	return append([]byte{mainPrefix, namePrefix}, []byte(name)...)
+}
+
+func getReversePath(address types.Address) []byte {
+	return append([]byte{mainPrefix, reversePrefix}, address.Bytes()...)
+}
@@ -0,0 +1,91 @@
+package names
+
+import (
+	"github.com/MinterTeam/minter-go-node/core/state/bus"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+	"testing"
+)
+
+func TestNamesToCommit(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	n, err := NewNames(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner, pubkey := types.Address{1}, types.Pubkey{1}
+
+	n.Register("alice", owner, pubkey, 100)
+	if err := n.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err = NewNames(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if address, ok := n.ResolveAddress("alice", 100); !ok || address != owner {
+		t.Fatal("Name is not resolved to address")
+	}
+
+	if resolved, ok := n.ResolvePubKey("alice", 100); !ok || resolved != pubkey {
+		t.Fatal("Name is not resolved to public key")
+	}
+
+	if name := n.ReverseLookup(owner, 100); name != "alice" {
+		t.Fatalf("Wrong reverse lookup: %s", name)
+	}
+
+	if _, ok := n.ResolveAddress("alice", 101); ok {
+		t.Fatal("Expired name is resolved")
+	}
+
+	if name := n.ReverseLookup(owner, 101); name != "" {
+		t.Fatalf("Expired name is returned by reverse lookup: %s", name)
+	}
+}
+
+func TestNamesExport(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	n, err := NewNames(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner := types.Address{1}
+
+	n.Register("alice", owner, types.Pubkey{}, 100)
+	n.Register("bob", owner, types.Pubkey{}, 100)
+	n.Register("carol", types.Address{2}, types.Pubkey{}, 10)
+	if err := n.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := new(types.AppState)
+	n.Export(state, 11)
+
+	if len(state.Names) != 2 {
+		t.Fatalf("Wrong amount of exported names: %d", len(state.Names))
+	}
+
+	if state.Names[0].Name != "alice" || state.Names[0].Primary || state.Names[0].PubKey != nil {
+		t.Fatal("Wrong exported name")
+	}
+
+	if state.Names[1].Name != "bob" || !state.Names[1].Primary || state.Names[1].Owner != owner {
+		t.Fatal("Wrong exported name")
+	}
+}
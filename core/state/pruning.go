@@ -0,0 +1,72 @@
+package state
+
+import "fmt"
+
+// Pruning strategies
+const (
+	// PruningNothing keeps all versions of state, it is an archive mode
+	PruningNothing = "nothing"
+	// PruningEverything keeps only the last two versions of state
+	PruningEverything = "everything"
+	// PruningWindow keeps versions of state within a window of the last KeepRecent versions
+	PruningWindow = "window"
+	// PruningKeepEvery keeps every KeepEvery-th version of state in addition to a window of the last KeepRecent versions
+	PruningKeepEvery = "keep_every"
+)
+
+// Pruning is a strategy of deleting old versions of state on commit
+type Pruning struct {
+	Strategy   string
+	KeepRecent int64
+	KeepEvery  int64
+
+	// Retain reports whether version has to be kept regardless of strategy, e.g. a height of snapshot. May be nil.
+	Retain func(version int64) bool
+}
+
+// NewPruning returns pruning of given strategy. Empty strategy is PruningWindow.
+func NewPruning(strategy string, keepRecent int64, keepEvery int64) (Pruning, error) {
+	switch strategy {
+	case PruningNothing:
+		return Pruning{Strategy: strategy}, nil
+	case PruningEverything:
+		return Pruning{Strategy: strategy, KeepRecent: 1}, nil
+	case "", PruningWindow:
+		if keepRecent < 1 {
+			return Pruning{}, fmt.Errorf("keep recent should be greater than 0 for %s pruning", PruningWindow)
+		}
+
+		return Pruning{Strategy: PruningWindow, KeepRecent: keepRecent}, nil
+	case PruningKeepEvery:
+		if keepRecent < 1 || keepEvery < 1 {
+			return Pruning{}, fmt.Errorf("keep recent and keep every should be greater than 0 for %s pruning", PruningKeepEvery)
+		}
+
+		return Pruning{Strategy: strategy, KeepRecent: keepRecent, KeepEvery: keepEvery}, nil
+	}
+
+	return Pruning{}, fmt.Errorf("unknown pruning strategy %q", strategy)
+}
+
+// Keeps reports whether version is kept by strategy regardless of its age: every KeepEvery-th version and retained ones.
+func (p Pruning) Keeps(version int64) bool {
+	if p.Strategy == PruningKeepEvery && version%p.KeepEvery == 0 {
+		return true
+	}
+
+	return p.Retain != nil && p.Retain(version)
+}
+
+// versionToDelete returns version which goes out of window after saving the version, 0 if there is nothing to delete
+func (p Pruning) versionToDelete(version int64) int64 {
+	if p.Strategy == PruningNothing {
+		return 0
+	}
+
+	versionToDelete := version - p.KeepRecent - 1
+	if versionToDelete < 1 || p.Keeps(versionToDelete) {
+		return 0
+	}
+
+	return versionToDelete
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/hex"
 	"fmt"
 	eventsdb "github.com/MinterTeam/minter-go-node/core/events"
+	"github.com/MinterTeam/minter-go-node/core/rewards"
 	"github.com/MinterTeam/minter-go-node/core/state/accounts"
+	"github.com/MinterTeam/minter-go-node/core/state/allowances"
 	"github.com/MinterTeam/minter-go-node/core/state/app"
 	"github.com/MinterTeam/minter-go-node/core/state/bus"
 	"github.com/MinterTeam/minter-go-node/core/state/candidates"
@@ -13,6 +15,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/state/coins"
 	"github.com/MinterTeam/minter-go-node/core/state/frozenfunds"
 	"github.com/MinterTeam/minter-go-node/core/state/halts"
+	"github.com/MinterTeam/minter-go-node/core/state/names"
 	"github.com/MinterTeam/minter-go-node/core/state/validators"
 	"github.com/MinterTeam/minter-go-node/core/state/waitlist"
 	"github.com/MinterTeam/minter-go-node/core/types"
@@ -22,6 +25,7 @@ import (
 	"log"
 	"math/big"
 	"sync"
+	"time"
 )
 
 type Interface interface {
@@ -85,6 +89,18 @@ func (cs *CheckState) Checks() checks.RChecks {
 func (cs *CheckState) WaitList() waitlist.RWaitList {
 	return cs.state.Waitlist
 }
+func (cs *CheckState) Allowances() allowances.RAllowances {
+	return cs.state.Allowances
+}
+func (cs *CheckState) Names() names.RNames {
+	return cs.state.Names
+}
+
+// Tracer returns tracer of state reads and writes, which records nothing unless state is created by NewStateAtHeight with a tracer
+func (cs *CheckState) Tracer() bus.Tracer {
+	return cs.state.bus.Tracer()
+}
+
 func (cs *CheckState) Tree() tree.ReadOnlyTree {
 	return cs.state.Tree()
 }
@@ -100,12 +116,16 @@ type State struct {
 	Checks      *checks.Checks
 	Checker     *checker.Checker
 	Waitlist    *waitlist.WaitList
+	Allowances  *allowances.Allowances
+	Names       *names.Names
 
-	db             db.DB
-	events         eventsdb.IEventsDB
-	tree           tree.MTree
-	keepLastStates int64
-	bus            *bus.Bus
+	db      db.DB
+	events  eventsdb.IEventsDB
+	tree    tree.MTree
+	pruning Pruning
+	bus     *bus.Bus
+
+	saveVersionDuration time.Duration
 
 	lock sync.RWMutex
 }
@@ -130,6 +150,31 @@ func NewState(height uint64, db db.DB, events eventsdb.IEventsDB, cacheSize int,
 	return state, nil
 }
 
+// NewStateForReplay creates state at given height keeping its later versions. Blocks re-executed on top of it have to
+// produce the same hashes as the kept versions, otherwise commit fails. Old versions are not pruned.
+func NewStateForReplay(height uint64, db db.DB, events eventsdb.IEventsDB, cacheSize int) (*State, error) {
+	iavlTree, err := tree.NewMutableTree(0, db, cacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := iavlTree.LoadVersion(int64(height)); err != nil {
+		return nil, err
+	}
+
+	state, err := newStateForTree(iavlTree, events, db, 0)
+	if err != nil {
+		return nil, err
+	}
+	state.pruning = Pruning{Strategy: PruningNothing}
+
+	state.Candidates.LoadCandidatesDeliver()
+	state.Candidates.LoadStakes()
+	state.Validators.LoadValidators()
+
+	return state, nil
+}
+
 func NewCheckStateAtHeight(height uint64, db db.DB) (*CheckState, error) {
 	iavlTree, err := tree.NewImmutableTree(height, db)
 	if err != nil {
@@ -138,6 +183,44 @@ func NewCheckStateAtHeight(height uint64, db db.DB) (*CheckState, error) {
 	return newCheckStateForTree(iavlTree, nil, db, 0)
 }
 
+// NewStateAtHeight creates state at given height which must not be committed. It's used to re-execute transactions of the
+// next block without touching kept versions of state, events of re-executed transactions are discarded. Reads and writes
+// of the state are recorded by given tracer, which may be nil. Live state has no tracer, so tracing never races with delivery.
+func NewStateAtHeight(height uint64, stateDB db.DB, tracer bus.Tracer) (*State, error) {
+	iavlTree, err := tree.NewImmutableTree(height, stateDB)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := newStateForTree(iavlTree, eventsdb.NewEventsStore(db.NewMemDB()), stateDB, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	state.bus.SetTracer(tracer)
+
+	state.Candidates.LoadCandidatesDeliver()
+	state.Candidates.LoadStakes()
+	state.Validators.LoadValidators()
+
+	return state, nil
+}
+
+// SetCoinsIndex sets node-side index of coins and their holders, which is fed on commit
+func (s *State) SetCoinsIndex(index bus.CoinsIndex) {
+	s.bus.SetCoinsIndex(index)
+}
+
+// SetPruning sets strategy of deleting old versions of state on commit. State keeps a window of keepLastStates versions by default.
+func (s *State) SetPruning(pruning Pruning) {
+	s.pruning = pruning
+}
+
+// Pruning returns strategy of deleting old versions of state on commit
+func (s *State) Pruning() Pruning {
+	return s.pruning
+}
+
 func (s *State) Tree() tree.MTree {
 	return s.tree
 }
@@ -174,65 +257,116 @@ func (s *State) Check() error {
 	return nil
 }
 
+// InitSupply sets supply of base coin to the amount held in the state at the beginning of the block at height,
+// emission to rewards for blocks before it and totals of frozen and waitlisted coins to the ones held in the state. Supply is not stored before UpgradeBlock2 and is not exported by genesis
+// made before it, so it is calculated once at it.
+func (s *State) InitSupply(height uint64) {
+	exported := s.ExportWorking(height - 1)
+	s.App.InitSupply(rewards.GetEmissionBefore(height), exported.BaseCoinSupply())
+
+	for _, ff := range exported.FrozenFunds {
+		s.App.AddFrozenTotal(types.CoinID(ff.Coin), helpers.StringToBigInt(ff.Value))
+	}
+
+	for _, item := range exported.Waitlist {
+		s.App.AddWaitlistTotal(types.CoinID(item.Coin), helpers.StringToBigInt(item.Value))
+	}
+}
+
 const countBatchBlocksDelete = 60
 
+// SaveVersionDuration returns duration of saving tree version by the last Commit
+func (s *State) SaveVersionDuration() time.Duration {
+	return s.saveVersionDuration
+}
+
 func (s *State) Commit() ([]byte, error) {
 	s.Checker.Reset()
 
 	s.tree.GlobalLock()
 	defer s.tree.GlobalUnlock()
 
-	if err := s.Accounts.Commit(); err != nil {
+	if err := s.commitModules(); err != nil {
 		return nil, err
 	}
 
-	if err := s.App.Commit(); err != nil {
+	start := time.Now()
+	hash, version, err := s.tree.SaveVersion()
+	s.saveVersionDuration = time.Since(start)
+	if err != nil {
+		return hash, err
+	}
+
+	versionToDelete := s.pruning.versionToDelete(version)
+	if versionToDelete == 0 {
+		return hash, nil
+	}
+
+	if err := s.tree.DeleteVersionIfExists(versionToDelete); err != nil {
+		log.Printf("DeleteVersion %d error: %s\n", versionToDelete, err)
+	}
+
+	return hash, nil
+}
+
+// WorkingHash writes changes of modules to the tree and returns hash of state which is not committed yet
+func (s *State) WorkingHash() ([]byte, error) {
+	s.tree.GlobalLock()
+	defer s.tree.GlobalUnlock()
+
+	if err := s.commitModules(); err != nil {
 		return nil, err
 	}
 
+	return s.tree.WorkingHash(), nil
+}
+
+func (s *State) commitModules() error {
+	if err := s.Accounts.Commit(); err != nil {
+		return err
+	}
+
+	if err := s.App.Commit(); err != nil {
+		return err
+	}
+
 	if err := s.Coins.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Candidates.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Validators.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Checks.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.FrozenFunds.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Halts.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Waitlist.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
-	hash, version, err := s.tree.SaveVersion()
-	if err != nil {
-		return hash, err
+	if err := s.Allowances.Commit(); err != nil {
+		return err
 	}
 
-	versionToDelete := version - s.keepLastStates - 1
-	if versionToDelete < 1 {
-		return hash, nil
+	if err := s.Names.Commit(); err != nil {
+		return err
 	}
 
-	if err := s.tree.DeleteVersionIfExists(versionToDelete); err != nil {
-		log.Printf("DeleteVersion %d error: %s\n", versionToDelete, err)
-	}
-
-	return hash, nil
+	return nil
 }
 
 func (s *State) Import(state types.AppState) error {
@@ -240,6 +374,9 @@ func (s *State) Import(state types.AppState) error {
 	totalSlash := helpers.StringToBigInt(state.TotalSlashed)
 	s.App.SetTotalSlashed(totalSlash)
 	s.App.SetCoinsCount(uint32(len(state.Coins)))
+	if state.Supply != "" {
+		s.App.InitSupply(helpers.StringToBigInt(state.Emission), helpers.StringToBigInt(state.Supply))
+	}
 
 	for _, a := range state.Accounts {
 		if a.MultisigData != nil {
@@ -295,6 +432,12 @@ func (s *State) Import(state types.AppState) error {
 
 		s.Candidates.SetTotalStake(c.PubKey, helpers.StringToBigInt(c.TotalBipStake))
 		s.Candidates.SetStakes(c.PubKey, c.Stakes, c.Updates)
+
+		if c.PendingCommission != nil {
+			s.Candidates.SetCommissionEdit(c.PubKey, uint32(c.PendingCommission.Commission), c.PendingCommission.Height, c.LastEditCommissionHeight)
+		} else if c.LastEditCommissionHeight != 0 {
+			s.Candidates.SetCommissionEdit(c.PubKey, 0, 0, c.LastEditCommissionHeight)
+		}
 	}
 	s.Candidates.RecalculateStakes(state.StartHeight)
 
@@ -312,6 +455,24 @@ func (s *State) Import(state types.AppState) error {
 		s.Checks.UseCheckHash(hash)
 	}
 
+	for _, item := range state.ExpirableChecks {
+		bytes, _ := hex.DecodeString(string(item.Hash))
+		var hash types.Hash
+		copy(hash[:], bytes)
+		if item.Revoked {
+			s.Checks.RevokeCheckHash(hash, item.DueBlock)
+		} else {
+			s.Checks.UseExpirableCheckHash(hash, item.DueBlock)
+		}
+	}
+
+	for _, item := range state.PartialChecks {
+		bytes, _ := hex.DecodeString(string(item.Hash))
+		var hash types.Hash
+		copy(hash[:], bytes)
+		s.Checks.SetPartialCheckRemainder(hash, item.DueBlock, helpers.StringToBigInt(item.Remainder))
+	}
+
 	for _, ff := range state.FrozenFunds {
 		coinID := types.CoinID(ff.Coin)
 		value := helpers.StringToBigInt(ff.Value)
@@ -319,6 +480,34 @@ func (s *State) Import(state types.AppState) error {
 		s.Checker.AddCoin(coinID, new(big.Int).Neg(value))
 	}
 
+	for _, a := range state.Allowances {
+		s.Allowances.SetAllowance(a.Owner, allowances.Item{
+			Spender:      a.Spender,
+			Coin:         types.CoinID(a.Coin),
+			Value:        helpers.StringToBigInt(a.Value),
+			ExpireHeight: a.ExpireHeight,
+			Period:       a.Period,
+			PeriodLimit:  helpers.StringToBigInt(a.PeriodLimit),
+			PeriodStart:  a.PeriodStart,
+			PeriodSpent:  helpers.StringToBigInt(a.PeriodSpent),
+		})
+	}
+
+	for _, n := range state.Names {
+		var pubkey types.Pubkey
+		if n.PubKey != nil {
+			pubkey = *n.PubKey
+		}
+
+		s.Names.Register(n.Name, n.Owner, pubkey, n.ExpireHeight)
+	}
+
+	for _, n := range state.Names {
+		if n.Primary {
+			s.Names.SetReverse(n.Owner, n.Name)
+		}
+	}
+
 	return nil
 }
 
@@ -328,6 +517,20 @@ func (s *State) Export(height uint64) types.AppState {
 		log.Panicf("Create new state at height %d failed: %s", height, err)
 	}
 
+	return export(state, height)
+}
+
+// ExportWorking exports state with changes written to the tree by WorkingHash, which are not committed yet
+func (s *State) ExportWorking(height uint64) types.AppState {
+	state, err := newCheckStateForTree(s.tree, nil, s.db, 0)
+	if err != nil {
+		log.Panicf("Create working state failed: %s", err)
+	}
+
+	return export(state, height)
+}
+
+func export(state *CheckState, height uint64) types.AppState {
 	appState := new(types.AppState)
 	state.App().Export(appState, height)
 	state.Validators().Export(appState)
@@ -336,8 +539,10 @@ func (s *State) Export(height uint64) types.AppState {
 	state.FrozenFunds().Export(appState, height)
 	state.Accounts().Export(appState)
 	state.Coins().Export(appState)
-	state.Checks().Export(appState)
+	state.Checks().Export(appState, height)
 	state.Halts().Export(appState)
+	state.Allowances().Export(appState, height)
+	state.Names().Export(appState, height)
 
 	return *appState
 }
@@ -402,6 +607,16 @@ func newStateForTree(iavlTree tree.MTree, events eventsdb.IEventsDB, db db.DB, k
 		return nil, err
 	}
 
+	allowancesState, err := allowances.NewAllowances(stateBus, iavlTree)
+	if err != nil {
+		return nil, err
+	}
+
+	namesState, err := names.NewNames(stateBus, iavlTree)
+	if err != nil {
+		return nil, err
+	}
+
 	state := &State{
 		Validators:  validatorsState,
 		App:         appState,
@@ -413,13 +628,15 @@ func newStateForTree(iavlTree tree.MTree, events eventsdb.IEventsDB, db db.DB, k
 		Checker:     stateChecker,
 		Halts:       haltsState,
 		Waitlist:    waitlistState,
+		Allowances:  allowancesState,
+		Names:       namesState,
 
 		bus: stateBus,
 
-		db:             db,
-		events:         events,
-		tree:           iavlTree,
-		keepLastStates: keepLastStates,
+		db:      db,
+		events:  events,
+		tree:    iavlTree,
+		pruning: Pruning{Strategy: PruningWindow, KeepRecent: keepLastStates},
 	}
 
 	return state, nil
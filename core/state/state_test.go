@@ -9,6 +9,7 @@ import (
 	"log"
 	"math/big"
 	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -90,6 +91,22 @@ func TestStateExport(t *testing.T) {
 
 	state.Checks.UseCheck(newCheck)
 
+	revokedCheck := &check.Check{
+		Nonce:    []byte("test revoked nonce"),
+		ChainID:  types.CurrentChainID,
+		DueBlock: height + 1,
+		Coin:     coinTestID,
+		Value:    helpers.BipToPip(big.NewInt(100)),
+		GasCoin:  coinTest2ID,
+	}
+
+	err = revokedCheck.Sign(privateKey1)
+	if err != nil {
+		log.Panicf("Cannot sign check: %s", err)
+	}
+
+	state.Checks.RevokeCheck(revokedCheck)
+
 	state.Halts.AddHaltBlock(height, types.Pubkey{0})
 	state.Halts.AddHaltBlock(height+1, types.Pubkey{1})
 	state.Halts.AddHaltBlock(height+2, types.Pubkey{2})
@@ -100,6 +117,10 @@ func TestStateExport(t *testing.T) {
 	state.Waitlist.AddWaitList(wlAddr1, candidatePubKey1, coinTestID, big.NewInt(1e18))
 	state.Waitlist.AddWaitList(wlAddr2, candidatePubKey2, coinTest2ID, big.NewInt(2e18))
 
+	state.Allowances.Approve(address1, address2, coinTestID, big.NewInt(1e18), 0, 10, big.NewInt(1e17), height)
+
+	state.Names.Register("test", address1, candidatePubKey1, height+10)
+
 	_, err = state.Commit()
 	if err != nil {
 		log.Panicf("Cannot commit state: %s", err)
@@ -196,6 +217,17 @@ func TestStateExport(t *testing.T) {
 		t.Fatal("Wrong new state used check data")
 	}
 
+	if len(newState.ExpirableChecks) != 1 {
+		t.Fatalf("Wrong new state expirable checks size. Expected %d, got %d", 1, len(newState.ExpirableChecks))
+	}
+
+	expirableCheck := newState.ExpirableChecks[0]
+	if string("Mx"+expirableCheck.Hash) != revokedCheck.Hash().String() ||
+		expirableCheck.DueBlock != revokedCheck.DueBlock ||
+		!expirableCheck.Revoked {
+		t.Fatal("Wrong new state expirable check data")
+	}
+
 	if len(newState.Accounts) != 2 {
 		t.Fatalf("Wrong new state accounts size. Expected %d, got %d", 2, len(newState.Accounts))
 	}
@@ -291,4 +323,193 @@ func TestStateExport(t *testing.T) {
 	if newState.Waitlist[1].Coin != uint64(coinTestID) || newState.Waitlist[1].Value != big.NewInt(1e18).String() || newState.Waitlist[1].Owner.Compare(wlAddr1) != 0 {
 		t.Fatal("Invalid waitlist data")
 	}
+
+	if len(newState.Allowances) != 1 {
+		t.Fatalf("Invalid amount of allowances: %d. Expected 1", len(newState.Allowances))
+	}
+
+	allowance := newState.Allowances[0]
+	if allowance.Owner != address1 || allowance.Spender != address2 || allowance.Coin != uint64(coinTestID) ||
+		allowance.Value != big.NewInt(1e18).String() || allowance.Period != 10 || allowance.PeriodLimit != big.NewInt(1e17).String() {
+		t.Fatal("Invalid allowance data")
+	}
+
+	if len(newState.Names) != 1 {
+		t.Fatalf("Invalid amount of names: %d. Expected 1", len(newState.Names))
+	}
+
+	name := newState.Names[0]
+	if name.Name != "test" || name.Owner != address1 || name.PubKey == nil || *name.PubKey != candidatePubKey1 ||
+		name.ExpireHeight != height+10 || !name.Primary {
+		t.Fatal("Invalid name data")
+	}
+}
+
+func TestStateInitSupply(t *testing.T) {
+	state, err := NewState(0, db.NewMemDB(), emptyEvents{}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := types.StringToAddress("1")
+	pubkey := types.Pubkey{1}
+
+	err = state.Import(types.AppState{
+		Accounts: []types.Account{
+			{
+				Address: address,
+				Balance: []types.Balance{
+					{Coin: uint64(types.GetBaseCoinID()), Value: "100"},
+					{Coin: 1, Value: "10"},
+				},
+			},
+		},
+		Coins: []types.Coin{
+			{ID: 1, Name: "TEST", Symbol: types.StrToCoinSymbol("TEST"), Volume: "10", Crr: 50, Reserve: "50", MaxSupply: "1000"},
+		},
+		FrozenFunds: []types.FrozenFund{
+			{Height: 10, Address: address, CandidateKey: &pubkey, Coin: uint64(types.GetBaseCoinID()), Value: "3"},
+		},
+		TotalSlashed: "5",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := state.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if state.App.IsSupplyTracked() {
+		t.Fatal("Supply is tracked after import")
+	}
+
+	state.InitSupply(2)
+
+	if state.App.GetSupply().String() != "158" {
+		t.Fatalf("Wrong supply. Expected 158, got %s", state.App.GetSupply())
+	}
+
+	if emission := helpers.BipToPip(big.NewInt(333)); state.App.GetEmission().Cmp(emission) != 0 {
+		t.Fatalf("Wrong emission. Expected %s, got %s", emission, state.App.GetEmission())
+	}
+
+	if frozen := state.App.GetFrozenTotals()[types.GetBaseCoinID()]; frozen == nil || frozen.String() != "3" {
+		t.Fatalf("Wrong frozen total. Expected 3, got %s", frozen)
+	}
+
+	state.App.AddEmission(big.NewInt(7))
+	state.Accounts.AddBalance(address, types.GetBaseCoinID(), big.NewInt(7))
+
+	if err := state.Check(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := state.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if state.App.GetSupply().String() != "165" {
+		t.Fatalf("Wrong supply. Expected 165, got %s", state.App.GetSupply())
+	}
+
+	state.App.AddEmission(big.NewInt(7))
+	state.Accounts.AddBalance(address, types.GetBaseCoinID(), big.NewInt(6))
+
+	if err := state.Check(); err == nil {
+		t.Fatal("Emission different from change of balances passed check")
+	}
+}
+
+func TestStateExportImportSupply(t *testing.T) {
+	state, err := NewState(0, db.NewMemDB(), emptyEvents{}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := types.StringToAddress("1")
+	state.Accounts.AddBalance(address, types.GetBaseCoinID(), big.NewInt(100))
+	state.App.InitSupply(big.NewInt(30), big.NewInt(100))
+
+	state.App.AddEmission(big.NewInt(7))
+	state.Accounts.AddBalance(address, types.GetBaseCoinID(), big.NewInt(7))
+
+	if _, err := state.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	exported := state.Export(1)
+	if exported.Emission != "37" || exported.Supply != "107" {
+		t.Fatalf("Wrong exported emission %s or supply %s", exported.Emission, exported.Supply)
+	}
+
+	imported, err := NewState(0, db.NewMemDB(), emptyEvents{}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := imported.Import(exported); err != nil {
+		t.Fatal(err)
+	}
+
+	if !imported.App.IsSupplyTracked() {
+		t.Fatal("Supply is not tracked after import")
+	}
+
+	if imported.App.GetEmission().String() != "37" || imported.App.GetSupply().String() != "107" {
+		t.Fatalf("Wrong imported emission %s or supply %s", imported.App.GetEmission(), imported.App.GetSupply())
+	}
+
+	exported.Supply = "106"
+	if err := exported.Verify(); err == nil || !strings.Contains(err.Error(), "supply of base coin") {
+		t.Fatalf("Genesis with supply different from held base coin is verified: %v", err)
+	}
+}
+
+func TestStateFrozenAndWaitlistTotals(t *testing.T) {
+	state, err := NewState(0, db.NewMemDB(), emptyEvents{}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := types.StringToAddress("1")
+	pubkey := types.Pubkey{1}
+	coin := types.GetBaseCoinID()
+	state.Candidates.Create(address, address, address, pubkey, 10)
+
+	// totals are not tracked until supply is initialized
+	state.FrozenFunds.AddFund(10, address, pubkey, state.Candidates.ID(pubkey), coin, big.NewInt(1))
+	if len(state.App.GetFrozenTotals()) != 0 {
+		t.Fatal("Frozen funds are tracked before supply")
+	}
+
+	state.App.InitSupply(big.NewInt(0), big.NewInt(0))
+
+	state.FrozenFunds.AddFund(10, address, pubkey, state.Candidates.ID(pubkey), coin, big.NewInt(100))
+	state.FrozenFunds.AddFund(11, address, pubkey, state.Candidates.ID(pubkey), coin, big.NewInt(20))
+	state.FrozenFunds.PunishFrozenFundsWithID(11, 11, state.Candidates.ID(pubkey))
+	state.Waitlist.AddWaitList(address, pubkey, coin, big.NewInt(30))
+
+	if err := state.App.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if frozen := state.App.GetFrozenTotals()[coin]; frozen == nil || frozen.String() != "119" {
+		t.Fatalf("Wrong frozen total. Expected 119, got %s", frozen)
+	}
+
+	if waitlisted := state.App.GetWaitlistTotals()[coin]; waitlisted == nil || waitlisted.String() != "30" {
+		t.Fatalf("Wrong waitlist total. Expected 30, got %s", waitlisted)
+	}
+
+	state.FrozenFunds.Delete(11)
+	state.Waitlist.Delete(address, pubkey, coin)
+
+	if frozen := state.App.GetFrozenTotals()[coin]; frozen == nil || frozen.String() != "100" {
+		t.Fatalf("Wrong frozen total. Expected 100, got %s", frozen)
+	}
+
+	if _, ok := state.App.GetWaitlistTotals()[coin]; ok {
+		t.Fatal("Total of deleted waitlist is kept")
+	}
 }
@@ -151,6 +151,7 @@ func (wl *WaitList) AddWaitList(address types.Address, pubkey types.Pubkey, coin
 	wl.setToMap(address, w)
 	w.markDirty(address)
 	wl.bus.Checker().AddCoin(coin, value)
+	wl.bus.App().AddWaitlistTotal(coin, value)
 }
 
 func (wl *WaitList) Delete(address types.Address, pubkey types.Pubkey, coin types.CoinID) {
@@ -178,6 +179,7 @@ func (wl *WaitList) Delete(address types.Address, pubkey types.Pubkey, coin type
 	wl.markDirty(address)
 	wl.setToMap(address, w)
 	wl.bus.Checker().AddCoin(coin, big.NewInt(0).Neg(value))
+	wl.bus.App().AddWaitlistTotal(coin, big.NewInt(0).Neg(value))
 }
 
 func (wl *WaitList) getOrNew(address types.Address) *Model {
@@ -1,6 +1,7 @@
 package waitlist
 
 import (
+	"github.com/MinterTeam/minter-go-node/core/state/app"
 	"github.com/MinterTeam/minter-go-node/core/state/bus"
 	"github.com/MinterTeam/minter-go-node/core/state/candidates"
 	"github.com/MinterTeam/minter-go-node/core/state/checker"
@@ -15,6 +16,11 @@ func TestWaitListToGetByAddressAndPubKey(t *testing.T) {
 	b := bus.NewBus()
 	b.SetChecker(checker.NewChecker(b))
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	appBus, err := app.NewApp(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetApp(appBus)
 
 	wl, err := NewWaitList(b, mutableTree)
 	if err != nil {
@@ -59,6 +65,11 @@ func TestWaitListToPartialDelete(t *testing.T) {
 	b := bus.NewBus()
 	b.SetChecker(checker.NewChecker(b))
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	appBus, err := app.NewApp(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetApp(appBus)
 
 	wl, err := NewWaitList(b, mutableTree)
 	if err != nil {
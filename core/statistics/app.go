@@ -0,0 +1,154 @@
+package statistics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UnknownTxType is a label of transactions which can't be decoded
+const UnknownTxType = "unknown"
+
+type appMetrics struct {
+	sync.Mutex
+	blockGas int64
+
+	txs                  *prometheus.CounterVec
+	checkTxRejections    *prometheus.CounterVec
+	blockGasProm         prometheus.Gauge
+	commitDuration       prometheus.Gauge
+	saveVersionDuration  prometheus.Gauge
+	recalculateDuration  prometheus.Gauge
+	stakeKicks           prometheus.Counter
+	mempoolSendersAmount prometheus.Gauge
+}
+
+func newAppMetrics() appMetrics {
+	txs := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "txs",
+			Help: "Delivered transactions by type and result code",
+		},
+		[]string{"type", "code"},
+	)
+	prometheus.MustRegister(txs)
+	checkTxRejections := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "check_tx_rejections",
+			Help: "Transactions rejected by CheckTx by result code",
+		},
+		[]string{"code"},
+	)
+	prometheus.MustRegister(checkTxRejections)
+	blockGas := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "block_gas_used",
+			Help: "Gas used by transactions of last block",
+		},
+	)
+	prometheus.MustRegister(blockGas)
+	commitDuration := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "commit_duration",
+			Help: "Last commit duration",
+		},
+	)
+	prometheus.MustRegister(commitDuration)
+	saveVersionDuration := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "iavl_save_version_duration",
+			Help: "Last IAVL save version duration",
+		},
+	)
+	prometheus.MustRegister(saveVersionDuration)
+	recalculateDuration := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "stakes_recalculation_duration",
+			Help: "Last stakes recalculation duration",
+		},
+	)
+	prometheus.MustRegister(recalculateDuration)
+	stakeKicks := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "stake_kicks",
+			Help: "Stakes moved to waitlist by stakes recalculation",
+		},
+	)
+	prometheus.MustRegister(stakeKicks)
+	mempoolSenders := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "mempool_senders",
+			Help: "Senders of transactions in mempool since last commit",
+		},
+	)
+	prometheus.MustRegister(mempoolSenders)
+
+	return appMetrics{
+		txs:                  txs,
+		checkTxRejections:    checkTxRejections,
+		blockGasProm:         blockGas,
+		commitDuration:       commitDuration,
+		saveVersionDuration:  saveVersionDuration,
+		recalculateDuration:  recalculateDuration,
+		stakeKicks:           stakeKicks,
+		mempoolSendersAmount: mempoolSenders,
+	}
+}
+
+// AddDeliverTx counts delivered transaction and adds its gas to gas used by the current block
+func (d *Data) AddDeliverTx(txType string, code uint32, gasUsed int64) {
+	if d == nil {
+		return
+	}
+
+	d.App.Lock()
+	defer d.App.Unlock()
+
+	d.App.txs.With(prometheus.Labels{"type": txType, "code": strconv.Itoa(int(code))}).Inc()
+	d.App.blockGas += gasUsed
+}
+
+// AddCheckTxRejection counts transaction rejected by CheckTx
+func (d *Data) AddCheckTxRejection(code uint32) {
+	if d == nil {
+		return
+	}
+
+	d.App.checkTxRejections.With(prometheus.Labels{"code": strconv.Itoa(int(code))}).Inc()
+}
+
+// SetCommit sets durations of the last commit and of saving its IAVL version, and gas used by the committed block
+func (d *Data) SetCommit(commit time.Duration, saveVersion time.Duration) {
+	if d == nil {
+		return
+	}
+
+	d.App.Lock()
+	defer d.App.Unlock()
+
+	d.App.commitDuration.Set(commit.Seconds())
+	d.App.saveVersionDuration.Set(saveVersion.Seconds())
+	d.App.blockGasProm.Set(float64(d.App.blockGas))
+	d.App.blockGas = 0
+}
+
+// SetStakesRecalculation sets duration of the last stakes recalculation and counts stakes kicked by it
+func (d *Data) SetStakesRecalculation(duration time.Duration, kicks int) {
+	if d == nil {
+		return
+	}
+
+	d.App.recalculateDuration.Set(duration.Seconds())
+	d.App.stakeKicks.Add(float64(kicks))
+}
+
+// SetMempoolSenders sets amount of senders of transactions accepted to mempool since last commit
+func (d *Data) SetMempoolSenders(amount int) {
+	if d == nil {
+		return
+	}
+
+	d.App.mempoolSendersAmount.Set(float64(amount))
+}
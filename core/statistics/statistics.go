@@ -91,6 +91,7 @@ type Data struct {
 
 	Api  apiResponseTime
 	Peer peerPing
+	App  appMetrics
 }
 
 type StartRequest struct {
@@ -170,6 +171,7 @@ func New() *Data {
 		Api:      apiResponseTime{responseTime: apiVec},
 		Peer:     peerPing{ping: peerVec},
 		BlockEnd: blockEnd{HeightProm: height, DurationProm: lastBlockDuration, TimestampProm: timeBlock},
+		App:      newAppMetrics(),
 		cS:       make(chan *StartRequest, 120),
 		cE:       make(chan *EndRequest, 120),
 	}
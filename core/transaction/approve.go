@@ -0,0 +1,147 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/commissions"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	"github.com/tendermint/tendermint/libs/kv"
+)
+
+// ApproveData allows Spender to transfer up to Value of sender's Coin with TransferFrom transactions.
+// Allowance can't be spent after ExpireHeight, if set. If Period is set, no more than PeriodLimit
+// can be spent within each Period blocks. Zero Value removes the allowance.
+type ApproveData struct {
+	Spender      types.Address
+	Coin         types.CoinID
+	Value        *big.Int
+	ExpireHeight uint64
+	Period       uint64
+	PeriodLimit  *big.Int
+}
+
+func (data ApproveData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.Value == nil || data.PeriodLimit == nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	if (data.Period == 0) != (data.PeriodLimit.Sign() == 0) {
+		return &Response{
+			Code: code.WrongAllowancePeriod,
+			Log:  "Period and period limit should be set together",
+			Info: EncodeError(code.NewWrongAllowancePeriod(strconv.FormatUint(data.Period, 10), data.PeriodLimit.String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if data.Spender == sender {
+		return &Response{
+			Code: code.SelfAllowanceForbidden,
+			Log:  "Spender should differ from sender",
+			Info: EncodeError(code.NewSelfAllowanceForbidden(sender.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data ApproveData) String() string {
+	return fmt.Sprintf("APPROVE spender:%s coin:%s value:%s",
+		data.Spender.String(), data.Coin.String(), data.Value.String())
+}
+
+func (data ApproveData) Gas() int64 {
+	return commissions.ApproveTx
+}
+
+func (data ApproveData) upgradeBlock() uint64 {
+	return upgrades.UpgradeBlock2
+}
+
+func (data ApproveData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.BasicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	if data.ExpireHeight != 0 && data.ExpireHeight < currentBlock {
+		return Response{
+			Code: code.AllowanceExpired,
+			Log:  "Allowance expire height is in the past",
+			Info: EncodeError(code.NewAllowanceExpired(strconv.FormatUint(data.ExpireHeight, 10), strconv.FormatUint(currentBlock, 10))),
+		}
+	}
+
+	commissionInBaseCoin := tx.CommissionInBaseCoin()
+	commission := big.NewInt(0).Set(commissionInBaseCoin)
+
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+
+	if !tx.GasCoin.IsBaseCoin() {
+		errResp := CheckReserveUnderflow(gasCoin, commissionInBaseCoin)
+		if errResp != nil {
+			return *errResp
+		}
+
+		commission = calculateSaleAmount(checkState, gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	if deliverState, ok := context.(*state.State); ok {
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Coins.SubReserve(tx.GasCoin, commissionInBaseCoin)
+		deliverState.Coins.SubVolume(tx.GasCoin, commission)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		deliverState.Allowances.Approve(sender, data.Spender, data.Coin, data.Value, data.ExpireHeight, data.Period, data.PeriodLimit, currentBlock)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+	}
+
+	tags := kv.Pairs{
+		kv.Pair{Key: []byte("tx.type"), Value: []byte(hex.EncodeToString([]byte{byte(TypeApprove)}))},
+		kv.Pair{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(sender[:]))},
+		kv.Pair{Key: []byte("tx.spender"), Value: []byte(hex.EncodeToString(data.Spender[:]))},
+		kv.Pair{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String())},
+	}
+
+	return Response{
+		Code:      code.OK,
+		GasUsed:   tx.Gas(),
+		GasWanted: tx.Gas(),
+		Tags:      tags,
+	}
+}
@@ -0,0 +1,111 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+)
+
+func TestApproveTx(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	spender := types.Address{1}
+	value := helpers.BipToPip(big.NewInt(100))
+	periodLimit := helpers.BipToPip(big.NewInt(10))
+
+	data := ApproveData{
+		Spender:      spender,
+		Coin:         coin,
+		Value:        value,
+		ExpireHeight: upgrades.UpgradeBlock2 + 100,
+		Period:       10,
+		PeriodLimit:  periodLimit,
+	}
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeApprove, data, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	allowance := cState.Allowances.Get(addr, spender, coin)
+	if allowance == nil {
+		t.Fatal("Allowance not found")
+	}
+
+	if allowance.Value.Cmp(value) != 0 || allowance.ExpireHeight != upgrades.UpgradeBlock2+100 || allowance.Period != 10 || allowance.PeriodLimit.Cmp(periodLimit) != 0 {
+		t.Fatal("Allowance is not correct")
+	}
+
+	if available := allowance.Available(upgrades.UpgradeBlock2 + 2); available.Cmp(periodLimit) != 0 {
+		t.Fatalf("Available allowance is not correct. Expected %s, got %s", periodLimit, available)
+	}
+
+	data.Value = big.NewInt(0)
+	data.Period = 0
+	data.PeriodLimit = big.NewInt(0)
+
+	response = RunTx(cState, makeTestCheckTx(t, 2, TypeApprove, data, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	if cState.Allowances.Get(addr, spender, coin) != nil {
+		t.Fatal("Allowance is not removed")
+	}
+
+	checkState(t, cState)
+}
+
+func TestApproveTxToSelf(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	data := ApproveData{
+		Spender:     addr,
+		Coin:        coin,
+		Value:       helpers.BipToPip(big.NewInt(100)),
+		PeriodLimit: big.NewInt(0),
+	}
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeApprove, data, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.SelfAllowanceForbidden {
+		t.Fatalf("Response code is not %d. Error %s", code.SelfAllowanceForbidden, response.Log)
+	}
+}
+
+func TestApproveTxWithWrongPeriod(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	data := ApproveData{
+		Spender:     types.Address{1},
+		Coin:        coin,
+		Value:       helpers.BipToPip(big.NewInt(100)),
+		Period:      10,
+		PeriodLimit: big.NewInt(0),
+	}
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeApprove, data, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.WrongAllowancePeriod {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongAllowancePeriod, response.Log)
+	}
+}
@@ -0,0 +1,108 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+)
+
+func TestBurnTokenTx(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+	tokenID := createTestToken(cState, types.Address{1})
+
+	holding := helpers.BipToPip(big.NewInt(100))
+	cState.Accounts.SubBalance(types.Address{1}, tokenID, holding)
+	cState.Accounts.AddBalance(addr, tokenID, holding)
+
+	cState.Checker.Reset()
+
+	value := helpers.BipToPip(big.NewInt(40))
+	tx, err := makeTestTokenTx(TypeBurnToken, BurnTokenData{Coin: tokenID, Value: value}, coin, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, tx, big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	checkTokenVolumeDelta(t, cState, tokenID)
+
+	checkState(t, cState)
+
+	targetVolume := big.NewInt(0).Sub(helpers.BipToPip(big.NewInt(100000)), value)
+	if volume := cState.Coins.GetCoin(tokenID).Volume(); volume.Cmp(targetVolume) != 0 {
+		t.Fatalf("Volume in state is not correct. Expected %s, got %s", targetVolume, volume)
+	}
+
+	targetBalance := big.NewInt(0).Sub(holding, value)
+	if balance := cState.Accounts.GetBalance(addr, tokenID); balance.Cmp(targetBalance) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", tokenID, targetBalance, balance)
+	}
+}
+
+func TestBurnTokenTxWithInsufficientFunds(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+	tokenID := createTestToken(cState, types.Address{1})
+
+	tx, err := makeTestTokenTx(TypeBurnToken, BurnTokenData{Coin: tokenID, Value: big.NewInt(1)}, coin, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, tx, big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientFunds, response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestSellTokenTx(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+	tokenID := createTestToken(cState, addr)
+
+	data := SellCoinData{
+		CoinToSell:        tokenID,
+		ValueToSell:       helpers.BipToPip(big.NewInt(10)),
+		CoinToBuy:         coin,
+		MinimumValueToBuy: big.NewInt(0),
+	}
+
+	tx, err := makeTestTokenTx(TypeSellCoin, data, coin, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, tx, big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != code.CoinHasNotReserve {
+		t.Fatalf("Response code is not %d. Error %s", code.CoinHasNotReserve, response.Log)
+	}
+
+	checkState(t, cState)
+}
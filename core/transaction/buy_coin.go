@@ -7,7 +7,6 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/commissions"
 	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/types"
-	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/tendermint/tendermint/libs/kv"
 	"math/big"
 )
@@ -49,7 +48,7 @@ func (data BuyCoinData) totalSpend(tx *Transaction, context *state.CheckState) (
 	switch {
 	case data.CoinToSell.IsBaseCoin():
 		coin := context.Coins().GetCoin(data.CoinToBuy)
-		value = formula.CalculatePurchaseAmount(coin.Volume(), coin.Reserve(), coin.Crr(), data.ValueToBuy)
+		value = calculatePurchaseAmount(context, coin.Volume(), coin.Reserve(), coin.Crr(), data.ValueToBuy)
 
 		if value.Cmp(data.MaximumValueToSell) == 1 {
 			return nil, nil, nil, &Response{
@@ -87,7 +86,7 @@ func (data BuyCoinData) totalSpend(tx *Transaction, context *state.CheckState) (
 				}
 			}
 
-			commission := formula.CalculateSaleAmount(nVolume, nReserveBalance, coin.Crr(), commissionInBaseCoin)
+			commission := calculateSaleAmount(context, nVolume, nReserveBalance, coin.Crr(), commissionInBaseCoin)
 
 			total.Add(tx.GasCoin, commission)
 			conversions = append(conversions, conversion{
@@ -114,7 +113,7 @@ func (data BuyCoinData) totalSpend(tx *Transaction, context *state.CheckState) (
 		}
 
 		coin := context.Coins().GetCoin(data.CoinToSell)
-		value = formula.CalculateSaleAmount(coin.Volume(), coin.Reserve(), coin.Crr(), valueToBuy)
+		value = calculateSaleAmount(context, coin.Volume(), coin.Reserve(), coin.Crr(), valueToBuy)
 
 		if value.Cmp(data.MaximumValueToSell) == 1 {
 			return nil, nil, nil, &Response{
@@ -138,7 +137,7 @@ func (data BuyCoinData) totalSpend(tx *Transaction, context *state.CheckState) (
 
 		coinFrom := context.Coins().GetCoin(data.CoinToSell)
 		coinTo := context.Coins().GetCoin(data.CoinToBuy)
-		baseCoinNeeded := formula.CalculatePurchaseAmount(coinTo.Volume(), coinTo.Reserve(), coinTo.Crr(), valueToBuy)
+		baseCoinNeeded := calculatePurchaseAmount(context, coinTo.Volume(), coinTo.Reserve(), coinTo.Crr(), valueToBuy)
 
 		if coinFrom.Reserve().Cmp(baseCoinNeeded) < 0 {
 			return nil, nil, nil, &Response{
@@ -183,7 +182,7 @@ func (data BuyCoinData) totalSpend(tx *Transaction, context *state.CheckState) (
 				}
 			}
 
-			commission := formula.CalculateSaleAmount(nVolume, nReserveBalance, coinTo.Crr(), commissionInBaseCoin)
+			commission := calculateSaleAmount(context, nVolume, nReserveBalance, coinTo.Crr(), commissionInBaseCoin)
 
 			total.Add(tx.GasCoin, commission)
 			conversions = append(conversions, conversion{
@@ -194,7 +193,7 @@ func (data BuyCoinData) totalSpend(tx *Transaction, context *state.CheckState) (
 			})
 		}
 
-		value = formula.CalculateSaleAmount(coinFrom.Volume(), coinFrom.Reserve(), coinFrom.Crr(), baseCoinNeeded)
+		value = calculateSaleAmount(context, coinFrom.Volume(), coinFrom.Reserve(), coinFrom.Crr(), baseCoinNeeded)
 
 		if value.Cmp(data.MaximumValueToSell) == 1 {
 			return nil, nil, nil, &Response{
@@ -231,7 +230,7 @@ func (data BuyCoinData) totalSpend(tx *Transaction, context *state.CheckState) (
 				}
 			}
 
-			commission := formula.CalculateSaleAmount(nVolume, nReserveBalance, coinFrom.Crr(), commissionInBaseCoin)
+			commission := calculateSaleAmount(context, nVolume, nReserveBalance, coinFrom.Crr(), commissionInBaseCoin)
 
 			total.Add(tx.GasCoin, commission)
 			conversions = append(conversions, conversion{
@@ -285,7 +284,7 @@ func (data BuyCoinData) totalSpend(tx *Transaction, context *state.CheckState) (
 				}
 			}
 
-			commission = formula.CalculateSaleAmount(coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
+			commission = calculateSaleAmount(context, coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
 			conversions = append(conversions, conversion{
 				FromCoin:    tx.GasCoin,
 				FromAmount:  commission,
@@ -326,6 +325,14 @@ func (data BuyCoinData) BasicCheck(tx *Transaction, context *state.CheckState) *
 		}
 	}
 
+	if errResp := CheckCoinHasReserve(context.Coins().GetCoin(data.CoinToSell)); errResp != nil {
+		return errResp
+	}
+
+	if errResp := CheckCoinHasReserve(context.Coins().GetCoin(data.CoinToBuy)); errResp != nil {
+		return errResp
+	}
+
 	if data.CoinToSell == data.CoinToBuy {
 		return &Response{
 			Code: code.CrossConvert,
@@ -10,7 +10,6 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/code"
 	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/types"
-	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/MinterTeam/minter-go-node/helpers"
 	"github.com/tendermint/tendermint/libs/kv"
 )
@@ -20,11 +19,13 @@ const allowedCoinSymbols = "^[A-Z0-9]{3,10}$"
 
 var (
 	minCoinSupply                      = helpers.BipToPip(big.NewInt(1))
-	minCoinReserve                     = helpers.BipToPip(big.NewInt(10000))
 	maxCoinSupply                      = big.NewInt(0).Exp(big.NewInt(10), big.NewInt(15+18), nil)
 	allowedCoinSymbolsRegexpCompile, _ = regexp.Compile(allowedCoinSymbols)
 )
 
+// MinCoinReserve is the minimal reserve a coin may have after its creation and any conversion.
+var MinCoinReserve = helpers.BipToPip(big.NewInt(10000))
+
 type CreateCoinData struct {
 	Name                 string
 	Symbol               types.CoinSymbol
@@ -79,7 +80,7 @@ func (data CreateCoinData) BasicCheck(tx *Transaction, context *state.CheckState
 		return &Response{
 			Code: code.WrongCoinSupply,
 			Log:  fmt.Sprintf("Max coin supply should be less than %s", maxCoinSupply),
-			Info: EncodeError(code.NewWrongCoinSupply(maxCoinSupply.String(), data.MaxSupply.String(), minCoinReserve.String(), data.InitialReserve.String(), minCoinSupply.String(), data.MaxSupply.String(), data.InitialAmount.String())),
+			Info: EncodeError(code.NewWrongCoinSupply(maxCoinSupply.String(), data.MaxSupply.String(), MinCoinReserve.String(), data.InitialReserve.String(), minCoinSupply.String(), data.MaxSupply.String(), data.InitialAmount.String())),
 		}
 	}
 
@@ -87,15 +88,15 @@ func (data CreateCoinData) BasicCheck(tx *Transaction, context *state.CheckState
 		return &Response{
 			Code: code.WrongCoinSupply,
 			Log:  fmt.Sprintf("Coin supply should be between %s and %s", minCoinSupply.String(), data.MaxSupply.String()),
-			Info: EncodeError(code.NewWrongCoinSupply(maxCoinSupply.String(), data.MaxSupply.String(), minCoinReserve.String(), data.InitialReserve.String(), minCoinSupply.String(), data.MaxSupply.String(), data.InitialAmount.String())),
+			Info: EncodeError(code.NewWrongCoinSupply(maxCoinSupply.String(), data.MaxSupply.String(), MinCoinReserve.String(), data.InitialReserve.String(), minCoinSupply.String(), data.MaxSupply.String(), data.InitialAmount.String())),
 		}
 	}
 
-	if data.InitialReserve.Cmp(minCoinReserve) == -1 {
+	if data.InitialReserve.Cmp(MinCoinReserve) == -1 {
 		return &Response{
 			Code: code.WrongCoinSupply,
-			Log:  fmt.Sprintf("Coin reserve should be greater than or equal to %s", minCoinReserve.String()),
-			Info: EncodeError(code.NewWrongCoinSupply(maxCoinSupply.String(), data.MaxSupply.String(), minCoinReserve.String(), data.InitialReserve.String(), minCoinSupply.String(), data.MaxSupply.String(), data.InitialAmount.String())),
+			Log:  fmt.Sprintf("Coin reserve should be greater than or equal to %s", MinCoinReserve.String()),
+			Info: EncodeError(code.NewWrongCoinSupply(maxCoinSupply.String(), data.MaxSupply.String(), MinCoinReserve.String(), data.InitialReserve.String(), minCoinSupply.String(), data.MaxSupply.String(), data.InitialAmount.String())),
 		}
 	}
 
@@ -108,7 +109,11 @@ func (data CreateCoinData) String() string {
 }
 
 func (data CreateCoinData) Gas() int64 {
-	switch len(data.Symbol.String()) {
+	return createCoinGas(data.Symbol)
+}
+
+func createCoinGas(symbol types.CoinSymbol) int64 {
+	switch len(symbol.String()) {
 	case 3:
 		return 1000000000 // 1mln bips
 	case 4:
@@ -146,7 +151,7 @@ func (data CreateCoinData) Run(tx *Transaction, context state.Interface, rewardP
 			return *errResp
 		}
 
-		commission = formula.CalculateSaleAmount(coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
+		commission = calculateSaleAmount(checkState, coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
 	}
 
 	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
@@ -0,0 +1,160 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	"github.com/tendermint/tendermint/libs/kv"
+)
+
+type CreateTokenData struct {
+	Name          string
+	Symbol        types.CoinSymbol
+	InitialAmount *big.Int
+	MaxSupply     *big.Int
+}
+
+func (data CreateTokenData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.InitialAmount == nil || data.MaxSupply == nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if len(data.Name) > maxCoinNameBytes {
+		return &Response{
+			Code: code.InvalidCoinName,
+			Log:  fmt.Sprintf("Coin name is invalid. Allowed up to %d bytes.", maxCoinNameBytes),
+			Info: EncodeError(code.NewInvalidCoinName(strconv.Itoa(maxCoinNameBytes), strconv.Itoa(len(data.Name)))),
+		}
+	}
+
+	if match := allowedCoinSymbolsRegexpCompile.MatchString(data.Symbol.String()); !match {
+		return &Response{
+			Code: code.InvalidCoinSymbol,
+			Log:  fmt.Sprintf("Invalid coin symbol. Should be %s", allowedCoinSymbols),
+			Info: EncodeError(code.NewInvalidCoinSymbol(allowedCoinSymbols, data.Symbol.String())),
+		}
+	}
+
+	if context.Coins().ExistsBySymbol(data.Symbol) {
+		return &Response{
+			Code: code.CoinAlreadyExists,
+			Log:  "Coin already exists",
+			Info: EncodeError(code.NewCoinAlreadyExists(types.StrToCoinSymbol(data.Symbol.String()).String(), context.Coins().GetCoinBySymbol(data.Symbol, 0).ID().String())),
+		}
+	}
+
+	if data.MaxSupply.Cmp(maxCoinSupply) == 1 {
+		return &Response{
+			Code: code.WrongCoinSupply,
+			Log:  fmt.Sprintf("Max coin supply should be less than %s", maxCoinSupply),
+			Info: EncodeError(code.NewWrongCoinSupply(maxCoinSupply.String(), data.MaxSupply.String(), "", "", minCoinSupply.String(), data.MaxSupply.String(), data.InitialAmount.String())),
+		}
+	}
+
+	if data.InitialAmount.Cmp(minCoinSupply) == -1 || data.InitialAmount.Cmp(data.MaxSupply) == 1 {
+		return &Response{
+			Code: code.WrongCoinSupply,
+			Log:  fmt.Sprintf("Coin supply should be between %s and %s", minCoinSupply.String(), data.MaxSupply.String()),
+			Info: EncodeError(code.NewWrongCoinSupply(maxCoinSupply.String(), data.MaxSupply.String(), "", "", minCoinSupply.String(), data.MaxSupply.String(), data.InitialAmount.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateTokenData) String() string {
+	return fmt.Sprintf("CREATE TOKEN symbol:%s amount:%s max supply:%s",
+		data.Symbol.String(), data.InitialAmount, data.MaxSupply)
+}
+
+func (data CreateTokenData) Gas() int64 {
+	return createCoinGas(data.Symbol)
+}
+
+func (data CreateTokenData) upgradeBlock() uint64 {
+	return upgrades.UpgradeBlock2
+}
+
+func (data CreateTokenData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+	response := data.BasicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := tx.CommissionInBaseCoin()
+	commission := big.NewInt(0).Set(commissionInBaseCoin)
+
+	if tx.GasCoin != types.GetBaseCoinID() {
+		coin := checkState.Coins().GetCoin(tx.GasCoin)
+
+		errResp := CheckReserveUnderflow(coin, commissionInBaseCoin)
+		if errResp != nil {
+			return *errResp
+		}
+
+		commission = calculateSaleAmount(checkState, coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var coinId = checkState.App().GetNextCoinID()
+	if deliverState, ok := context.(*state.State); ok {
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Coins.SubReserve(tx.GasCoin, commissionInBaseCoin)
+		deliverState.Coins.SubVolume(tx.GasCoin, commission)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		deliverState.Coins.CreateToken(
+			coinId,
+			data.Symbol,
+			data.Name,
+			data.InitialAmount,
+			data.MaxSupply,
+			&sender,
+		)
+
+		deliverState.App.SetCoinsCount(coinId.Uint32())
+		deliverState.Accounts.AddBalance(sender, coinId, data.InitialAmount)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+	}
+
+	tags := kv.Pairs{
+		kv.Pair{Key: []byte("tx.type"), Value: []byte(hex.EncodeToString([]byte{byte(TypeCreateToken)}))},
+		kv.Pair{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(sender[:]))},
+		kv.Pair{Key: []byte("tx.coin_symbol"), Value: []byte(data.Symbol.String())},
+		kv.Pair{Key: []byte("tx.coin_id"), Value: []byte(coinId.String())},
+	}
+
+	return Response{
+		Code:      code.OK,
+		Tags:      tags,
+		GasUsed:   tx.Gas(),
+		GasWanted: tx.Gas(),
+	}
+}
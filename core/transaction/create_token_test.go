@@ -0,0 +1,211 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+)
+
+func TestCreateTokenTx(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	toCreate := types.StrToCoinSymbol("ABCDEF")
+	amount := helpers.BipToPip(big.NewInt(100))
+	maxSupply := big.NewInt(0).Mul(amount, big.NewInt(10))
+	name := "My Test Token"
+
+	data := CreateTokenData{
+		Name:          name,
+		Symbol:        toCreate,
+		InitialAmount: amount,
+		MaxSupply:     maxSupply,
+	}
+
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       coin,
+		Type:          TypeCreateToken,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, encodedTx, big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	checkState(t, cState)
+
+	targetBalance, _ := big.NewInt(0).SetString("999000000000000000000000", 10)
+	balance := cState.Accounts.GetBalance(addr, coin)
+	if balance.Cmp(targetBalance) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", coin, targetBalance, balance)
+	}
+
+	stateCoin := cState.Coins.GetCoinBySymbol(toCreate, 0)
+	if stateCoin == nil {
+		t.Fatalf("Coin %s not found in state", toCreate)
+	}
+
+	if !stateCoin.IsToken() {
+		t.Fatalf("Coin %s is not a token", toCreate)
+	}
+
+	if stateCoin.Reserve().Sign() != 0 {
+		t.Fatalf("Reserve balance in state is not correct. Expected 0, got %s", stateCoin.Reserve())
+	}
+
+	if stateCoin.Volume().Cmp(amount) != 0 {
+		t.Fatalf("Volume in state is not correct. Expected %s, got %s", amount, stateCoin.Volume())
+	}
+
+	if stateCoin.MaxSupply().Cmp(maxSupply) != 0 {
+		t.Fatalf("Max supply in state is not correct. Expected %s, got %s", maxSupply, stateCoin.MaxSupply())
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, stateCoin.ID()); balance.Cmp(amount) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", toCreate, amount, balance)
+	}
+
+	symbolInfo := cState.Coins.GetSymbolInfo(toCreate)
+	if symbolInfo == nil {
+		t.Fatalf("Symbol %s info not found in state", toCreate)
+	}
+
+	if *symbolInfo.OwnerAddress() != addr {
+		t.Fatalf("Target owner address is not correct. Expected %s, got %s", addr.String(), symbolInfo.OwnerAddress().String())
+	}
+}
+
+func TestCreateTokenTxWithExistingSymbol(t *testing.T) {
+	cState := getState()
+
+	createTestCoin(cState)
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	amount := helpers.BipToPip(big.NewInt(100))
+	data := CreateTokenData{
+		Name:          "My Test Token",
+		Symbol:        getTestCoinSymbol(),
+		InitialAmount: amount,
+		MaxSupply:     big.NewInt(0).Mul(amount, big.NewInt(10)),
+	}
+
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       coin,
+		Type:          TypeCreateToken,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, encodedTx, big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != code.CoinAlreadyExists {
+		t.Fatalf("Response code is not %d. Error %s", code.CoinAlreadyExists, response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestCreateTokenTxBeforeUpgrade(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	amount := helpers.BipToPip(big.NewInt(100))
+	data := CreateTokenData{
+		Name:          "My Test Token",
+		Symbol:        types.StrToCoinSymbol("ABCDEF"),
+		InitialAmount: amount,
+		MaxSupply:     big.NewInt(0).Mul(amount, big.NewInt(10)),
+	}
+
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       coin,
+		Type:          TypeCreateToken,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, encodedTx, big.NewInt(0), upgrades.UpgradeBlock2-1, &sync.Map{}, 0)
+	if response.Code != code.DecodeError {
+		t.Fatalf("Response code is not %d. Error %s", code.DecodeError, response.Log)
+	}
+
+	if cState.Coins.GetCoinBySymbol(data.Symbol, 0) != nil {
+		t.Fatalf("Coin %s is created before upgrade", data.Symbol)
+	}
+
+	checkState(t, cState)
+}
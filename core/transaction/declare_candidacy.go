@@ -8,7 +8,6 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/types"
 	"github.com/MinterTeam/minter-go-node/core/validators"
-	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/tendermint/tendermint/libs/kv"
 	"math/big"
 )
@@ -24,6 +23,10 @@ type DeclareCandidacyData struct {
 	Stake      *big.Int
 }
 
+func (data DeclareCandidacyData) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
 func (data DeclareCandidacyData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
 	if data.Stake == nil {
 		return &Response{
@@ -41,6 +44,10 @@ func (data DeclareCandidacyData) BasicCheck(tx *Transaction, context *state.Chec
 		}
 	}
 
+	if errResp := CheckCoinHasReserve(context.Coins().GetCoin(data.Coin)); errResp != nil {
+		return errResp
+	}
+
 	if context.Candidates().Exists(data.PubKey) {
 		return &Response{
 			Code: code.CandidateExists,
@@ -113,7 +120,7 @@ func (data DeclareCandidacyData) Run(tx *Transaction, context state.Interface, r
 			return *errResp
 		}
 
-		commission = formula.CalculateSaleAmount(gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+		commission = calculateSaleAmount(checkState, gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
 	}
 
 	if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(data.Stake) < 0 {
@@ -1,10 +1,12 @@
 package transaction
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/MinterTeam/minter-go-node/rlp"
 	"reflect"
+	"sort"
 )
 
 var TxDecoder = Decoder{
@@ -32,6 +34,17 @@ func init() {
 	TxDecoder.RegisterType(TypeEditMultisig, EditMultisigData{})
 	TxDecoder.RegisterType(TypePriceVote, PriceVoteData{})
 	TxDecoder.RegisterType(TypeEditCandidatePublicKey, EditCandidatePublicKeyData{})
+	TxDecoder.RegisterType(TypeCreateToken, CreateTokenData{})
+	TxDecoder.RegisterType(TypeMintToken, MintTokenData{})
+	TxDecoder.RegisterType(TypeBurnToken, BurnTokenData{})
+	TxDecoder.RegisterType(TypeEditCandidateCommission, EditCandidateCommissionData{})
+	TxDecoder.RegisterType(TypeRevokeCheck, RevokeCheckData{})
+	TxDecoder.RegisterType(TypeRedeemPartialCheck, RedeemPartialCheckData{})
+	TxDecoder.RegisterType(TypeApprove, ApproveData{})
+	TxDecoder.RegisterType(TypeTransferFrom, TransferFromData{})
+	TxDecoder.RegisterType(TypeRegisterName, RegisterNameData{})
+	TxDecoder.RegisterType(TypeRenewName, RenewNameData{})
+	TxDecoder.RegisterType(TypeTransferName, TransferNameData{})
 }
 
 type Decoder struct {
@@ -42,6 +55,30 @@ func (decoder *Decoder) RegisterType(t TxType, d Data) {
 	decoder.registeredTypes[t] = d
 }
 
+// RegisteredTypes returns registered types of transactions in ascending order
+func (decoder *Decoder) RegisteredTypes() []TxType {
+	txTypes := make([]TxType, 0, len(decoder.registeredTypes))
+	for t := range decoder.registeredTypes {
+		txTypes = append(txTypes, t)
+	}
+
+	sort.Slice(txTypes, func(i, j int) bool {
+		return txTypes[i] < txTypes[j]
+	})
+
+	return txTypes
+}
+
+// NewData returns zero value of data of the registered type
+func (decoder *Decoder) NewData(t TxType) (Data, error) {
+	d, ok := decoder.registeredTypes[t]
+	if !ok {
+		return nil, fmt.Errorf("tx type %x is not registered", t)
+	}
+
+	return reflect.Zero(reflect.TypeOf(d)).Interface().(Data), nil
+}
+
 func (decoder *Decoder) DecodeFromBytes(buf []byte) (*Transaction, error) {
 	tx, err := decoder.DecodeFromBytesWithoutSig(buf)
 	if err != nil {
@@ -107,3 +144,18 @@ func (decoder *Decoder) DecodeFromBytesWithoutSig(buf []byte) (*Transaction, err
 
 	return &tx, nil
 }
+
+// DecodeDataFromJSON decodes data of the registered type from its JSON representation
+func (decoder *Decoder) DecodeDataFromJSON(t TxType, buf []byte) (Data, error) {
+	d, ok := decoder.registeredTypes[t]
+	if !ok {
+		return nil, fmt.Errorf("tx type %x is not registered", t)
+	}
+
+	data := reflect.New(reflect.TypeOf(d))
+	if err := json.Unmarshal(buf, data.Interface()); err != nil {
+		return nil, err
+	}
+
+	return data.Elem().Interface().(Data), nil
+}
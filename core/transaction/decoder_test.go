@@ -118,3 +118,45 @@ func TestDecodeFromBytesWithoutSigToInvalidData(t *testing.T) {
 		t.Fatal("Expected invalid data error")
 	}
 }
+
+func TestDecodeDataFromJSON(t *testing.T) {
+	data, err := TxDecoder.DecodeDataFromJSON(TypeSend, []byte(`{"Coin":1,"To":"Mx0000000000000000000000000000000000000010","Value":1000000000000000000000}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sendData, ok := data.(SendData)
+	if !ok {
+		t.Fatalf("Expected SendData, got %T", data)
+	}
+
+	if sendData.Coin != 1 || sendData.To != types.BytesToAddress([]byte{0x10}) || sendData.Value.String() != "1000000000000000000000" {
+		t.Fatalf("Unexpected data: %s", sendData.String())
+	}
+
+	if _, err := TxDecoder.DecodeDataFromJSON(0xFF, []byte(`{}`)); err == nil {
+		t.Fatal("Expected error for unregistered type")
+	}
+}
+
+func TestDecoder_RegisteredTypes(t *testing.T) {
+	txTypes := TxDecoder.RegisteredTypes()
+	if len(txTypes) == 0 || txTypes[0] != TypeSend {
+		t.Fatalf("unexpected registered types: %v", txTypes)
+	}
+
+	for i, txType := range txTypes {
+		if i > 0 && txTypes[i-1] >= txType {
+			t.Fatalf("registered types are not sorted: %v", txTypes)
+		}
+
+		data, err := TxDecoder.NewData(txType)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := rlp.EncodeToBytes(data); err != nil {
+			t.Errorf("cannot encode zero data of type 0x%02X: %s", byte(txType), err)
+		}
+	}
+}
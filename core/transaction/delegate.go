@@ -7,8 +7,8 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/commissions"
 	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/types"
-	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/MinterTeam/minter-go-node/hexutil"
+	"github.com/MinterTeam/minter-go-node/upgrades"
 	"github.com/tendermint/tendermint/libs/kv"
 	"math/big"
 )
@@ -17,6 +17,13 @@ type DelegateData struct {
 	PubKey types.Pubkey
 	Coin   types.CoinID
 	Value  *big.Int
+
+	// Name is an optional name of candidate, which is resolved to public key instead of PubKey
+	Name []string `rlp:"tail"`
+}
+
+func (data DelegateData) GetPubKey() types.Pubkey {
+	return data.PubKey
 }
 
 func (data DelegateData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
@@ -44,6 +51,10 @@ func (data DelegateData) BasicCheck(tx *Transaction, context *state.CheckState)
 		}
 	}
 
+	if errResp := CheckCoinHasReserve(context.Coins().GetCoin(data.Coin)); errResp != nil {
+		return errResp
+	}
+
 	sender, _ := tx.Sender()
 	value := big.NewInt(0).Set(data.Value)
 	if waitList := context.WaitList().Get(sender, data.PubKey, data.Coin); waitList != nil {
@@ -77,6 +88,39 @@ func (data DelegateData) BasicCheck(tx *Transaction, context *state.CheckState)
 	return nil
 }
 
+// withResolvedName returns data with PubKey set to public key resolved from Name
+func (data DelegateData) withResolvedName(context *state.CheckState, currentBlock uint64) (DelegateData, *Response) {
+	if len(data.Name) == 0 {
+		return data, nil
+	}
+
+	if len(data.Name) > 1 || data.PubKey != (types.Pubkey{}) {
+		return data, &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	pubkey, response := resolveNamePubKey(data.Name[0], context, currentBlock)
+	if response != nil {
+		return data, response
+	}
+
+	data.PubKey, data.Name = pubkey, nil
+
+	return data, nil
+}
+
+// upgradeBlock makes Name available only since UpgradeBlock2, data without it is valid at any height
+func (data DelegateData) upgradeBlock() uint64 {
+	if len(data.Name) == 0 {
+		return 0
+	}
+
+	return upgrades.UpgradeBlock2
+}
+
 func (data DelegateData) String() string {
 	return fmt.Sprintf("DELEGATE pubkey:%s ",
 		hexutil.Encode(data.PubKey[:]))
@@ -95,7 +139,12 @@ func (data DelegateData) Run(tx *Transaction, context state.Interface, rewardPoo
 		checkState = state.NewCheckState(context.(*state.State))
 	}
 
-	response := data.BasicCheck(tx, checkState)
+	data, response := data.withResolvedName(checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	response = data.BasicCheck(tx, checkState)
 	if response != nil {
 		return *response
 	}
@@ -112,7 +161,7 @@ func (data DelegateData) Run(tx *Transaction, context state.Interface, rewardPoo
 			return *errResp
 		}
 
-		commission = formula.CalculateSaleAmount(gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+		commission = calculateSaleAmount(checkState, gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
 	}
 
 	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
@@ -167,6 +216,7 @@ func (data DelegateData) Run(tx *Transaction, context state.Interface, rewardPoo
 	tags := kv.Pairs{
 		kv.Pair{Key: []byte("tx.type"), Value: []byte(hex.EncodeToString([]byte{byte(TypeDelegate)}))},
 		kv.Pair{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(sender[:]))},
+		kv.Pair{Key: []byte("tx.public_key"), Value: []byte(hex.EncodeToString(data.PubKey[:]))},
 	}
 
 	return Response{
@@ -0,0 +1,135 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/commissions"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/state/candidates"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	"github.com/tendermint/tendermint/libs/kv"
+)
+
+type EditCandidateCommissionData struct {
+	PubKey     types.Pubkey
+	Commission uint32
+}
+
+func (data EditCandidateCommissionData) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
+func (data EditCandidateCommissionData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
+	errResp := checkCandidateOwnership(data, tx, context)
+	if errResp != nil {
+		return errResp
+	}
+
+	candidate := context.Candidates().GetCandidate(data.PubKey)
+
+	min, max := int64(minCommission), int64(maxCommission)
+	if current := int64(candidate.Commission); current-candidates.MaxCommissionEditDelta > min {
+		min = current - candidates.MaxCommissionEditDelta
+	}
+	if current := int64(candidate.Commission); current+candidates.MaxCommissionEditDelta < max {
+		max = current + candidates.MaxCommissionEditDelta
+	}
+
+	if int64(data.Commission) < min || int64(data.Commission) > max {
+		return &Response{
+			Code: code.WrongCommission,
+			Log:  fmt.Sprintf("Commission should be between %d and %d", min, max),
+			Info: EncodeError(code.NewWrongCommission(fmt.Sprintf("%d", data.Commission), strconv.Itoa(int(min)), strconv.Itoa(int(max)))),
+		}
+	}
+
+	return nil
+}
+
+func (data EditCandidateCommissionData) String() string {
+	return fmt.Sprintf("EDIT CANDIDATE COMMISSION pubkey: %x commission: %d",
+		data.PubKey, data.Commission)
+}
+
+func (data EditCandidateCommissionData) Gas() int64 {
+	return commissions.EditCandidateCommission
+}
+
+func (data EditCandidateCommissionData) upgradeBlock() uint64 {
+	return upgrades.UpgradeBlock2
+}
+
+func (data EditCandidateCommissionData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.BasicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	candidate := checkState.Candidates().GetCandidate(data.PubKey)
+	if lastEditHeight := candidate.LastEditCommissionHeight(); lastEditHeight != 0 && currentBlock < lastEditHeight+candidates.EditCommissionPeriod {
+		nextEditHeight := lastEditHeight + candidates.EditCommissionPeriod
+		return Response{
+			Code: code.TooEarlyCommissionEdit,
+			Log:  fmt.Sprintf("Commission of the candidate can be edited again at block %d", nextEditHeight),
+			Info: EncodeError(code.NewTooEarlyCommissionEdit(strconv.FormatUint(lastEditHeight, 10), strconv.FormatUint(nextEditHeight, 10))),
+		}
+	}
+
+	commissionInBaseCoin := tx.CommissionInBaseCoin()
+	commission := big.NewInt(0).Set(commissionInBaseCoin)
+
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+
+	if !tx.GasCoin.IsBaseCoin() {
+		errResp := CheckReserveUnderflow(gasCoin, commissionInBaseCoin)
+		if errResp != nil {
+			return *errResp
+		}
+
+		commission = calculateSaleAmount(checkState, gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	if deliverState, ok := context.(*state.State); ok {
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Coins.SubReserve(tx.GasCoin, commissionInBaseCoin)
+		deliverState.Coins.SubVolume(tx.GasCoin, commission)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		deliverState.Candidates.EditCommission(data.PubKey, data.Commission, currentBlock)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+	}
+
+	tags := kv.Pairs{
+		kv.Pair{Key: []byte("tx.type"), Value: []byte(hex.EncodeToString([]byte{byte(TypeEditCandidateCommission)}))},
+		kv.Pair{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(sender[:]))},
+	}
+
+	return Response{
+		Code:      code.OK,
+		GasUsed:   tx.Gas(),
+		GasWanted: tx.Gas(),
+		Tags:      tags,
+	}
+}
@@ -0,0 +1,157 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/state/candidates"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+)
+
+func makeEditCandidateCommissionTx(t *testing.T, nonce uint64, pubkey types.Pubkey, commission uint32, privateKey *ecdsa.PrivateKey) []byte {
+	encodedData, err := rlp.EncodeToBytes(EditCandidateCommissionData{
+		PubKey:     pubkey,
+		Commission: commission,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          TypeEditCandidateCommission,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encodedTx
+}
+
+func TestEditCandidateCommissionTx(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := [32]byte{}
+	rand.Read(pubkey[:])
+
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10)
+
+	response := RunTx(cState, makeEditCandidateCommissionTx(t, 1, pubkey, 20, privateKey), big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	targetBalance, _ := big.NewInt(0).SetString("999990000000000000000000", 10)
+	balance := cState.Accounts.GetBalance(addr, coin)
+	if balance.Cmp(targetBalance) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", coin, targetBalance, balance)
+	}
+
+	candidate := cState.Candidates.GetCandidate(pubkey)
+	if candidate.Commission != 10 {
+		t.Fatalf("Commission is changed before delay: %d", candidate.Commission)
+	}
+
+	commission, height, ok := candidate.PendingCommission()
+	if !ok || commission != 20 || height != upgrades.UpgradeBlock2+candidates.EditCommissionDelay {
+		t.Fatalf("Pending commission is not correct: %d at %d", commission, height)
+	}
+
+	cState.Candidates.ApplyPendingCommissions(height)
+	if candidate.Commission != 20 {
+		t.Fatalf("Commission is not applied: %d", candidate.Commission)
+	}
+}
+
+func TestEditCandidateCommissionTxTooFar(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := [32]byte{}
+	rand.Read(pubkey[:])
+
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10)
+
+	response := RunTx(cState, makeEditCandidateCommissionTx(t, 1, pubkey, 21, privateKey), big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != code.WrongCommission {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongCommission, response.Log)
+	}
+
+	response = RunTx(cState, makeEditCandidateCommissionTx(t, 1, pubkey, 0, privateKey), big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+}
+
+func TestEditCandidateCommissionTxTooEarly(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := [32]byte{}
+	rand.Read(pubkey[:])
+
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10)
+
+	response := RunTx(cState, makeEditCandidateCommissionTx(t, 1, pubkey, 15, privateKey), big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	response = RunTx(cState, makeEditCandidateCommissionTx(t, 2, pubkey, 20, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+candidates.EditCommissionPeriod-1, &sync.Map{}, 0)
+	if response.Code != code.TooEarlyCommissionEdit {
+		t.Fatalf("Response code is not %d. Error %s", code.TooEarlyCommissionEdit, response.Log)
+	}
+
+	response = RunTx(cState, makeEditCandidateCommissionTx(t, 2, pubkey, 20, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+candidates.EditCommissionPeriod, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+}
+
+func TestEditCandidateCommissionTxByNotOwner(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := [32]byte{}
+	rand.Read(pubkey[:])
+
+	cState.Candidates.Create(types.Address{1}, types.Address{1}, types.Address{1}, pubkey, 10)
+
+	response := RunTx(cState, makeEditCandidateCommissionTx(t, 1, pubkey, 15, privateKey), big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != code.IsNotOwnerOfCandidate {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCandidate, response.Log)
+	}
+}
@@ -34,26 +34,37 @@ type TransactionResponse struct {
 }
 
 var resourcesConfig = map[transaction.TxType]TxDataResource{
-	transaction.TypeSend:                   new(SendDataResource),
-	transaction.TypeSellCoin:               new(SellCoinDataResource),
-	transaction.TypeSellAllCoin:            new(SellAllCoinDataResource),
-	transaction.TypeBuyCoin:                new(BuyCoinDataResource),
-	transaction.TypeCreateCoin:             new(CreateCoinDataResource),
-	transaction.TypeDeclareCandidacy:       new(DeclareCandidacyDataResource),
-	transaction.TypeDelegate:               new(DelegateDataResource),
-	transaction.TypeUnbond:                 new(UnbondDataResource),
-	transaction.TypeRedeemCheck:            new(RedeemCheckDataResource),
-	transaction.TypeSetCandidateOnline:     new(SetCandidateOnDataResource),
-	transaction.TypeSetCandidateOffline:    new(SetCandidateOffDataResource),
-	transaction.TypeCreateMultisig:         new(CreateMultisigDataResource),
-	transaction.TypeMultisend:              new(MultiSendDataResource),
-	transaction.TypeEditCandidate:          new(EditCandidateDataResource),
-	transaction.TypeSetHaltBlock:           new(SetHaltBlockDataResource),
-	transaction.TypeRecreateCoin:           new(RecreateCoinDataResource),
-	transaction.TypeEditCoinOwner:          new(EditCoinOwnerDataResource),
-	transaction.TypeEditMultisig:           new(EditMultisigResource),
-	transaction.TypePriceVote:              new(PriceVoteResource),
-	transaction.TypeEditCandidatePublicKey: new(EditCandidatePublicKeyResource),
+	transaction.TypeSend:                    new(SendDataResource),
+	transaction.TypeSellCoin:                new(SellCoinDataResource),
+	transaction.TypeSellAllCoin:             new(SellAllCoinDataResource),
+	transaction.TypeBuyCoin:                 new(BuyCoinDataResource),
+	transaction.TypeCreateCoin:              new(CreateCoinDataResource),
+	transaction.TypeDeclareCandidacy:        new(DeclareCandidacyDataResource),
+	transaction.TypeDelegate:                new(DelegateDataResource),
+	transaction.TypeUnbond:                  new(UnbondDataResource),
+	transaction.TypeRedeemCheck:             new(RedeemCheckDataResource),
+	transaction.TypeSetCandidateOnline:      new(SetCandidateOnDataResource),
+	transaction.TypeSetCandidateOffline:     new(SetCandidateOffDataResource),
+	transaction.TypeCreateMultisig:          new(CreateMultisigDataResource),
+	transaction.TypeMultisend:               new(MultiSendDataResource),
+	transaction.TypeEditCandidate:           new(EditCandidateDataResource),
+	transaction.TypeSetHaltBlock:            new(SetHaltBlockDataResource),
+	transaction.TypeRecreateCoin:            new(RecreateCoinDataResource),
+	transaction.TypeEditCoinOwner:           new(EditCoinOwnerDataResource),
+	transaction.TypeEditMultisig:            new(EditMultisigResource),
+	transaction.TypePriceVote:               new(PriceVoteResource),
+	transaction.TypeEditCandidatePublicKey:  new(EditCandidatePublicKeyResource),
+	transaction.TypeCreateToken:             new(CreateTokenDataResource),
+	transaction.TypeMintToken:               new(MintTokenDataResource),
+	transaction.TypeBurnToken:               new(BurnTokenDataResource),
+	transaction.TypeEditCandidateCommission: new(EditCandidateCommissionDataResource),
+	transaction.TypeRevokeCheck:             new(RevokeCheckDataResource),
+	transaction.TypeRedeemPartialCheck:      new(RedeemPartialCheckDataResource),
+	transaction.TypeApprove:                 new(ApproveDataResource),
+	transaction.TypeTransferFrom:            new(TransferFromDataResource),
+	transaction.TypeRegisterName:            new(RegisterNameDataResource),
+	transaction.TypeRenewName:               new(RenewNameDataResource),
+	transaction.TypeTransferName:            new(TransferNameDataResource),
 }
 
 func NewTxEncoderJSON(context *state.CheckState) *TxEncoderJSON {
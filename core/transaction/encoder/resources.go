@@ -25,6 +25,7 @@ type SendDataResource struct {
 	Coin  CoinResource `json:"coin"`
 	To    string       `json:"to"`
 	Value string       `json:"value"`
+	Name  string       `json:"name,omitempty"`
 }
 
 // Transform returns TxDataResource from given txData. Used for JSON encoder.
@@ -32,11 +33,17 @@ func (SendDataResource) Transform(txData interface{}, context *state.CheckState)
 	data := txData.(*transaction.SendData)
 	coin := context.Coins().GetCoin(data.Coin)
 
-	return SendDataResource{
+	resource := SendDataResource{
 		To:    data.To.String(),
 		Value: data.Value.String(),
 		Coin:  CoinResource{coin.ID().Uint32(), coin.GetFullSymbol()},
 	}
+
+	if len(data.Name) != 0 {
+		resource.Name = data.Name[0]
+	}
+
+	return resource
 }
 
 // SellCoinDataResource is JSON representation of TxType 0x02
@@ -155,6 +162,7 @@ type DelegateDataResource struct {
 	PubKey string       `json:"pub_key"`
 	Coin   CoinResource `json:"coin"`
 	Value  string       `json:"value"`
+	Name   string       `json:"name,omitempty"`
 }
 
 // Transform returns TxDataResource from given txData. Used for JSON encoder.
@@ -162,11 +170,17 @@ func (DelegateDataResource) Transform(txData interface{}, context *state.CheckSt
 	data := txData.(*transaction.DelegateData)
 	coin := context.Coins().GetCoin(data.Coin)
 
-	return DelegateDataResource{
+	resource := DelegateDataResource{
 		PubKey: data.PubKey.String(),
 		Value:  data.Value.String(),
 		Coin:   CoinResource{coin.ID().Uint32(), coin.GetFullSymbol()},
 	}
+
+	if len(data.Name) != 0 {
+		resource.Name = data.Name[0]
+	}
+
+	return resource
 }
 
 // UnbondDataResource is JSON representation of TxType 0x08
@@ -399,3 +413,202 @@ func (EditCandidatePublicKeyResource) Transform(txData interface{}, context *sta
 		NewPubKey: data.NewPubKey.String(),
 	}
 }
+
+// CreateTokenDataResource is JSON representation of TxType 0x15
+type CreateTokenDataResource struct {
+	Name          string           `json:"name"`
+	Symbol        types.CoinSymbol `json:"symbol"`
+	InitialAmount string           `json:"initial_amount"`
+	MaxSupply     string           `json:"max_supply"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (CreateTokenDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.CreateTokenData)
+
+	return CreateTokenDataResource{
+		Name:          data.Name,
+		Symbol:        data.Symbol,
+		InitialAmount: data.InitialAmount.String(),
+		MaxSupply:     data.MaxSupply.String(),
+	}
+}
+
+// MintTokenDataResource is JSON representation of TxType 0x16
+type MintTokenDataResource struct {
+	Coin  CoinResource `json:"coin"`
+	Value string       `json:"value"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (MintTokenDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.MintTokenData)
+	coin := context.Coins().GetCoin(data.Coin)
+
+	return MintTokenDataResource{
+		Coin:  CoinResource{coin.ID().Uint32(), coin.GetFullSymbol()},
+		Value: data.Value.String(),
+	}
+}
+
+// BurnTokenDataResource is JSON representation of TxType 0x17
+type BurnTokenDataResource struct {
+	Coin  CoinResource `json:"coin"`
+	Value string       `json:"value"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (BurnTokenDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.BurnTokenData)
+	coin := context.Coins().GetCoin(data.Coin)
+
+	return BurnTokenDataResource{
+		Coin:  CoinResource{coin.ID().Uint32(), coin.GetFullSymbol()},
+		Value: data.Value.String(),
+	}
+}
+
+// EditCandidateCommissionDataResource is JSON representation of TxType 0x18
+type EditCandidateCommissionDataResource struct {
+	PubKey     string `json:"pub_key"`
+	Commission string `json:"commission"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (EditCandidateCommissionDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.EditCandidateCommissionData)
+
+	return EditCandidateCommissionDataResource{
+		PubKey:     data.PubKey.String(),
+		Commission: strconv.Itoa(int(data.Commission)),
+	}
+}
+
+// RevokeCheckDataResource is JSON representation of TxType 0x19
+type RevokeCheckDataResource struct {
+	RawCheck string `json:"raw_check"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (RevokeCheckDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.RevokeCheckData)
+
+	return RevokeCheckDataResource{
+		RawCheck: base64.StdEncoding.EncodeToString(data.RawCheck),
+	}
+}
+
+// RedeemPartialCheckDataResource is JSON representation of TxType 0x1A
+type RedeemPartialCheckDataResource struct {
+	RawCheck string `json:"raw_check"`
+	Proof    string `json:"proof"`
+	Value    string `json:"value"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (RedeemPartialCheckDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.RedeemPartialCheckData)
+
+	return RedeemPartialCheckDataResource{
+		RawCheck: base64.StdEncoding.EncodeToString(data.RawCheck),
+		Proof:    base64.StdEncoding.EncodeToString(data.Proof[:]),
+		Value:    data.Value.String(),
+	}
+}
+
+// ApproveDataResource is JSON representation of TxType 0x1B
+type ApproveDataResource struct {
+	Spender      string       `json:"spender"`
+	Coin         CoinResource `json:"coin"`
+	Value        string       `json:"value"`
+	ExpireHeight string       `json:"expire_height"`
+	Period       string       `json:"period"`
+	PeriodLimit  string       `json:"period_limit"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (ApproveDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.ApproveData)
+	coin := context.Coins().GetCoin(data.Coin)
+
+	return ApproveDataResource{
+		Spender:      data.Spender.String(),
+		Coin:         CoinResource{coin.ID().Uint32(), coin.GetFullSymbol()},
+		Value:        data.Value.String(),
+		ExpireHeight: strconv.FormatUint(data.ExpireHeight, 10),
+		Period:       strconv.FormatUint(data.Period, 10),
+		PeriodLimit:  data.PeriodLimit.String(),
+	}
+}
+
+// TransferFromDataResource is JSON representation of TxType 0x1C
+type TransferFromDataResource struct {
+	From  string       `json:"from"`
+	To    string       `json:"to"`
+	Coin  CoinResource `json:"coin"`
+	Value string       `json:"value"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (TransferFromDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.TransferFromData)
+	coin := context.Coins().GetCoin(data.Coin)
+
+	return TransferFromDataResource{
+		From:  data.From.String(),
+		To:    data.To.String(),
+		Coin:  CoinResource{coin.ID().Uint32(), coin.GetFullSymbol()},
+		Value: data.Value.String(),
+	}
+}
+
+// RegisterNameDataResource is JSON representation of TxType 0x1D
+type RegisterNameDataResource struct {
+	Name   string `json:"name"`
+	PubKey string `json:"pub_key,omitempty"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (RegisterNameDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.RegisterNameData)
+
+	resource := RegisterNameDataResource{
+		Name: data.Name,
+	}
+
+	if data.PubKey != (types.Pubkey{}) {
+		resource.PubKey = data.PubKey.String()
+	}
+
+	return resource
+}
+
+// RenewNameDataResource is JSON representation of TxType 0x1E
+type RenewNameDataResource struct {
+	Name string `json:"name"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (RenewNameDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.RenewNameData)
+
+	return RenewNameDataResource{
+		Name: data.Name,
+	}
+}
+
+// TransferNameDataResource is JSON representation of TxType 0x1F
+type TransferNameDataResource struct {
+	Name string `json:"name"`
+	To   string `json:"to"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (TransferNameDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.TransferNameData)
+
+	return TransferNameDataResource{
+		Name: data.Name,
+		To:   data.To.String(),
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
-	"sync"
 
 	"github.com/MinterTeam/minter-go-node/core/code"
 	"github.com/MinterTeam/minter-go-node/core/state"
@@ -30,6 +29,22 @@ type Response struct {
 	GasUsed   int64     `json:"gas_used,omitempty"`
 	Tags      []kv.Pair `json:"tags,omitempty"`
 	GasPrice  uint32    `json:"gas_price"`
+
+	// Type is a type of decoded transaction, 0 if transaction can't be decoded
+	Type TxType `json:"-"`
+}
+
+// Mempool is a set of senders of transactions accepted to mempool since the last commit
+type Mempool interface {
+	Load(sender interface{}) (value interface{}, ok bool)
+	Store(sender, value interface{})
+	Delete(sender interface{})
+}
+
+// upgradableData is implemented by data of transactions introduced at an upgrade block. Before it such transactions
+// are rejected the same way as not registered ones.
+type upgradableData interface {
+	upgradeBlock() uint64
 }
 
 // RunTx executes transaction in given context
@@ -37,7 +52,7 @@ func RunTx(context state.Interface,
 	rawTx []byte,
 	rewardPool *big.Int,
 	currentBlock uint64,
-	currentMempool *sync.Map,
+	currentMempool Mempool,
 	minGasPrice uint32) Response {
 	lenRawTx := len(rawTx)
 	if lenRawTx > maxTxLength {
@@ -57,6 +72,24 @@ func RunTx(context state.Interface,
 		}
 	}
 
+	response := executeTx(context, rawTx, tx, rewardPool, currentBlock, currentMempool, minGasPrice)
+	response.Type = tx.Type
+
+	return response
+}
+
+// executeTx checks and runs decoded transaction
+func executeTx(context state.Interface, rawTx []byte, tx *Transaction, rewardPool *big.Int, currentBlock uint64, currentMempool Mempool, minGasPrice uint32) Response {
+	if data, ok := tx.decodedData.(upgradableData); ok && currentBlock < data.upgradeBlock() {
+		return Response{
+			Code: code.DecodeError,
+			Log:  fmt.Sprintf("tx type %x is not available before block %d", tx.Type, data.upgradeBlock()),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	RecoveredSigners.load(rawTx, tx)
+
 	if tx.ChainID != types.CurrentChainID {
 		return Response{
 			Code: code.WrongChainID,
@@ -79,6 +112,10 @@ func RunTx(context state.Interface,
 		}
 	}
 
+	if errResp := CheckCoinHasReserve(checkState.Coins().GetCoin(tx.GasCoin)); errResp != nil {
+		return *errResp
+	}
+
 	if isCheck && tx.GasPrice < minGasPrice {
 		return Response{
 			Code: code.TooLowGasPrice,
@@ -141,7 +178,7 @@ func RunTx(context state.Interface,
 
 		multisigData := multisig.Multisig()
 
-		if len(tx.multisig.Signatures) > 32 || len(multisigData.Weights) < len(tx.multisig.Signatures) {
+		if len(tx.multisig.Signatures) > maxMultisigSignatures || len(multisigData.Weights) < len(tx.multisig.Signatures) {
 			return Response{
 				Code: code.IncorrectMultiSignature,
 				Log:  "Incorrect multi-signature",
@@ -149,20 +186,11 @@ func RunTx(context state.Interface,
 			}
 		}
 
-		txHash := tx.Hash()
 		var totalWeight uint32
 		var usedAccounts = map[types.Address]bool{}
 
-		for _, sig := range tx.multisig.Signatures {
-			signer, err := RecoverPlain(txHash, sig.R, sig.S, sig.V)
-			if err != nil {
-				return Response{
-					Code: code.IncorrectMultiSignature,
-					Log:  "Incorrect multi-signature",
-					Info: EncodeError(code.NewIncorrectMultiSignature()),
-				}
-			}
-
+		signers, err := tx.Signers()
+		for _, signer := range signers {
 			if usedAccounts[signer] {
 				return Response{
 					Code: code.DuplicatedAddresses,
@@ -175,6 +203,14 @@ func RunTx(context state.Interface,
 			totalWeight += multisigData.GetWeight(signer)
 		}
 
+		if err != nil {
+			return Response{
+				Code: code.IncorrectMultiSignature,
+				Log:  "Incorrect multi-signature",
+				Info: EncodeError(code.NewIncorrectMultiSignature()),
+			}
+		}
+
 		if totalWeight < multisigData.Threshold {
 			return Response{
 				Code: code.NotEnoughMultisigVotes,
@@ -185,6 +221,8 @@ func RunTx(context state.Interface,
 
 	}
 
+	RecoveredSigners.store(rawTx, tx)
+
 	if expectedNonce := checkState.Accounts().GetNonce(sender) + 1; expectedNonce != tx.Nonce {
 		return Response{
 			Code: code.WrongNonce,
@@ -202,7 +240,7 @@ func RunTx(context state.Interface,
 	response.GasPrice = tx.GasPrice
 
 	switch tx.Type {
-	case TypeCreateCoin, TypeEditCoinOwner, TypeRecreateCoin, TypeEditCandidatePublicKey:
+	case TypeCreateCoin, TypeCreateToken, TypeEditCoinOwner, TypeRecreateCoin, TypeEditCandidatePublicKey:
 		response.GasUsed = stdGas
 		response.GasWanted = stdGas
 	}
@@ -0,0 +1,142 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/commissions"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	"github.com/tendermint/tendermint/libs/kv"
+)
+
+type MintTokenData struct {
+	Coin  types.CoinID
+	Value *big.Int
+}
+
+func (data MintTokenData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	coin := context.Coins().GetCoin(data.Coin)
+	if coin == nil {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  "Coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	if !coin.IsToken() {
+		return &Response{
+			Code: code.CoinIsNotToken,
+			Log:  fmt.Sprintf("Coin %s is not a token and can't be minted", coin.GetFullSymbol()),
+			Info: EncodeError(code.NewCoinIsNotToken(coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	info := context.Coins().GetSymbolInfo(coin.Symbol())
+	if info == nil || info.OwnerAddress() == nil || *info.OwnerAddress() != sender {
+		var owner *string
+		if info != nil && info.OwnerAddress() != nil {
+			own := info.OwnerAddress().String()
+			owner = &own
+		}
+		return &Response{
+			Code: code.IsNotOwnerOfCoin,
+			Log:  "Sender is not owner of coin",
+			Info: EncodeError(code.NewIsNotOwnerOfCoin(coin.Symbol().String(), owner)),
+		}
+	}
+
+	if errResp := CheckForCoinSupplyOverflow(coin, data.Value); errResp != nil {
+		return errResp
+	}
+
+	return nil
+}
+
+func (data MintTokenData) String() string {
+	return fmt.Sprintf("MINT TOKEN coin:%s value:%s", data.Coin.String(), data.Value)
+}
+
+func (data MintTokenData) Gas() int64 {
+	return commissions.MintToken
+}
+
+func (data MintTokenData) upgradeBlock() uint64 {
+	return upgrades.UpgradeBlock2
+}
+
+func (data MintTokenData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.BasicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := tx.CommissionInBaseCoin()
+	commission := big.NewInt(0).Set(commissionInBaseCoin)
+
+	if tx.GasCoin != types.GetBaseCoinID() {
+		gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+
+		errResp := CheckReserveUnderflow(gasCoin, commissionInBaseCoin)
+		if errResp != nil {
+			return *errResp
+		}
+
+		commission = calculateSaleAmount(checkState, gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	if deliverState, ok := context.(*state.State); ok {
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Coins.SubReserve(tx.GasCoin, commissionInBaseCoin)
+		deliverState.Coins.SubVolume(tx.GasCoin, commission)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		deliverState.Coins.AddVolume(data.Coin, data.Value)
+		deliverState.Accounts.AddBalance(sender, data.Coin, data.Value)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+	}
+
+	tags := kv.Pairs{
+		kv.Pair{Key: []byte("tx.type"), Value: []byte(hex.EncodeToString([]byte{byte(TypeMintToken)}))},
+		kv.Pair{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(sender[:]))},
+		kv.Pair{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String())},
+	}
+
+	return Response{
+		Code:      code.OK,
+		Tags:      tags,
+		GasUsed:   tx.Gas(),
+		GasWanted: tx.Gas(),
+	}
+}
@@ -0,0 +1,185 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+)
+
+func createTestToken(stateDB *state.State, owner types.Address) types.CoinID {
+	volume := helpers.BipToPip(big.NewInt(100000))
+
+	id := stateDB.App.GetNextCoinID()
+	stateDB.Coins.CreateToken(id, getTestCoinSymbol(), "TEST TOKEN", volume,
+		big.NewInt(0).Mul(volume, big.NewInt(10)), &owner)
+	stateDB.App.SetCoinsCount(id.Uint32())
+	stateDB.Accounts.AddBalance(owner, id, volume)
+
+	return id
+}
+
+func makeTestTokenTx(txType TxType, data interface{}, gasCoin types.CoinID, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       gasCoin,
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		return nil, err
+	}
+
+	return rlp.EncodeToBytes(tx)
+}
+
+func checkTokenVolumeDelta(t *testing.T, cState *state.State, coin types.CoinID) {
+	delta := cState.Checker.Deltas()[coin]
+	volumeDelta := cState.Checker.VolumeDeltas()[coin]
+	if delta == nil || volumeDelta == nil || delta.Cmp(volumeDelta) != 0 {
+		t.Fatalf("Volume delta of coin %s is not correct. Balances changed by %s, volume changed by %s", coin, delta, volumeDelta)
+	}
+}
+
+func TestMintTokenTx(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+	tokenID := createTestToken(cState, addr)
+
+	cState.Checker.Reset()
+
+	value := helpers.BipToPip(big.NewInt(100))
+	tx, err := makeTestTokenTx(TypeMintToken, MintTokenData{Coin: tokenID, Value: value}, coin, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, tx, big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	checkTokenVolumeDelta(t, cState, tokenID)
+
+	checkState(t, cState)
+
+	targetVolume := big.NewInt(0).Add(helpers.BipToPip(big.NewInt(100000)), value)
+	if volume := cState.Coins.GetCoin(tokenID).Volume(); volume.Cmp(targetVolume) != 0 {
+		t.Fatalf("Volume in state is not correct. Expected %s, got %s", targetVolume, volume)
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, tokenID); balance.Cmp(targetVolume) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", tokenID, targetVolume, balance)
+	}
+}
+
+func TestMintTokenTxByNotOwner(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+	tokenID := createTestToken(cState, types.Address{1})
+
+	tx, err := makeTestTokenTx(TypeMintToken, MintTokenData{Coin: tokenID, Value: big.NewInt(1)}, coin, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, tx, big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != code.IsNotOwnerOfCoin {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCoin, response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestMintTokenTxOverMaxSupply(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+	tokenID := createTestToken(cState, addr)
+
+	tx, err := makeTestTokenTx(TypeMintToken, MintTokenData{Coin: tokenID, Value: helpers.BipToPip(big.NewInt(900000))}, coin, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, tx, big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != code.CoinSupplyOverflow {
+		t.Fatalf("Response code is not %d. Error %s", code.CoinSupplyOverflow, response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestMintTokenTxForReserveCoin(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+	coinID := createTestCoinWithOwner(cState, addr)
+
+	tx, err := makeTestTokenTx(TypeMintToken, MintTokenData{Coin: coinID, Value: big.NewInt(1)}, coin, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, tx, big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != code.CoinIsNotToken {
+		t.Fatalf("Response code is not %d. Error %s", code.CoinIsNotToken, response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestTokenAsGasCoin(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	tokenID := createTestToken(cState, addr)
+
+	tx, err := makeTestTokenTx(TypeMintToken, MintTokenData{Coin: tokenID, Value: big.NewInt(1)}, tokenID, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, tx, big.NewInt(0), upgrades.UpgradeBlock2, &sync.Map{}, 0)
+	if response.Code != code.CoinHasNotReserve {
+		t.Fatalf("Response code is not %d. Error %s", code.CoinHasNotReserve, response.Log)
+	}
+
+	checkState(t, cState)
+}
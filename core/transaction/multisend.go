@@ -12,7 +12,6 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/commissions"
 	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/types"
-	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/tendermint/tendermint/libs/kv"
 )
 
@@ -87,7 +86,7 @@ func (data MultisendData) Run(tx *Transaction, context state.Interface, rewardPo
 			return *errResp
 		}
 
-		commission = formula.CalculateSaleAmount(coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
+		commission = calculateSaleAmount(checkState, coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
 	}
 
 	if errResp := checkBalances(checkState, sender, data.List, commission, tx.GasCoin); errResp != nil {
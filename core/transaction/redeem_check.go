@@ -13,8 +13,8 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/types"
 	"github.com/MinterTeam/minter-go-node/crypto"
-	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/upgrades"
 	"github.com/tendermint/tendermint/libs/kv"
 	"golang.org/x/crypto/sha3"
 )
@@ -118,6 +118,10 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 		}
 	}
 
+	if errResp := CheckCoinHasReserve(checkState.Coins().GetCoin(decodedCheck.GasCoin)); errResp != nil {
+		return *errResp
+	}
+
 	if tx.GasCoin != decodedCheck.GasCoin {
 		return Response{
 			Code: code.WrongGasCoin,
@@ -134,6 +138,14 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 		}
 	}
 
+	if checkState.Checks().IsCheckRevoked(decodedCheck) {
+		return Response{
+			Code: code.CheckRevoked,
+			Log:  "Check revoked by issuer",
+			Info: EncodeError(code.NewCheckRevoked()),
+		}
+	}
+
 	if checkState.Checks().IsCheckUsed(decodedCheck) {
 		return Response{
 			Code: code.CheckUsed,
@@ -188,7 +200,7 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 		if errResp != nil {
 			return *errResp
 		}
-		commission = formula.CalculateSaleAmount(gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+		commission = calculateSaleAmount(checkState, gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
 	}
 
 	if decodedCheck.Coin == decodedCheck.GasCoin {
@@ -219,7 +231,12 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 	}
 
 	if deliverState, ok := context.(*state.State); ok {
-		deliverState.Checks.UseCheck(decodedCheck)
+		// checks redeemed before the upgrade are kept forever, see checks.Checks
+		if currentBlock >= upgrades.UpgradeBlock2 {
+			deliverState.Checks.UseExpirableCheck(decodedCheck)
+		} else {
+			deliverState.Checks.UseCheck(decodedCheck)
+		}
 		rewardPool.Add(rewardPool, commissionInBaseCoin)
 
 		deliverState.Coins.SubVolume(decodedCheck.GasCoin, commission)
@@ -0,0 +1,198 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	c "github.com/MinterTeam/minter-go-node/core/check"
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	"golang.org/x/crypto/sha3"
+)
+
+func makeTestPartialCheck(t *testing.T, issuer *ecdsa.PrivateKey, passphrase string, value, limit *big.Int) []byte {
+	passphraseHash := sha256.Sum256([]byte(passphrase))
+	passphrasePk, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := c.PartialCheck{
+		Nonce:           []byte{1, 2, 3},
+		ChainID:         types.CurrentChainID,
+		DueBlock:        upgrades.UpgradeBlock2 + 10,
+		Coin:            types.GetBaseCoinID(),
+		Value:           value,
+		RedemptionLimit: limit,
+		GasCoin:         types.GetBaseCoinID(),
+	}
+
+	lock, err := crypto.Sign(check.HashWithoutLock().Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check.Lock = big.NewInt(0).SetBytes(lock)
+
+	if err := check.Sign(issuer); err != nil {
+		t.Fatal(err)
+	}
+
+	rawCheck, err := rlp.EncodeToBytes(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rawCheck
+}
+
+func makeTestRedeemPartialCheckTx(t *testing.T, nonce uint64, rawCheck []byte, passphrase string, value *big.Int, receiver *ecdsa.PrivateKey) []byte {
+	passphraseHash := sha256.Sum256([]byte(passphrase))
+	passphrasePk, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var senderAddressHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		crypto.PubkeyToAddress(receiver.PublicKey),
+	})
+	hw.Sum(senderAddressHash[:0])
+
+	sig, err := crypto.Sign(senderAddressHash.Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof := [65]byte{}
+	copy(proof[:], sig)
+
+	return makeTestCheckTx(t, nonce, TypeRedeemPartialCheck, RedeemPartialCheckData{RawCheck: rawCheck, Proof: proof, Value: value}, receiver)
+}
+
+func TestRedeemPartialCheckTx(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerPrivateKey, _ := crypto.GenerateKey()
+	issuerAddr := crypto.PubkeyToAddress(issuerPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuerAddr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	rawCheck := makeTestPartialCheck(t, issuerPrivateKey, "password", helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(4)))
+
+	var receivers []types.Address
+	for _, value := range []int64{4, 4, 2} {
+		receiverPrivateKey, _ := crypto.GenerateKey()
+		receivers = append(receivers, crypto.PubkeyToAddress(receiverPrivateKey.PublicKey))
+
+		response := RunTx(cState, makeTestRedeemPartialCheckTx(t, 1, rawCheck, "password", helpers.BipToPip(big.NewInt(value)), receiverPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+		if response.Code != 0 {
+			t.Fatalf("Response code is not 0. Error %s", response.Log)
+		}
+	}
+
+	for i, value := range []int64{4, 4, 2} {
+		balance := cState.Accounts.GetBalance(receivers[i], coin)
+		if balance.Cmp(helpers.BipToPip(big.NewInt(value))) != 0 {
+			t.Fatalf("Target %s balance is not correct. Expected %d, got %s", coin, value, balance)
+		}
+	}
+
+	decodedCheck, _ := c.DecodePartialFromBytes(rawCheck)
+	if remainder := cState.Checks.GetPartialCheckRemainder(decodedCheck.Hash()); remainder == nil || remainder.Sign() != 0 {
+		t.Fatalf("Remainder of the check is not correct: %s", remainder)
+	}
+
+	receiverPrivateKey, _ := crypto.GenerateKey()
+	response := RunTx(cState, makeTestRedeemPartialCheckTx(t, 1, rawCheck, "password", big.NewInt(1), receiverPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.CheckUsed {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckUsed, response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestRedeemPartialCheckTxToWrongValue(t *testing.T) {
+	cState := getState()
+
+	issuerPrivateKey, _ := crypto.GenerateKey()
+	issuerAddr := crypto.PubkeyToAddress(issuerPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuerAddr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	rawCheck := makeTestPartialCheck(t, issuerPrivateKey, "password", helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(4)))
+
+	receiverPrivateKey, _ := crypto.GenerateKey()
+
+	response := RunTx(cState, makeTestRedeemPartialCheckTx(t, 1, rawCheck, "password", helpers.BipToPip(big.NewInt(5)), receiverPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.WrongCheckValue {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongCheckValue, response.Log)
+	}
+
+	for i := 0; i < 2; i++ {
+		response = RunTx(cState, makeTestRedeemPartialCheckTx(t, uint64(i+1), rawCheck, "password", helpers.BipToPip(big.NewInt(4)), receiverPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+		if response.Code != 0 {
+			t.Fatalf("Response code is not 0. Error %s", response.Log)
+		}
+	}
+
+	response = RunTx(cState, makeTestRedeemPartialCheckTx(t, 3, rawCheck, "password", helpers.BipToPip(big.NewInt(3)), receiverPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.WrongCheckValue {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongCheckValue, response.Log)
+	}
+}
+
+func TestRedeemPartialCheckTxToRevokedCheck(t *testing.T) {
+	cState := getState()
+
+	issuerPrivateKey, _ := crypto.GenerateKey()
+	issuerAddr := crypto.PubkeyToAddress(issuerPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuerAddr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	rawCheck := makeTestPartialCheck(t, issuerPrivateKey, "password", helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(4)))
+
+	receiverPrivateKey, _ := crypto.GenerateKey()
+
+	response := RunTx(cState, makeTestRedeemPartialCheckTx(t, 1, rawCheck, "password", helpers.BipToPip(big.NewInt(4)), receiverPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	response = RunTx(cState, makeTestCheckTx(t, 1, TypeRevokeCheck, RevokeCheckData{RawCheck: rawCheck}, issuerPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	response = RunTx(cState, makeTestRedeemPartialCheckTx(t, 2, rawCheck, "password", helpers.BipToPip(big.NewInt(4)), receiverPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.CheckRevoked {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckRevoked, response.Log)
+	}
+}
+
+func TestRedeemPartialCheckTxToWrongRedemptionLimit(t *testing.T) {
+	cState := getState()
+
+	issuerPrivateKey, _ := crypto.GenerateKey()
+	issuerAddr := crypto.PubkeyToAddress(issuerPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuerAddr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	receiverPrivateKey, _ := crypto.GenerateKey()
+
+	for _, limit := range []int64{0, 11} {
+		rawCheck := makeTestPartialCheck(t, issuerPrivateKey, "password", helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(limit)))
+
+		response := RunTx(cState, makeTestRedeemPartialCheckTx(t, 1, rawCheck, "password", helpers.BipToPip(big.NewInt(1)), receiverPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+		if response.Code != code.DecodeError {
+			t.Fatalf("Response code is not %d. Error %s", code.DecodeError, response.Log)
+		}
+	}
+
+	checkState(t, cState)
+}
@@ -0,0 +1,180 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/commissions"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/state/names"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	"github.com/tendermint/tendermint/libs/kv"
+)
+
+const allowedNames = "^[a-z0-9][a-z0-9-]{2,31}$"
+
+var allowedNamesRegexpCompile = regexp.MustCompile(allowedNames)
+
+// RegisterNameData registers Name for sender's address. If PubKey is set, the name also resolves
+// to public key of candidate controlled by sender.
+type RegisterNameData struct {
+	Name   string
+	PubKey types.Pubkey
+}
+
+func (data RegisterNameData) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
+func (data RegisterNameData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if !allowedNamesRegexpCompile.MatchString(data.Name) {
+		return &Response{
+			Code: code.InvalidName,
+			Log:  fmt.Sprintf("Invalid name. Should be %s", allowedNames),
+			Info: EncodeError(code.NewInvalidName(allowedNames, data.Name)),
+		}
+	}
+
+	if data.PubKey != (types.Pubkey{}) {
+		return checkCandidateControl(data, tx, context)
+	}
+
+	return nil
+}
+
+func (data RegisterNameData) String() string {
+	return fmt.Sprintf("REGISTER NAME name:%s", data.Name)
+}
+
+func (data RegisterNameData) Gas() int64 {
+	return commissions.RegisterName
+}
+
+func (data RegisterNameData) upgradeBlock() uint64 {
+	return upgrades.UpgradeBlock2
+}
+
+func (data RegisterNameData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.BasicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	if name := checkState.Names().GetName(data.Name); name != nil && !name.IsExpired(currentBlock) {
+		return Response{
+			Code: code.NameAlreadyExists,
+			Log:  "Name already exists",
+			Info: EncodeError(code.NewNameAlreadyExists(data.Name, strconv.FormatUint(name.ExpireHeight, 10))),
+		}
+	}
+
+	commissionInBaseCoin := tx.CommissionInBaseCoin()
+	commission := big.NewInt(0).Set(commissionInBaseCoin)
+
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+
+	if !tx.GasCoin.IsBaseCoin() {
+		errResp := CheckReserveUnderflow(gasCoin, commissionInBaseCoin)
+		if errResp != nil {
+			return *errResp
+		}
+
+		commission = calculateSaleAmount(checkState, gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	if deliverState, ok := context.(*state.State); ok {
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Coins.SubReserve(tx.GasCoin, commissionInBaseCoin)
+		deliverState.Coins.SubVolume(tx.GasCoin, commission)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		deliverState.Names.Register(data.Name, sender, data.PubKey, currentBlock+names.RegistrationPeriod)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+	}
+
+	tags := kv.Pairs{
+		kv.Pair{Key: []byte("tx.type"), Value: []byte(hex.EncodeToString([]byte{byte(TypeRegisterName)}))},
+		kv.Pair{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(sender[:]))},
+		kv.Pair{Key: []byte("tx.name"), Value: []byte(data.Name)},
+	}
+
+	return Response{
+		Code:      code.OK,
+		GasUsed:   tx.Gas(),
+		GasWanted: tx.Gas(),
+		Tags:      tags,
+	}
+}
+
+// checkNameOwnership returns error response if the name is not registered, expired or sender is not its owner
+func checkNameOwnership(name string, tx *Transaction, context *state.CheckState, currentBlock uint64) *Response {
+	model := context.Names().GetName(name)
+	if model == nil || model.IsExpired(currentBlock) {
+		return &Response{
+			Code: code.NameNotExists,
+			Log:  fmt.Sprintf("Name %s not exists", name),
+			Info: EncodeError(code.NewNameNotExists(name)),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if model.Owner != sender {
+		return &Response{
+			Code: code.IsNotOwnerOfName,
+			Log:  "Sender is not an owner of a name",
+			Info: EncodeError(code.NewIsNotOwnerOfName(sender.String(), model.Owner.String(), name)),
+		}
+	}
+
+	return nil
+}
+
+// resolveNameAddress returns address which the name resolves to
+func resolveNameAddress(name string, context *state.CheckState, currentBlock uint64) (types.Address, *Response) {
+	address, ok := context.Names().ResolveAddress(name, currentBlock)
+	if !ok {
+		return types.Address{}, &Response{
+			Code: code.NameNotExists,
+			Log:  fmt.Sprintf("Name %s not exists", name),
+			Info: EncodeError(code.NewNameNotExists(name)),
+		}
+	}
+
+	return address, nil
+}
+
+// resolveNamePubKey returns public key of candidate which the name resolves to
+func resolveNamePubKey(name string, context *state.CheckState, currentBlock uint64) (types.Pubkey, *Response) {
+	pubkey, ok := context.Names().ResolvePubKey(name, currentBlock)
+	if !ok {
+		return types.Pubkey{}, &Response{
+			Code: code.NameNotExists,
+			Log:  fmt.Sprintf("Name %s not exists or has no public key", name),
+			Info: EncodeError(code.NewNameNotExists(name)),
+		}
+	}
+
+	return pubkey, nil
+}
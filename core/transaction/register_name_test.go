@@ -0,0 +1,200 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/state/names"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+)
+
+func TestRegisterNameTx(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10)
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeRegisterName, RegisterNameData{Name: "alice", PubKey: pubkey}, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	targetBalance, _ := big.NewInt(0).SetString("999900000000000000000000", 10)
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(targetBalance) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", coin, targetBalance, balance)
+	}
+
+	if address, ok := cState.Names.ResolveAddress("alice", upgrades.UpgradeBlock2+2); !ok || address != addr {
+		t.Fatal("Name is not resolved to address")
+	}
+
+	if resolved, ok := cState.Names.ResolvePubKey("alice", upgrades.UpgradeBlock2+2); !ok || resolved != pubkey {
+		t.Fatal("Name is not resolved to public key")
+	}
+
+	if name := cState.Names.ReverseLookup(addr, upgrades.UpgradeBlock2+2); name != "alice" {
+		t.Fatalf("Wrong reverse lookup: %s", name)
+	}
+
+	checkState(t, cState)
+}
+
+func TestRegisterNameTxToExistingName(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	cState.Names.Register("alice", types.Address{1}, types.Pubkey{}, upgrades.UpgradeBlock2+10)
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeRegisterName, RegisterNameData{Name: "alice"}, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+10, &sync.Map{}, 0)
+	if response.Code != code.NameAlreadyExists {
+		t.Fatalf("Response code is not %d. Error %s", code.NameAlreadyExists, response.Log)
+	}
+
+	response = RunTx(cState, makeTestCheckTx(t, 1, TypeRegisterName, RegisterNameData{Name: "alice"}, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+11, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	if name := cState.Names.GetName("alice"); name.Owner != addr || name.ExpireHeight != upgrades.UpgradeBlock2+11+names.RegistrationPeriod {
+		t.Fatal("Expired name is not registered again")
+	}
+}
+
+func TestRegisterNameTxWithInvalidName(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeRegisterName, RegisterNameData{Name: "Alice"}, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.InvalidName {
+		t.Fatalf("Response code is not %d. Error %s", code.InvalidName, response.Log)
+	}
+}
+
+func TestSendTxToName(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	to := types.Address{1}
+	cState.Names.Register("alice", to, types.Pubkey{}, upgrades.UpgradeBlock2+10)
+
+	value := helpers.BipToPip(big.NewInt(10))
+	data := SendData{
+		Coin:  coin,
+		Value: value,
+		Name:  []string{"alice"},
+	}
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeSend, data, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(to, coin); balance.Cmp(value) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", coin, value, balance)
+	}
+
+	response = RunTx(cState, makeTestCheckTx(t, 2, TypeSend, data, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+11, &sync.Map{}, 0)
+	if response.Code != code.NameNotExists {
+		t.Fatalf("Response code is not %d. Error %s", code.NameNotExists, response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestSendTxToNameBeforeUpgrade(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	to := types.Address{1}
+	cState.Names.Register("alice", to, types.Pubkey{}, upgrades.UpgradeBlock2+10)
+
+	data := SendData{
+		Coin:  coin,
+		Value: helpers.BipToPip(big.NewInt(10)),
+		Name:  []string{"alice"},
+	}
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeSend, data, privateKey), big.NewInt(0), upgrades.UpgradeBlock2-1, &sync.Map{}, 0)
+	if response.Code != code.DecodeError {
+		t.Fatalf("Response code is not %d. Error %s", code.DecodeError, response.Log)
+	}
+
+	data.To, data.Name = to, nil
+	response = RunTx(cState, makeTestCheckTx(t, 1, TypeSend, data, privateKey), big.NewInt(0), upgrades.UpgradeBlock2-1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestDelegateTxToName(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	pubkey := createTestCandidate(cState)
+	cState.Names.Register("validator", types.Address{}, pubkey, upgrades.UpgradeBlock2+10)
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	data := DelegateData{
+		Coin:  coin,
+		Value: value,
+		Name:  []string{"validator"},
+	}
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeDelegate, data, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	var taggedPubKey string
+	for _, item := range response.Tags {
+		if string(item.Key) == "tx.public_key" {
+			taggedPubKey = string(item.Value)
+		}
+	}
+	if taggedPubKey != hex.EncodeToString(pubkey[:]) {
+		t.Fatalf("Wrong public key tag: %s", taggedPubKey)
+	}
+
+	cState.Candidates.RecalculateStakes(109000)
+
+	stake := cState.Candidates.GetStakeOfAddress(pubkey, addr, coin)
+	if stake == nil || stake.Value.Cmp(value) != 0 {
+		t.Fatal("Stake is not correct")
+	}
+
+	checkState(t, cState)
+}
@@ -0,0 +1,199 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/core/check"
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/commissions"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	"github.com/tendermint/tendermint/libs/kv"
+)
+
+type RevokeCheckData struct {
+	RawCheck []byte
+}
+
+func (data RevokeCheckData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.RawCheck == nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	return nil
+}
+
+func (data RevokeCheckData) String() string {
+	return fmt.Sprintf("REVOKE CHECK raw check: %x", data.RawCheck)
+}
+
+func (data RevokeCheckData) Gas() int64 {
+	return commissions.RevokeCheckTx
+}
+
+func (data RevokeCheckData) upgradeBlock() uint64 {
+	return upgrades.UpgradeBlock2
+}
+
+func (data RevokeCheckData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.BasicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	revokedCheck, err := decodeRevokedCheck(data.RawCheck)
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if revokedCheck.chainID != types.CurrentChainID {
+		return Response{
+			Code: code.WrongChainID,
+			Log:  "Wrong chain id",
+			Info: EncodeError(code.NewWrongChainID(fmt.Sprintf("%d", types.CurrentChainID), fmt.Sprintf("%d", revokedCheck.chainID))),
+		}
+	}
+
+	if revokedCheck.issuer != sender {
+		return Response{
+			Code: code.IsNotCheckIssuer,
+			Log:  "Sender is not an issuer of the check",
+			Info: EncodeError(code.NewIsNotCheckIssuer(sender.String(), revokedCheck.issuer.String())),
+		}
+	}
+
+	if revokedCheck.dueBlock < currentBlock {
+		return Response{
+			Code: code.CheckExpired,
+			Log:  "Check expired",
+			Info: EncodeError(code.MewCheckExpired(fmt.Sprintf("%d", revokedCheck.dueBlock), fmt.Sprintf("%d", currentBlock))),
+		}
+	}
+
+	if checkState.Checks().IsCheckHashRevoked(revokedCheck.hash) {
+		return Response{
+			Code: code.CheckRevoked,
+			Log:  "Check already revoked",
+			Info: EncodeError(code.NewCheckRevoked()),
+		}
+	}
+
+	isUsed := checkState.Checks().IsCheckHashUsed(revokedCheck.hash)
+	if remainder := checkState.Checks().GetPartialCheckRemainder(revokedCheck.hash); remainder != nil && remainder.Sign() == 0 {
+		isUsed = true
+	}
+
+	if isUsed {
+		return Response{
+			Code: code.CheckUsed,
+			Log:  "Check already redeemed",
+			Info: EncodeError(code.NewCheckUsed()),
+		}
+	}
+
+	commissionInBaseCoin := tx.CommissionInBaseCoin()
+	commission := big.NewInt(0).Set(commissionInBaseCoin)
+
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+
+	if !tx.GasCoin.IsBaseCoin() {
+		errResp := CheckReserveUnderflow(gasCoin, commissionInBaseCoin)
+		if errResp != nil {
+			return *errResp
+		}
+
+		commission = calculateSaleAmount(checkState, gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	if deliverState, ok := context.(*state.State); ok {
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Coins.SubReserve(tx.GasCoin, commissionInBaseCoin)
+		deliverState.Coins.SubVolume(tx.GasCoin, commission)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		deliverState.Checks.RevokeCheckHash(revokedCheck.hash, revokedCheck.dueBlock)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+	}
+
+	tags := kv.Pairs{
+		kv.Pair{Key: []byte("tx.type"), Value: []byte(hex.EncodeToString([]byte{byte(TypeRevokeCheck)}))},
+		kv.Pair{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(sender[:]))},
+		kv.Pair{Key: []byte("tx.check_hash"), Value: []byte(hex.EncodeToString(revokedCheck.hash[:]))},
+	}
+
+	return Response{
+		Code:      code.OK,
+		GasUsed:   tx.Gas(),
+		GasWanted: tx.Gas(),
+		Tags:      tags,
+	}
+}
+
+// revokedCheck holds common fields of ordinary and partial checks which are needed to revoke them
+type revokedCheck struct {
+	hash     types.Hash
+	issuer   types.Address
+	chainID  types.ChainID
+	dueBlock uint64
+}
+
+func decodeRevokedCheck(rawCheck []byte) (*revokedCheck, error) {
+	if decodedCheck, err := check.DecodeFromBytes(rawCheck); err == nil {
+		issuer, err := decodedCheck.Sender()
+		if err != nil {
+			return nil, err
+		}
+
+		return &revokedCheck{
+			hash:     decodedCheck.Hash(),
+			issuer:   issuer,
+			chainID:  decodedCheck.ChainID,
+			dueBlock: decodedCheck.DueBlock,
+		}, nil
+	}
+
+	decodedCheck, err := check.DecodePartialFromBytes(rawCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer, err := decodedCheck.Sender()
+	if err != nil {
+		return nil, err
+	}
+
+	return &revokedCheck{
+		hash:     decodedCheck.Hash(),
+		issuer:   issuer,
+		chainID:  decodedCheck.ChainID,
+		dueBlock: decodedCheck.DueBlock,
+	}, nil
+}
@@ -0,0 +1,197 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	c "github.com/MinterTeam/minter-go-node/core/check"
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	"golang.org/x/crypto/sha3"
+)
+
+func makeTestCheck(t *testing.T, issuer *ecdsa.PrivateKey, passphrase string, dueBlock uint64) []byte {
+	passphraseHash := sha256.Sum256([]byte(passphrase))
+	passphrasePk, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := c.Check{
+		Nonce:    []byte{1, 2, 3},
+		ChainID:  types.CurrentChainID,
+		DueBlock: dueBlock,
+		Coin:     types.GetBaseCoinID(),
+		Value:    helpers.BipToPip(big.NewInt(10)),
+		GasCoin:  types.GetBaseCoinID(),
+	}
+
+	lock, err := crypto.Sign(check.HashWithoutLock().Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check.Lock = big.NewInt(0).SetBytes(lock)
+
+	if err := check.Sign(issuer); err != nil {
+		t.Fatal(err)
+	}
+
+	rawCheck, err := rlp.EncodeToBytes(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rawCheck
+}
+
+func makeTestRedeemCheckTx(t *testing.T, rawCheck []byte, passphrase string, receiver *ecdsa.PrivateKey) []byte {
+	passphraseHash := sha256.Sum256([]byte(passphrase))
+	passphrasePk, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var senderAddressHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		crypto.PubkeyToAddress(receiver.PublicKey),
+	})
+	hw.Sum(senderAddressHash[:0])
+
+	sig, err := crypto.Sign(senderAddressHash.Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof := [65]byte{}
+	copy(proof[:], sig)
+
+	return makeTestCheckTx(t, 1, TypeRedeemCheck, RedeemCheckData{RawCheck: rawCheck, Proof: proof}, receiver)
+}
+
+func makeTestCheckTx(t *testing.T, nonce uint64, txType TxType, data interface{}, privateKey *ecdsa.PrivateKey) []byte {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encodedTx
+}
+
+func TestRevokeCheckTx(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerPrivateKey, _ := crypto.GenerateKey()
+	issuerAddr := crypto.PubkeyToAddress(issuerPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuerAddr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	receiverPrivateKey, _ := crypto.GenerateKey()
+	receiverAddr := crypto.PubkeyToAddress(receiverPrivateKey.PublicKey)
+
+	rawCheck := makeTestCheck(t, issuerPrivateKey, "password", upgrades.UpgradeBlock2+10)
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeRevokeCheck, RevokeCheckData{RawCheck: rawCheck}, issuerPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	decodedCheck, _ := c.DecodeFromBytes(rawCheck)
+	if !cState.Checks.IsCheckRevoked(decodedCheck) {
+		t.Fatal("Check is not revoked")
+	}
+
+	response = RunTx(cState, makeTestRedeemCheckTx(t, rawCheck, "password", receiverPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.CheckRevoked {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckRevoked, response.Log)
+	}
+
+	balance := cState.Accounts.GetBalance(receiverAddr, coin)
+	if balance.Sign() != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected 0, got %s", coin, balance)
+	}
+
+	checkState(t, cState)
+}
+
+func TestRevokeCheckTxByNotIssuer(t *testing.T) {
+	cState := getState()
+
+	issuerPrivateKey, _ := crypto.GenerateKey()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	rawCheck := makeTestCheck(t, issuerPrivateKey, "password", upgrades.UpgradeBlock2+10)
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeRevokeCheck, RevokeCheckData{RawCheck: rawCheck}, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.IsNotCheckIssuer {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotCheckIssuer, response.Log)
+	}
+}
+
+func TestRevokeCheckTxToUsedCheck(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerPrivateKey, _ := crypto.GenerateKey()
+	issuerAddr := crypto.PubkeyToAddress(issuerPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuerAddr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	receiverPrivateKey, _ := crypto.GenerateKey()
+
+	rawCheck := makeTestCheck(t, issuerPrivateKey, "password", upgrades.UpgradeBlock2+10)
+
+	response := RunTx(cState, makeTestRedeemCheckTx(t, rawCheck, "password", receiverPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	response = RunTx(cState, makeTestCheckTx(t, 1, TypeRevokeCheck, RevokeCheckData{RawCheck: rawCheck}, issuerPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.CheckUsed {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckUsed, response.Log)
+	}
+}
+
+func TestRevokeCheckTxToExpiredCheck(t *testing.T) {
+	cState := getState()
+
+	issuerPrivateKey, _ := crypto.GenerateKey()
+	issuerAddr := crypto.PubkeyToAddress(issuerPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuerAddr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	rawCheck := makeTestCheck(t, issuerPrivateKey, "password", upgrades.UpgradeBlock2+10)
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeRevokeCheck, RevokeCheckData{RawCheck: rawCheck}, issuerPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+11, &sync.Map{}, 0)
+	if response.Code != code.CheckExpired {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckExpired, response.Log)
+	}
+}
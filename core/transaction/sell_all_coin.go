@@ -7,7 +7,6 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/commissions"
 	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/types"
-	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/tendermint/tendermint/libs/kv"
 	"math/big"
 )
@@ -44,7 +43,7 @@ func (data SellAllCoinData) totalSpend(tx *Transaction, context *state.CheckStat
 		// 	}
 		// }
 
-		value = formula.CalculatePurchaseReturn(coin.Volume(), coin.Reserve(), coin.Crr(), amountToSell)
+		value = calculatePurchaseReturn(context, coin.Volume(), coin.Reserve(), coin.Crr(), amountToSell)
 
 		if value.Cmp(data.MinimumValueToBuy) == -1 {
 			return nil, nil, nil, &Response{
@@ -68,7 +67,7 @@ func (data SellAllCoinData) totalSpend(tx *Transaction, context *state.CheckStat
 		amountToSell := big.NewInt(0).Set(available)
 
 		coin := context.Coins().GetCoin(data.CoinToSell)
-		ret := formula.CalculateSaleReturn(coin.Volume(), coin.Reserve(), coin.Crr(), amountToSell)
+		ret := calculateSaleReturn(context, coin.Volume(), coin.Reserve(), coin.Crr(), amountToSell)
 
 		if ret.Cmp(data.MinimumValueToBuy) == -1 {
 			return nil, nil, nil, &Response{
@@ -101,7 +100,7 @@ func (data SellAllCoinData) totalSpend(tx *Transaction, context *state.CheckStat
 		coinFrom := context.Coins().GetCoin(data.CoinToSell)
 		coinTo := context.Coins().GetCoin(data.CoinToBuy)
 
-		basecoinValue := formula.CalculateSaleReturn(coinFrom.Volume(), coinFrom.Reserve(), coinFrom.Crr(), amountToSell)
+		basecoinValue := calculateSaleReturn(context, coinFrom.Volume(), coinFrom.Reserve(), coinFrom.Crr(), amountToSell)
 		if basecoinValue.Cmp(commissionInBaseCoin) == -1 {
 			return nil, nil, nil, &Response{
 				Code: code.InsufficientFunds,
@@ -112,7 +111,7 @@ func (data SellAllCoinData) totalSpend(tx *Transaction, context *state.CheckStat
 
 		basecoinValue.Sub(basecoinValue, commissionInBaseCoin)
 
-		value = formula.CalculatePurchaseReturn(coinTo.Volume(), coinTo.Reserve(), coinTo.Crr(), basecoinValue)
+		value = calculatePurchaseReturn(context, coinTo.Volume(), coinTo.Reserve(), coinTo.Crr(), basecoinValue)
 		if value.Cmp(data.MinimumValueToBuy) == -1 {
 			return nil, nil, nil, &Response{
 				Code: code.MinimumValueToBuyReached,
@@ -155,6 +154,14 @@ func (data SellAllCoinData) BasicCheck(tx *Transaction, context *state.CheckStat
 		}
 	}
 
+	if errResp := CheckCoinHasReserve(context.Coins().GetCoin(data.CoinToSell)); errResp != nil {
+		return errResp
+	}
+
+	if errResp := CheckCoinHasReserve(context.Coins().GetCoin(data.CoinToBuy)); errResp != nil {
+		return errResp
+	}
+
 	if data.CoinToSell == data.CoinToBuy {
 		return &Response{
 			Code: code.CrossConvert,
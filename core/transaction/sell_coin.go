@@ -7,7 +7,6 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/commissions"
 	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/types"
-	"github.com/MinterTeam/minter-go-node/formula"
 	"github.com/tendermint/tendermint/libs/kv"
 	"math/big"
 )
@@ -31,7 +30,7 @@ func (data SellCoinData) totalSpend(tx *Transaction, context *state.CheckState)
 	switch {
 	case data.CoinToSell.IsBaseCoin():
 		coin := context.Coins().GetCoin(data.CoinToBuy)
-		value = formula.CalculatePurchaseReturn(coin.Volume(), coin.Reserve(), coin.Crr(), data.ValueToSell)
+		value = calculatePurchaseReturn(context, coin.Volume(), coin.Reserve(), coin.Crr(), data.ValueToSell)
 		if value.Cmp(data.MinimumValueToBuy) == -1 {
 			return nil, nil, nil, &Response{
 				Code: code.MinimumValueToBuyReached,
@@ -49,7 +48,7 @@ func (data SellCoinData) totalSpend(tx *Transaction, context *state.CheckState)
 			nReserveBalance := big.NewInt(0).Set(coin.Reserve())
 			nReserveBalance.Add(nReserveBalance, data.ValueToSell)
 
-			commission := formula.CalculateSaleAmount(nVolume, nReserveBalance, coin.Crr(), commissionInBaseCoin)
+			commission := calculateSaleAmount(context, nVolume, nReserveBalance, coin.Crr(), commissionInBaseCoin)
 
 			total.Add(tx.GasCoin, commission)
 			conversions = append(conversions, conversion{
@@ -73,7 +72,7 @@ func (data SellCoinData) totalSpend(tx *Transaction, context *state.CheckState)
 		})
 	case data.CoinToBuy.IsBaseCoin():
 		coin := context.Coins().GetCoin(data.CoinToSell)
-		value = formula.CalculateSaleReturn(coin.Volume(), coin.Reserve(), coin.Crr(), data.ValueToSell)
+		value = calculateSaleReturn(context, coin.Volume(), coin.Reserve(), coin.Crr(), data.ValueToSell)
 
 		if value.Cmp(data.MinimumValueToBuy) == -1 {
 			return nil, nil, nil, &Response{
@@ -112,7 +111,7 @@ func (data SellCoinData) totalSpend(tx *Transaction, context *state.CheckState)
 				}
 			}
 
-			c := formula.CalculateSaleAmount(newVolume, newReserve, coin.Crr(), commissionInBaseCoin)
+			c := calculateSaleAmount(context, newVolume, newReserve, coin.Crr(), commissionInBaseCoin)
 
 			total.Add(tx.GasCoin, c)
 			conversions = append(conversions, conversion{
@@ -136,7 +135,7 @@ func (data SellCoinData) totalSpend(tx *Transaction, context *state.CheckState)
 
 		valueToSell := big.NewInt(0).Set(data.ValueToSell)
 
-		basecoinValue := formula.CalculateSaleReturn(coinFrom.Volume(), coinFrom.Reserve(), coinFrom.Crr(), data.ValueToSell)
+		basecoinValue := calculateSaleReturn(context, coinFrom.Volume(), coinFrom.Reserve(), coinFrom.Crr(), data.ValueToSell)
 		fromReserve := big.NewInt(0).Set(basecoinValue)
 
 		if tx.GasCoin == data.CoinToSell {
@@ -164,7 +163,7 @@ func (data SellCoinData) totalSpend(tx *Transaction, context *state.CheckState)
 				}
 			}
 
-			c := formula.CalculateSaleAmount(newVolume, newReserve, coinFrom.Crr(), commissionInBaseCoin)
+			c := calculateSaleAmount(context, newVolume, newReserve, coinFrom.Crr(), commissionInBaseCoin)
 
 			total.Add(tx.GasCoin, c)
 			conversions = append(conversions, conversion{
@@ -175,7 +174,7 @@ func (data SellCoinData) totalSpend(tx *Transaction, context *state.CheckState)
 			})
 		}
 
-		value = formula.CalculatePurchaseReturn(coinTo.Volume(), coinTo.Reserve(), coinTo.Crr(), basecoinValue)
+		value = calculatePurchaseReturn(context, coinTo.Volume(), coinTo.Reserve(), coinTo.Crr(), basecoinValue)
 
 		if value.Cmp(data.MinimumValueToBuy) == -1 {
 			return nil, nil, nil, &Response{
@@ -194,7 +193,7 @@ func (data SellCoinData) totalSpend(tx *Transaction, context *state.CheckState)
 			nReserveBalance := big.NewInt(0).Set(coinTo.Reserve())
 			nReserveBalance.Add(nReserveBalance, basecoinValue)
 
-			commission := formula.CalculateSaleAmount(nVolume, nReserveBalance, coinTo.Crr(), commissionInBaseCoin)
+			commission := calculateSaleAmount(context, nVolume, nReserveBalance, coinTo.Crr(), commissionInBaseCoin)
 
 			total.Add(tx.GasCoin, commission)
 			conversions = append(conversions, conversion{
@@ -244,7 +243,7 @@ func (data SellCoinData) totalSpend(tx *Transaction, context *state.CheckState)
 				}
 			}
 
-			commission = formula.CalculateSaleAmount(coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
+			commission = calculateSaleAmount(context, coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
 			conversions = append(conversions, conversion{
 				FromCoin:    tx.GasCoin,
 				FromAmount:  commission,
@@ -284,6 +283,14 @@ func (data SellCoinData) BasicCheck(tx *Transaction, context *state.CheckState)
 		}
 	}
 
+	if errResp := CheckCoinHasReserve(context.Coins().GetCoin(data.CoinToSell)); errResp != nil {
+		return errResp
+	}
+
+	if errResp := CheckCoinHasReserve(context.Coins().GetCoin(data.CoinToBuy)); errResp != nil {
+		return errResp
+	}
+
 	if data.CoinToSell == data.CoinToBuy {
 		return &Response{
 			Code: code.CrossConvert,
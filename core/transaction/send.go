@@ -7,7 +7,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/commissions"
 	"github.com/MinterTeam/minter-go-node/core/state"
 	"github.com/MinterTeam/minter-go-node/core/types"
-	"github.com/MinterTeam/minter-go-node/formula"
+	"github.com/MinterTeam/minter-go-node/upgrades"
 	"github.com/tendermint/tendermint/libs/kv"
 	"math/big"
 )
@@ -16,6 +16,9 @@ type SendData struct {
 	Coin  types.CoinID
 	To    types.Address
 	Value *big.Int
+
+	// Name is an optional name of recipient, which is resolved to address instead of To
+	Name []string `rlp:"tail"`
 }
 
 type Coin struct {
@@ -38,7 +41,7 @@ func (data SendData) totalSpend(tx *Transaction, context *state.CheckState) (Tot
 			return nil, nil, nil, errResp
 		}
 
-		commission = formula.CalculateSaleAmount(coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
+		commission = calculateSaleAmount(context, coin.Volume(), coin.Reserve(), coin.Crr(), commissionInBaseCoin)
 		conversions = append(conversions, conversion{
 			FromCoin:    tx.GasCoin,
 			FromAmount:  commission,
@@ -73,6 +76,39 @@ func (data SendData) BasicCheck(tx *Transaction, context *state.CheckState) *Res
 	return nil
 }
 
+// withResolvedName returns data with To set to address resolved from Name
+func (data SendData) withResolvedName(context *state.CheckState, currentBlock uint64) (SendData, *Response) {
+	if len(data.Name) == 0 {
+		return data, nil
+	}
+
+	if len(data.Name) > 1 || data.To != (types.Address{}) {
+		return data, &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	to, response := resolveNameAddress(data.Name[0], context, currentBlock)
+	if response != nil {
+		return data, response
+	}
+
+	data.To, data.Name = to, nil
+
+	return data, nil
+}
+
+// upgradeBlock makes Name available only since UpgradeBlock2, data without it is valid at any height
+func (data SendData) upgradeBlock() uint64 {
+	if len(data.Name) == 0 {
+		return 0
+	}
+
+	return upgrades.UpgradeBlock2
+}
+
 func (data SendData) String() string {
 	return fmt.Sprintf("SEND to:%s coin:%s value:%s",
 		data.To.String(), data.Coin.String(), data.Value.String())
@@ -91,7 +127,12 @@ func (data SendData) Run(tx *Transaction, context state.Interface, rewardPool *b
 		checkState = state.NewCheckState(context.(*state.State))
 	}
 
-	response := data.BasicCheck(tx, checkState)
+	data, response := data.withResolvedName(checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	response = data.BasicCheck(tx, checkState)
 	if response != nil {
 		return *response
 	}
@@ -0,0 +1,164 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"runtime"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/core/types"
+)
+
+const (
+	maxMultisigSignatures = 32
+	signersCacheSize      = 20000
+)
+
+// RecoveredSigners is used by RunTx, so transactions recovered in CheckTx or pre-recovered from a proposed block
+// aren't recovered again in DeliverTx
+var RecoveredSigners = NewSignersCache(signersCacheSize)
+
+// SignersCache keeps senders and multisig signers recovered from signatures of transactions. Entries are keyed by hash
+// of raw transaction and don't depend on state, so they stay valid across resets of state. The cache is bounded, the
+// oldest entries are evicted first.
+type SignersCache struct {
+	lock  sync.Mutex
+	items map[[sha256.Size]byte][]types.Address
+	keys  [][sha256.Size]byte
+	next  int
+}
+
+// NewSignersCache returns cache keeping signers of at most size transactions
+func NewSignersCache(size int) *SignersCache {
+	return &SignersCache{
+		items: make(map[[sha256.Size]byte][]types.Address, size),
+		keys:  make([][sha256.Size]byte, 0, size),
+	}
+}
+
+// Len returns amount of cached transactions
+func (c *SignersCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.items)
+}
+
+// load sets sender or multisig signers of decoded transaction if they are cached
+func (c *SignersCache) load(rawTx []byte, tx *Transaction) {
+	c.lock.Lock()
+	signers, ok := c.items[sha256.Sum256(rawTx)]
+	c.lock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	switch tx.SignatureType {
+	case SigTypeSingle:
+		sender := signers[0]
+		tx.sender = &sender
+	case SigTypeMulti:
+		tx.signers = signers
+	}
+}
+
+// store caches sender or multisig signers of transaction, if all its signatures are recovered
+func (c *SignersCache) store(rawTx []byte, tx *Transaction) {
+	var signers []types.Address
+	switch tx.SignatureType {
+	case SigTypeSingle:
+		if tx.sender == nil {
+			return
+		}
+		signers = []types.Address{*tx.sender}
+	case SigTypeMulti:
+		if tx.signers == nil {
+			return
+		}
+		signers = tx.signers
+	default:
+		return
+	}
+
+	key := sha256.Sum256(rawTx)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.items[key]; ok || cap(c.keys) == 0 {
+		return
+	}
+
+	if len(c.keys) < cap(c.keys) {
+		c.keys = append(c.keys, key)
+	} else {
+		delete(c.items, c.keys[c.next])
+		c.keys[c.next] = key
+		c.next = (c.next + 1) % len(c.keys)
+	}
+
+	c.items[key] = signers
+}
+
+// Recover recovers and caches signers of raw transaction. Invalid transactions are skipped.
+func (c *SignersCache) Recover(rawTx []byte) {
+	if len(rawTx) > maxTxLength {
+		return
+	}
+
+	c.lock.Lock()
+	_, ok := c.items[sha256.Sum256(rawTx)]
+	c.lock.Unlock()
+
+	if ok {
+		return
+	}
+
+	tx, err := TxDecoder.DecodeFromBytes(rawTx)
+	if err != nil {
+		return
+	}
+
+	switch tx.SignatureType {
+	case SigTypeSingle:
+		if _, err := tx.Sender(); err != nil {
+			return
+		}
+	case SigTypeMulti:
+		if len(tx.multisig.Signatures) > maxMultisigSignatures {
+			return
+		}
+		if _, err := tx.Signers(); err != nil {
+			return
+		}
+	}
+
+	c.store(rawTx, tx)
+}
+
+// RecoverAll recovers and caches signers of raw transactions in parallel, one worker per CPU
+func (c *SignersCache) RecoverAll(txs [][]byte) {
+	workers := runtime.NumCPU()
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+
+	jobs := make(chan []byte)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for rawTx := range jobs {
+				c.Recover(rawTx)
+			}
+		}()
+	}
+
+	for _, rawTx := range txs {
+		jobs <- rawTx
+	}
+	close(jobs)
+
+	wg.Wait()
+}
@@ -0,0 +1,168 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/state/accounts"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func makeSignedSendTx(t *testing.T, nonce uint64, multisig *types.Address, keys ...*ecdsa.PrivateKey) []byte {
+	encodedData, err := rlp.EncodeToBytes(SendData{
+		Coin:  types.GetBaseCoinID(),
+		To:    types.Address{},
+		Value: big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          TypeSend,
+		ChainID:       types.CurrentChainID,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+	if multisig != nil {
+		tx.SignatureType = SigTypeMulti
+	}
+
+	for _, key := range keys {
+		if err := tx.Sign(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if multisig != nil {
+		tx.SetMultisigAddress(*multisig)
+	}
+
+	txBytes, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return txBytes
+}
+
+func TestSignersCache_Recover(t *testing.T) {
+	cache := NewSignersCache(10)
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	txBytes := makeSignedSendTx(t, 1, nil, privateKey)
+
+	cache.Recover(txBytes)
+	if cache.Len() != 1 {
+		t.Fatalf("sender is not cached")
+	}
+
+	tx, err := TxDecoder.DecodeFromBytes(txBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.load(txBytes, tx)
+	if tx.sender == nil || *tx.sender != addr {
+		t.Fatalf("cached sender is not loaded")
+	}
+
+	msigAddress := types.Address{1}
+	cache.Recover(makeSignedSendTx(t, 1, &msigAddress, privateKey, privateKey))
+	if cache.Len() != 2 {
+		t.Fatalf("signers of multisig tx are not cached")
+	}
+
+	invalid, err := TxDecoder.DecodeFromBytes(makeSignedSendTx(t, 2, &msigAddress, privateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid.multisig.Signatures[0].V = big.NewInt(1)
+	invalid.SignatureData, err = rlp.EncodeToBytes(invalid.multisig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalidBytes, err := rlp.EncodeToBytes(invalid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Recover(invalidBytes)
+	if cache.Len() != 2 {
+		t.Fatalf("tx with invalid signature is cached")
+	}
+}
+
+func TestSignersCache_Bounded(t *testing.T) {
+	cache := NewSignersCache(2)
+
+	privateKey, _ := crypto.GenerateKey()
+	txs := [][]byte{
+		makeSignedSendTx(t, 1, nil, privateKey),
+		makeSignedSendTx(t, 2, nil, privateKey),
+		makeSignedSendTx(t, 3, nil, privateKey),
+	}
+
+	for _, tx := range txs {
+		cache.Recover(tx)
+	}
+
+	if cache.Len() != 2 {
+		t.Fatalf("cache size is %d, expected 2", cache.Len())
+	}
+
+	tx, err := TxDecoder.DecodeFromBytes(txs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.load(txs[0], tx)
+	if tx.sender != nil {
+		t.Fatalf("the oldest tx is not evicted")
+	}
+}
+
+func TestSignersCache_RecoverAll(t *testing.T) {
+	cache := NewSignersCache(100)
+
+	var txs [][]byte
+	for i := 0; i < 50; i++ {
+		privateKey, _ := crypto.GenerateKey()
+		txs = append(txs, makeSignedSendTx(t, 1, nil, privateKey))
+	}
+
+	cache.RecoverAll(txs)
+	if cache.Len() != len(txs) {
+		t.Fatalf("%d of %d txs are cached", cache.Len(), len(txs))
+	}
+}
+
+func TestRunTxCachedMultisigSigners(t *testing.T) {
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	msigAddress := cState.Accounts.CreateMultisig([]uint32{1, 1}, []types.Address{addr, {}}, 2, accounts.CreateMultisigAddress(addr, 1))
+	cState.Accounts.AddBalance(msigAddress, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	txBytes := makeSignedSendTx(t, 1, &msigAddress, privateKey, privateKey)
+	RecoveredSigners.Recover(txBytes)
+
+	response := RunTx(cState, txBytes, big.NewInt(0), 0, &sync.Map{}, 0)
+	if response.Code != code.DuplicatedAddresses {
+		t.Fatalf("Error code is not %d, got %d", code.DuplicatedAddresses, response.Code)
+	}
+
+	checkState(t, cState)
+}
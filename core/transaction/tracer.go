@@ -0,0 +1,114 @@
+package transaction
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/formula"
+)
+
+// Operations of trace steps
+const (
+	TraceBalanceRead  = "balance_read"
+	TraceBalanceWrite = "balance_write"
+	TraceCoinRead     = "coin_read"
+	TraceVolumeWrite  = "volume_write"
+	TraceReserveWrite = "reserve_write"
+	TraceStakeRead    = "stake_read"
+	TraceStakeWrite   = "stake_write"
+	TraceFormula      = "formula"
+)
+
+// TraceStep is a state read or write, or a formula calculation. Values are in pips.
+type TraceStep struct {
+	Op        string `json:"op"`
+	Address   string `json:"address,omitempty"`
+	PublicKey string `json:"public_key,omitempty"`
+	Coin      string `json:"coin,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Before    string `json:"before,omitempty"`
+	After     string `json:"after,omitempty"`
+
+	Formula string `json:"formula,omitempty"`
+	Supply  string `json:"supply,omitempty"`
+	Volume  string `json:"volume,omitempty"`
+	Reserve string `json:"reserve,omitempty"`
+	Crr     uint32 `json:"crr,omitempty"`
+	Amount  string `json:"amount,omitempty"`
+	Result  string `json:"result,omitempty"`
+}
+
+// Trace is a tracer which keeps steps in order they are made
+type Trace struct {
+	Steps []TraceStep `json:"steps"`
+}
+
+// NewTrace returns empty trace
+func NewTrace() *Trace {
+	return &Trace{Steps: []TraceStep{}}
+}
+
+func (t *Trace) OnBalanceRead(address types.Address, coin types.CoinID, value *big.Int) {
+	t.Steps = append(t.Steps, TraceStep{Op: TraceBalanceRead, Address: address.String(), Coin: coin.String(), Value: value.String()})
+}
+
+func (t *Trace) OnBalanceWrite(address types.Address, coin types.CoinID, before, after *big.Int) {
+	t.Steps = append(t.Steps, TraceStep{Op: TraceBalanceWrite, Address: address.String(), Coin: coin.String(), Before: before.String(), After: after.String()})
+}
+
+func (t *Trace) OnCoinRead(coin types.CoinID, volume, reserve *big.Int, crr uint32) {
+	t.Steps = append(t.Steps, TraceStep{Op: TraceCoinRead, Coin: coin.String(), Volume: volume.String(), Reserve: reserve.String(), Crr: crr})
+}
+
+func (t *Trace) OnVolumeWrite(coin types.CoinID, before, after *big.Int) {
+	t.Steps = append(t.Steps, TraceStep{Op: TraceVolumeWrite, Coin: coin.String(), Before: before.String(), After: after.String()})
+}
+
+func (t *Trace) OnReserveWrite(coin types.CoinID, before, after *big.Int) {
+	t.Steps = append(t.Steps, TraceStep{Op: TraceReserveWrite, Coin: coin.String(), Before: before.String(), After: after.String()})
+}
+
+func (t *Trace) OnStakeRead(owner types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int) {
+	t.Steps = append(t.Steps, TraceStep{Op: TraceStakeRead, Address: owner.String(), PublicKey: pubkey.String(), Coin: coin.String(), Value: value.String()})
+}
+
+func (t *Trace) OnStakeWrite(owner types.Address, pubkey types.Pubkey, coin types.CoinID, before, after *big.Int) {
+	t.Steps = append(t.Steps, TraceStep{Op: TraceStakeWrite, Address: owner.String(), PublicKey: pubkey.String(), Coin: coin.String(), Before: before.String(), After: after.String()})
+}
+
+func (t *Trace) OnFormula(name string, supply, reserve *big.Int, crr uint32, amount, result *big.Int) {
+	t.Steps = append(t.Steps, TraceStep{Op: TraceFormula, Formula: name, Supply: supply.String(), Reserve: reserve.String(), Crr: crr, Amount: amount.String(), Result: result.String()})
+}
+
+// calculatePurchaseReturn calls formula.CalculatePurchaseReturn and records the calculation by tracer of state
+func calculatePurchaseReturn(context *state.CheckState, supply *big.Int, reserve *big.Int, crr uint32, deposit *big.Int) *big.Int {
+	result := formula.CalculatePurchaseReturn(supply, reserve, crr, deposit)
+	context.Tracer().OnFormula("CalculatePurchaseReturn", supply, reserve, crr, deposit, result)
+
+	return result
+}
+
+// calculatePurchaseAmount calls formula.CalculatePurchaseAmount and records the calculation by tracer of state
+func calculatePurchaseAmount(context *state.CheckState, supply *big.Int, reserve *big.Int, crr uint32, wantReceive *big.Int) *big.Int {
+	result := formula.CalculatePurchaseAmount(supply, reserve, crr, wantReceive)
+	context.Tracer().OnFormula("CalculatePurchaseAmount", supply, reserve, crr, wantReceive, result)
+
+	return result
+}
+
+// calculateSaleReturn calls formula.CalculateSaleReturn and records the calculation by tracer of state
+func calculateSaleReturn(context *state.CheckState, supply *big.Int, reserve *big.Int, crr uint32, sellAmount *big.Int) *big.Int {
+	result := formula.CalculateSaleReturn(supply, reserve, crr, sellAmount)
+	context.Tracer().OnFormula("CalculateSaleReturn", supply, reserve, crr, sellAmount, result)
+
+	return result
+}
+
+// calculateSaleAmount calls formula.CalculateSaleAmount and records the calculation by tracer of state
+func calculateSaleAmount(context *state.CheckState, supply *big.Int, reserve *big.Int, crr uint32, wantReceive *big.Int) *big.Int {
+	result := formula.CalculateSaleAmount(supply, reserve, crr, wantReceive)
+	context.Tracer().OnFormula("CalculateSaleAmount", supply, reserve, crr, wantReceive, result)
+
+	return result
+}
@@ -0,0 +1,124 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/formula"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestTraceTx(t *testing.T) {
+	stateDB := db.NewMemDB()
+	cState, err := state.NewState(0, stateDB, nil, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cState.Validators.Create(types.Pubkey{}, big.NewInt(1))
+	cState.Candidates.Create(types.Address{}, types.Address{}, types.Address{}, types.Pubkey{}, 10)
+
+	coinToBuyID := createTestCoin(cState)
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	initBalance := helpers.BipToPip(big.NewInt(1000000))
+	cState.Accounts.AddBalance(addr, coin, initBalance)
+	cState.Coins.AddVolume(coin, initBalance)
+
+	toBuy := helpers.BipToPip(big.NewInt(10))
+	data := BuyCoinData{
+		CoinToBuy:          coinToBuyID,
+		ValueToBuy:         toBuy,
+		CoinToSell:         coin,
+		MaximumValueToSell: helpers.BipToPip(big.NewInt(1000)),
+	}
+
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       coin,
+		Type:          TypeBuyCoin,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coinModel := cState.Coins.GetCoin(coinToBuyID)
+	volume, reserve := coinModel.Volume(), coinModel.Reserve()
+	cost := formula.CalculatePurchaseAmount(volume, reserve, coinModel.Crr(), toBuy)
+
+	if _, err := cState.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	trace := NewTrace()
+	tracedState, err := state.NewStateAtHeight(uint64(cState.Tree().Version()), stateDB, trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(tracedState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	expected := []TraceStep{
+		{Op: TraceFormula, Formula: "CalculatePurchaseAmount", Supply: volume.String(), Reserve: reserve.String(), Crr: 10, Amount: toBuy.String(), Result: cost.String()},
+		{Op: TraceVolumeWrite, Coin: coinToBuyID.String(), Before: volume.String(), After: big.NewInt(0).Add(volume, toBuy).String()},
+		{Op: TraceReserveWrite, Coin: coinToBuyID.String(), Before: reserve.String(), After: big.NewInt(0).Add(reserve, cost).String()},
+		{Op: TraceBalanceWrite, Address: addr.String(), Coin: coinToBuyID.String(), Before: "0", After: toBuy.String()},
+	}
+
+	for _, step := range expected {
+		if !hasTraceStep(trace, step) {
+			t.Errorf("step %+v is not traced", step)
+		}
+	}
+
+	spent := false
+	for _, step := range trace.Steps {
+		if step.Op == TraceBalanceWrite && step.Address == addr.String() && step.Coin == coin.String() && step.Before == initBalance.String() {
+			spent = true
+		}
+	}
+	if !spent {
+		t.Errorf("spending of base coin is not traced")
+	}
+
+	if cState.Accounts.GetBalance(addr, coin).Cmp(initBalance) != 0 {
+		t.Fatalf("traced transaction changes state it is traced against")
+	}
+}
+
+func hasTraceStep(trace *Trace, step TraceStep) bool {
+	for _, s := range trace.Steps {
+		if s == step {
+			return true
+		}
+	}
+
+	return false
+}
@@ -21,26 +21,37 @@ type TxType byte
 type SigType byte
 
 const (
-	TypeSend                   TxType = 0x01
-	TypeSellCoin               TxType = 0x02
-	TypeSellAllCoin            TxType = 0x03
-	TypeBuyCoin                TxType = 0x04
-	TypeCreateCoin             TxType = 0x05
-	TypeDeclareCandidacy       TxType = 0x06
-	TypeDelegate               TxType = 0x07
-	TypeUnbond                 TxType = 0x08
-	TypeRedeemCheck            TxType = 0x09
-	TypeSetCandidateOnline     TxType = 0x0A
-	TypeSetCandidateOffline    TxType = 0x0B
-	TypeCreateMultisig         TxType = 0x0C
-	TypeMultisend              TxType = 0x0D
-	TypeEditCandidate          TxType = 0x0E
-	TypeSetHaltBlock           TxType = 0x0F
-	TypeRecreateCoin           TxType = 0x10
-	TypeEditCoinOwner          TxType = 0x11
-	TypeEditMultisig           TxType = 0x12
-	TypePriceVote              TxType = 0x13
-	TypeEditCandidatePublicKey TxType = 0x14
+	TypeSend                    TxType = 0x01
+	TypeSellCoin                TxType = 0x02
+	TypeSellAllCoin             TxType = 0x03
+	TypeBuyCoin                 TxType = 0x04
+	TypeCreateCoin              TxType = 0x05
+	TypeDeclareCandidacy        TxType = 0x06
+	TypeDelegate                TxType = 0x07
+	TypeUnbond                  TxType = 0x08
+	TypeRedeemCheck             TxType = 0x09
+	TypeSetCandidateOnline      TxType = 0x0A
+	TypeSetCandidateOffline     TxType = 0x0B
+	TypeCreateMultisig          TxType = 0x0C
+	TypeMultisend               TxType = 0x0D
+	TypeEditCandidate           TxType = 0x0E
+	TypeSetHaltBlock            TxType = 0x0F
+	TypeRecreateCoin            TxType = 0x10
+	TypeEditCoinOwner           TxType = 0x11
+	TypeEditMultisig            TxType = 0x12
+	TypePriceVote               TxType = 0x13
+	TypeEditCandidatePublicKey  TxType = 0x14
+	TypeCreateToken             TxType = 0x15
+	TypeMintToken               TxType = 0x16
+	TypeBurnToken               TxType = 0x17
+	TypeEditCandidateCommission TxType = 0x18
+	TypeRevokeCheck             TxType = 0x19
+	TypeRedeemPartialCheck      TxType = 0x1A
+	TypeApprove                 TxType = 0x1B
+	TypeTransferFrom            TxType = 0x1C
+	TypeRegisterName            TxType = 0x1D
+	TypeRenewName               TxType = 0x1E
+	TypeTransferName            TxType = 0x1F
 
 	SigTypeSingle SigType = 0x01
 	SigTypeMulti  SigType = 0x02
@@ -70,6 +81,7 @@ type Transaction struct {
 	sig         *Signature
 	multisig    *SignatureMulti
 	sender      *types.Address
+	signers     []types.Address
 }
 
 type Signature struct {
@@ -226,6 +238,32 @@ func (tx *Transaction) Sender() (types.Address, error) {
 	return types.Address{}, errors.New("unknown signature type")
 }
 
+// Signers returns addresses recovered from signatures of multisig transaction, in order of signatures. If a signature
+// is invalid, signers of the preceding signatures are returned along with error.
+func (tx *Transaction) Signers() ([]types.Address, error) {
+	if tx.signers != nil {
+		return tx.signers, nil
+	}
+
+	if tx.SignatureType != SigTypeMulti {
+		return nil, errors.New("transaction is not multisig")
+	}
+
+	txHash := tx.Hash()
+	signers := make([]types.Address, 0, len(tx.multisig.Signatures))
+	for _, sig := range tx.multisig.Signatures {
+		signer, err := RecoverPlain(txHash, sig.R, sig.S, sig.V)
+		if err != nil {
+			return signers, err
+		}
+
+		signers = append(signers, signer)
+	}
+
+	tx.signers = signers
+	return signers, nil
+}
+
 func (tx *Transaction) Hash() types.Hash {
 	return rlpHash([]interface{}{
 		tx.Nonce,
@@ -320,12 +358,24 @@ func CheckForCoinSupplyOverflow(coin *coins.Model, delta *big.Int) *Response {
 func CheckReserveUnderflow(coin *coins.Model, delta *big.Int) *Response {
 	total := big.NewInt(0).Sub(coin.Reserve(), delta)
 
-	if total.Cmp(minCoinReserve) == -1 {
-		min := big.NewInt(0).Add(minCoinReserve, delta)
+	if total.Cmp(MinCoinReserve) == -1 {
+		min := big.NewInt(0).Add(MinCoinReserve, delta)
 		return &Response{
 			Code: code.CoinReserveUnderflow,
 			Log:  fmt.Sprintf("coin %s reserve is too small (%s, required at least %s)", coin.GetFullSymbol(), coin.Reserve().String(), min.String()),
-			Info: EncodeError(code.NewCoinReserveUnderflow(delta.String(), coin.Reserve().String(), total.String(), minCoinReserve.String(), coin.GetFullSymbol(), coin.ID().String())),
+			Info: EncodeError(code.NewCoinReserveUnderflow(delta.String(), coin.Reserve().String(), total.String(), MinCoinReserve.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	return nil
+}
+
+func CheckCoinHasReserve(coin *coins.Model) *Response {
+	if coin.IsToken() {
+		return &Response{
+			Code: code.CoinHasNotReserve,
+			Log:  fmt.Sprintf("coin %s has no reserve", coin.GetFullSymbol()),
+			Info: EncodeError(code.NewCoinHasNotReserve(coin.GetFullSymbol(), coin.ID().String())),
 		}
 	}
 
@@ -0,0 +1,156 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/commissions"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+	"github.com/tendermint/tendermint/libs/kv"
+)
+
+// TransferFromData transfers Value of Coin from account of From to To within allowance granted to sender.
+// Commission is paid by sender.
+type TransferFromData struct {
+	From  types.Address
+	To    types.Address
+	Coin  types.CoinID
+	Value *big.Int
+}
+
+func (data TransferFromData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.Value == nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data TransferFromData) String() string {
+	return fmt.Sprintf("TRANSFER FROM from:%s to:%s coin:%s value:%s",
+		data.From.String(), data.To.String(), data.Coin.String(), data.Value.String())
+}
+
+func (data TransferFromData) Gas() int64 {
+	return commissions.TransferFromTx
+}
+
+func (data TransferFromData) upgradeBlock() uint64 {
+	return upgrades.UpgradeBlock2
+}
+
+func (data TransferFromData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.BasicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	allowance := checkState.Allowances().Get(data.From, sender, data.Coin)
+	if allowance == nil {
+		return Response{
+			Code: code.AllowanceNotFound,
+			Log:  "Allowance not found",
+			Info: EncodeError(code.NewAllowanceNotFound(data.From.String(), sender.String(), data.Coin.String())),
+		}
+	}
+
+	if allowance.IsExpired(currentBlock) {
+		return Response{
+			Code: code.AllowanceExpired,
+			Log:  "Allowance expired",
+			Info: EncodeError(code.NewAllowanceExpired(strconv.FormatUint(allowance.ExpireHeight, 10), strconv.FormatUint(currentBlock, 10))),
+		}
+	}
+
+	if available := allowance.Available(currentBlock); available.Cmp(data.Value) < 0 {
+		return Response{
+			Code: code.InsufficientAllowance,
+			Log:  fmt.Sprintf("Insufficient allowance. Wanted %s, available %s", data.Value.String(), available.String()),
+			Info: EncodeError(code.NewInsufficientAllowance(data.Value.String(), available.String())),
+		}
+	}
+
+	commissionInBaseCoin := tx.CommissionInBaseCoin()
+	commission := big.NewInt(0).Set(commissionInBaseCoin)
+
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	coin := checkState.Coins().GetCoin(data.Coin)
+
+	if !tx.GasCoin.IsBaseCoin() {
+		errResp := CheckReserveUnderflow(gasCoin, commissionInBaseCoin)
+		if errResp != nil {
+			return *errResp
+		}
+
+		commission = calculateSaleAmount(checkState, gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	if checkState.Accounts().GetBalance(data.From, data.Coin).Cmp(data.Value) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for owner account: %s. Wanted %s %s", data.From.String(), data.Value.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(data.From.String(), data.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	if deliverState, ok := context.(*state.State); ok {
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Coins.SubReserve(tx.GasCoin, commissionInBaseCoin)
+		deliverState.Coins.SubVolume(tx.GasCoin, commission)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		deliverState.Allowances.Spend(data.From, sender, data.Coin, data.Value, currentBlock)
+		deliverState.Accounts.SubBalance(data.From, data.Coin, data.Value)
+		deliverState.Accounts.AddBalance(data.To, data.Coin, data.Value)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+	}
+
+	tags := kv.Pairs{
+		kv.Pair{Key: []byte("tx.type"), Value: []byte(hex.EncodeToString([]byte{byte(TypeTransferFrom)}))},
+		kv.Pair{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(data.From[:]))},
+		kv.Pair{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(data.To[:]))},
+		kv.Pair{Key: []byte("tx.spender"), Value: []byte(hex.EncodeToString(sender[:]))},
+		kv.Pair{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String())},
+	}
+
+	return Response{
+		Code:      code.OK,
+		Tags:      tags,
+		GasUsed:   tx.Gas(),
+		GasWanted: tx.Gas(),
+	}
+}
@@ -0,0 +1,155 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+)
+
+func TestTransferFromTx(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	ownerPrivateKey, _ := crypto.GenerateKey()
+	owner := crypto.PubkeyToAddress(ownerPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(owner, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	spenderPrivateKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(spender, coin, helpers.BipToPip(big.NewInt(1)))
+
+	cState.Allowances.Approve(owner, spender, coin, helpers.BipToPip(big.NewInt(100)), 0, 0, big.NewInt(0), upgrades.UpgradeBlock2+1)
+
+	to := types.Address{1}
+	value := helpers.BipToPip(big.NewInt(60))
+
+	data := TransferFromData{
+		From:  owner,
+		To:    to,
+		Coin:  coin,
+		Value: value,
+	}
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeTransferFrom, data, spenderPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	targetBalance := cState.Accounts.GetBalance(to, coin)
+	if targetBalance.Cmp(value) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", coin, value, targetBalance)
+	}
+
+	ownerBalance, _ := big.NewInt(0).SetString("940000000000000000000", 10)
+	if balance := cState.Accounts.GetBalance(owner, coin); balance.Cmp(ownerBalance) != 0 {
+		t.Fatalf("Owner %s balance is not correct. Expected %s, got %s", coin, ownerBalance, balance)
+	}
+
+	spenderBalance, _ := big.NewInt(0).SetString("980000000000000000", 10)
+	if balance := cState.Accounts.GetBalance(spender, coin); balance.Cmp(spenderBalance) != 0 {
+		t.Fatalf("Spender %s balance is not correct. Expected %s, got %s", coin, spenderBalance, balance)
+	}
+
+	allowance := cState.Allowances.Get(owner, spender, coin)
+	if allowance == nil || allowance.Value.Cmp(helpers.BipToPip(big.NewInt(40))) != 0 {
+		t.Fatal("Allowance is not correct")
+	}
+
+	response = RunTx(cState, makeTestCheckTx(t, 2, TypeTransferFrom, data, spenderPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.InsufficientAllowance {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientAllowance, response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestTransferFromTxWithPeriodLimit(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	owner := types.Address{2}
+	cState.Accounts.AddBalance(owner, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	spenderPrivateKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(spender, coin, helpers.BipToPip(big.NewInt(1)))
+
+	cState.Allowances.Approve(owner, spender, coin, helpers.BipToPip(big.NewInt(100)), 0, 10, helpers.BipToPip(big.NewInt(10)), upgrades.UpgradeBlock2+1)
+
+	data := TransferFromData{
+		From:  owner,
+		To:    types.Address{1},
+		Coin:  coin,
+		Value: helpers.BipToPip(big.NewInt(10)),
+	}
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeTransferFrom, data, spenderPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+5, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	response = RunTx(cState, makeTestCheckTx(t, 2, TypeTransferFrom, data, spenderPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+10, &sync.Map{}, 0)
+	if response.Code != code.InsufficientAllowance {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientAllowance, response.Log)
+	}
+
+	response = RunTx(cState, makeTestCheckTx(t, 2, TypeTransferFrom, data, spenderPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+11, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestTransferFromTxWithExpiredAllowance(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	owner := types.Address{2}
+	cState.Accounts.AddBalance(owner, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	spenderPrivateKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(spender, coin, helpers.BipToPip(big.NewInt(1)))
+
+	cState.Allowances.Approve(owner, spender, coin, helpers.BipToPip(big.NewInt(100)), upgrades.UpgradeBlock2+10, 0, big.NewInt(0), upgrades.UpgradeBlock2+1)
+
+	data := TransferFromData{
+		From:  owner,
+		To:    types.Address{1},
+		Coin:  coin,
+		Value: helpers.BipToPip(big.NewInt(10)),
+	}
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeTransferFrom, data, spenderPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+11, &sync.Map{}, 0)
+	if response.Code != code.AllowanceExpired {
+		t.Fatalf("Response code is not %d. Error %s", code.AllowanceExpired, response.Log)
+	}
+}
+
+func TestTransferFromTxWithoutAllowance(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	spenderPrivateKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(spender, coin, helpers.BipToPip(big.NewInt(1)))
+
+	data := TransferFromData{
+		From:  types.Address{2},
+		To:    types.Address{1},
+		Coin:  coin,
+		Value: helpers.BipToPip(big.NewInt(10)),
+	}
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeTransferFrom, data, spenderPrivateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.AllowanceNotFound {
+		t.Fatalf("Response code is not %d. Error %s", code.AllowanceNotFound, response.Log)
+	}
+}
@@ -0,0 +1,77 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/core/code"
+	"github.com/MinterTeam/minter-go-node/core/state/names"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/upgrades"
+)
+
+func TestTransferNameTx(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	cState.Names.Register("alice", addr, types.Pubkey{1}, upgrades.UpgradeBlock2+10)
+
+	to := types.Address{1}
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeTransferName, TransferNameData{Name: "alice", To: to}, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	if address, ok := cState.Names.ResolveAddress("alice", upgrades.UpgradeBlock2+2); !ok || address != to {
+		t.Fatal("Name is not transferred")
+	}
+
+	if _, ok := cState.Names.ResolvePubKey("alice", upgrades.UpgradeBlock2+2); ok {
+		t.Fatal("Public key of transferred name is not reset")
+	}
+
+	if name := cState.Names.ReverseLookup(addr, upgrades.UpgradeBlock2+2); name != "" {
+		t.Fatalf("Previous owner still has reverse record %s", name)
+	}
+
+	response = RunTx(cState, makeTestCheckTx(t, 2, TypeTransferName, TransferNameData{Name: "alice", To: addr}, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+1, &sync.Map{}, 0)
+	if response.Code != code.IsNotOwnerOfName {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfName, response.Log)
+	}
+
+	checkState(t, cState)
+}
+
+func TestRenewNameTx(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	cState.Names.Register("alice", addr, types.Pubkey{}, upgrades.UpgradeBlock2+10)
+
+	response := RunTx(cState, makeTestCheckTx(t, 1, TypeRenewName, RenewNameData{Name: "alice"}, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+5, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	if name := cState.Names.GetName("alice"); name.ExpireHeight != upgrades.UpgradeBlock2+10+names.RegistrationPeriod {
+		t.Fatalf("Wrong expire height of renewed name: %d", name.ExpireHeight)
+	}
+
+	response = RunTx(cState, makeTestCheckTx(t, 2, TypeRenewName, RenewNameData{Name: "bob"}, privateKey), big.NewInt(0), upgrades.UpgradeBlock2+5, &sync.Map{}, 0)
+	if response.Code != code.NameNotExists {
+		t.Fatalf("Response code is not %d. Error %s", code.NameNotExists, response.Log)
+	}
+
+	checkState(t, cState)
+}
@@ -8,19 +8,71 @@ import (
 )
 
 type AppState struct {
-	Note                string       `json:"note"`
-	StartHeight         uint64       `json:"start_height"`
-	Validators          []Validator  `json:"validators,omitempty"`
-	Candidates          []Candidate  `json:"candidates,omitempty"`
-	BlockListCandidates []Pubkey     `json:"block_list_candidates,omitempty"`
-	Waitlist            []Waitlist   `json:"waitlist,omitempty"`
-	Accounts            []Account    `json:"accounts,omitempty"`
-	Coins               []Coin       `json:"coins,omitempty"`
-	FrozenFunds         []FrozenFund `json:"frozen_funds,omitempty"`
-	HaltBlocks          []HaltBlock  `json:"halt_blocks,omitempty"`
-	UsedChecks          []UsedCheck  `json:"used_checks,omitempty"`
-	MaxGas              uint64       `json:"max_gas"`
-	TotalSlashed        string       `json:"total_slashed"`
+	Note                string           `json:"note"`
+	StartHeight         uint64           `json:"start_height"`
+	Validators          []Validator      `json:"validators,omitempty"`
+	Candidates          []Candidate      `json:"candidates,omitempty"`
+	BlockListCandidates []Pubkey         `json:"block_list_candidates,omitempty"`
+	Waitlist            []Waitlist       `json:"waitlist,omitempty"`
+	Accounts            []Account        `json:"accounts,omitempty"`
+	Coins               []Coin           `json:"coins,omitempty"`
+	FrozenFunds         []FrozenFund     `json:"frozen_funds,omitempty"`
+	HaltBlocks          []HaltBlock      `json:"halt_blocks,omitempty"`
+	UsedChecks          []UsedCheck      `json:"used_checks,omitempty"`
+	ExpirableChecks     []ExpirableCheck `json:"expirable_checks,omitempty"`
+	PartialChecks       []PartialCheck   `json:"partial_checks,omitempty"`
+	Allowances          []Allowance      `json:"allowances,omitempty"`
+	Names               []Name           `json:"names,omitempty"`
+	MaxGas              uint64           `json:"max_gas"`
+	TotalSlashed        string           `json:"total_slashed"`
+	Emission            string           `json:"emission,omitempty"`
+	Supply              string           `json:"supply,omitempty"`
+}
+
+// BaseCoinSupply returns amount of base coin held in the state: balances, reserves of coins, accumulated rewards of
+// validators, stakes, waitlist, frozen funds and total slashed
+func (s *AppState) BaseCoinSupply() *big.Int {
+	supply := helpers.StringToBigInt(s.TotalSlashed)
+
+	for _, account := range s.Accounts {
+		for _, balance := range account.Balance {
+			if CoinID(balance.Coin).IsBaseCoin() {
+				supply.Add(supply, helpers.StringToBigInt(balance.Value))
+			}
+		}
+	}
+
+	for _, coin := range s.Coins {
+		supply.Add(supply, helpers.StringToBigInt(coin.Reserve))
+	}
+
+	for _, validator := range s.Validators {
+		supply.Add(supply, helpers.StringToBigInt(validator.AccumReward))
+	}
+
+	for _, candidate := range s.Candidates {
+		for _, stakes := range [][]Stake{candidate.Stakes, candidate.Updates} {
+			for _, stake := range stakes {
+				if CoinID(stake.Coin).IsBaseCoin() {
+					supply.Add(supply, helpers.StringToBigInt(stake.Value))
+				}
+			}
+		}
+	}
+
+	for _, item := range s.Waitlist {
+		if CoinID(item.Coin).IsBaseCoin() {
+			supply.Add(supply, helpers.StringToBigInt(item.Value))
+		}
+	}
+
+	for _, ff := range s.FrozenFunds {
+		if CoinID(ff.Coin).IsBaseCoin() {
+			supply.Add(supply, helpers.StringToBigInt(ff.Value))
+		}
+	}
+
+	return supply
 }
 
 func (s *AppState) Verify() error {
@@ -28,6 +80,17 @@ func (s *AppState) Verify() error {
 		return fmt.Errorf("total slashed is not valid BigInt")
 	}
 
+	// emission and supply of base coin are exported since UpgradeBlock2
+	if s.Emission != "" || s.Supply != "" {
+		if !helpers.IsValidBigInt(s.Emission) || !helpers.IsValidBigInt(s.Supply) {
+			return fmt.Errorf("emission or supply is not valid BigInt")
+		}
+
+		if held := s.BaseCoinSupply(); held.Cmp(helpers.StringToBigInt(s.Supply)) != 0 {
+			return fmt.Errorf("supply of base coin is %s, but %s is held", s.Supply, held)
+		}
+	}
+
 	if len(s.Validators) < 1 {
 		return fmt.Errorf("there should be at least one validator")
 	}
@@ -241,6 +304,87 @@ func (s *AppState) Verify() error {
 		}
 	}
 
+	// check expirable checks
+	for _, check := range s.ExpirableChecks {
+		b, err := hex.DecodeString(string(check.Hash))
+		if err != nil {
+			return err
+		}
+
+		if len(b) != 32 {
+			return fmt.Errorf("wrong expirable check size %s", check.Hash)
+		}
+
+		if check.DueBlock == 0 {
+			return fmt.Errorf("expirable check %s has no due block", check.Hash)
+		}
+	}
+
+	// check partial checks
+	for _, check := range s.PartialChecks {
+		b, err := hex.DecodeString(string(check.Hash))
+		if err != nil {
+			return err
+		}
+
+		if len(b) != 32 {
+			return fmt.Errorf("wrong partial check size %s", check.Hash)
+		}
+
+		if !helpers.IsValidBigInt(check.Remainder) {
+			return fmt.Errorf("partial check %s remainder is not valid BigInt", check.Hash)
+		}
+	}
+
+	allowances := map[string]struct{}{}
+	for _, allowance := range s.Allowances {
+		// check duplicated allowances
+		key := fmt.Sprintf("%s:%s:%d", allowance.Owner.String(), allowance.Spender.String(), allowance.Coin)
+		if _, exists := allowances[key]; exists {
+			return fmt.Errorf("duplicated allowance %s", key)
+		}
+		allowances[key] = struct{}{}
+
+		if !helpers.IsValidBigInt(allowance.Value) || !helpers.IsValidBigInt(allowance.PeriodLimit) || !helpers.IsValidBigInt(allowance.PeriodSpent) {
+			return fmt.Errorf("allowance %s values are not valid", key)
+		}
+
+		// check not existing coins
+		coinID := CoinID(allowance.Coin)
+		if !coinID.IsBaseCoin() {
+			foundCoin := false
+			for _, coin := range s.Coins {
+				if CoinID(coin.ID) == coinID {
+					foundCoin = true
+					break
+				}
+			}
+
+			if !foundCoin {
+				return fmt.Errorf("coin %s not found", coinID)
+			}
+		}
+	}
+
+	names := map[string]struct{}{}
+	primaryNames := map[Address]struct{}{}
+	for _, name := range s.Names {
+		// check duplicated names
+		if _, exists := names[name.Name]; exists {
+			return fmt.Errorf("duplicated name %s", name.Name)
+		}
+		names[name.Name] = struct{}{}
+
+		if !name.Primary {
+			continue
+		}
+
+		if _, exists := primaryNames[name.Owner]; exists {
+			return fmt.Errorf("duplicated primary name of %s", name.Owner.String())
+		}
+		primaryNames[name.Owner] = struct{}{}
+	}
+
 	return nil
 }
 
@@ -262,6 +406,14 @@ type Candidate struct {
 	Stakes         []Stake `json:"stakes"`
 	Updates        []Stake `json:"updates"`
 	Status         uint64  `json:"status"`
+
+	PendingCommission        *PendingCommission `json:"pending_commission,omitempty"`
+	LastEditCommissionHeight uint64             `json:"last_edit_commission_height,omitempty"`
+}
+
+type PendingCommission struct {
+	Commission uint64 `json:"commission"`
+	Height     uint64 `json:"height"`
 }
 
 type Stake struct {
@@ -301,6 +453,42 @@ type FrozenFund struct {
 
 type UsedCheck string
 
+// ExpirableCheck is a used or revoked check which is deleted from state after its due block
+type ExpirableCheck struct {
+	Hash     UsedCheck `json:"hash"`
+	DueBlock uint64    `json:"due_block"`
+	Revoked  bool      `json:"revoked,omitempty"`
+}
+
+// PartialCheck is a remainder of partially redeemed check
+type PartialCheck struct {
+	Hash      UsedCheck `json:"hash"`
+	DueBlock  uint64    `json:"due_block"`
+	Remainder string    `json:"remainder"`
+}
+
+// Allowance is an amount of coins which spender is allowed to transfer from owner's account
+type Allowance struct {
+	Owner        Address `json:"owner"`
+	Spender      Address `json:"spender"`
+	Coin         uint64  `json:"coin"`
+	Value        string  `json:"value"`
+	ExpireHeight uint64  `json:"expire_height,omitempty"`
+	Period       uint64  `json:"period,omitempty"`
+	PeriodLimit  string  `json:"period_limit"`
+	PeriodStart  uint64  `json:"period_start"`
+	PeriodSpent  string  `json:"period_spent"`
+}
+
+// Name is a human readable name of owner's address and, optionally, candidate's public key
+type Name struct {
+	Name         string  `json:"name"`
+	Owner        Address `json:"owner"`
+	PubKey       *Pubkey `json:"pub_key,omitempty"`
+	ExpireHeight uint64  `json:"expire_height"`
+	Primary      bool    `json:"primary,omitempty"`
+}
+
 type Account struct {
 	Address      Address   `json:"address"`
 	Balance      []Balance `json:"balance"`
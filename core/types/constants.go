@@ -10,6 +10,9 @@ const (
 	ChainTestnet ChainID = 0x02
 )
 
+// BlocksPerYear is amount of 5 seconds blocks in a year
+const BlocksPerYear = 6307200
+
 // CurrentChainID is current ChainID of the network
 var CurrentChainID = ChainMainnet
 
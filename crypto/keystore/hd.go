@@ -0,0 +1,123 @@
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/crypto"
+)
+
+// DefaultDerivationPath is BIP-44 derivation path of the first Minter address, the same as of Ethereum
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+const hardenedOffset uint32 = 0x80000000
+
+var errInvalidChildKey = errors.New("invalid child key, derive the next index")
+
+// AccountPath returns BIP-44 derivation path of Minter address with given index
+func AccountPath(index uint32) string {
+	return fmt.Sprintf("m/44'/60'/0'/0/%d", index)
+}
+
+// ParseDerivationPath parses BIP-32 derivation path, such as m/44'/60'/0'/0/0, into child indexes.
+// Hardened indexes are marked with ' or h.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("derivation path %q should start with m", path)
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		offset := uint32(0)
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") {
+			offset = hardenedOffset
+			part = part[:len(part)-1]
+		}
+
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= hardenedOffset {
+			return nil, fmt.Errorf("invalid index %q of derivation path %q", part, path)
+		}
+
+		indexes = append(indexes, uint32(index)+offset)
+	}
+
+	return indexes, nil
+}
+
+// DeriveKey derives private key from BIP-39 seed by BIP-32 derivation path
+func DeriveKey(seed []byte, path string) (*ecdsa.PrivateKey, error) {
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode := hmacSHA512([]byte("Bitcoin seed"), seed)
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		key, chainCode, err = deriveChild(key, chainCode, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return crypto.ToECDSA(key)
+}
+
+// deriveChild returns private key and chain code of the child with given index
+func deriveChild(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= hardenedOffset {
+		data = append([]byte{0}, key...)
+	} else {
+		data = crypto.CompressPubkey(&crypto.ToECDSAUnsafe(key).PublicKey)
+	}
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[len(data)-4:], index)
+
+	il, childChainCode := hmacSHA512(chainCode, data)
+	if err := checkKey(il); err != nil {
+		return nil, nil, errInvalidChildKey
+	}
+
+	child := new(big.Int).SetBytes(il)
+	child.Add(child, new(big.Int).SetBytes(key))
+	child.Mod(child, crypto.S256().Params().N)
+
+	childKey := make([]byte, 32)
+	child.FillBytes(childKey)
+	if err := checkKey(childKey); err != nil {
+		return nil, nil, errInvalidChildKey
+	}
+
+	return childKey, childChainCode, nil
+}
+
+// checkKey checks that key is a valid secp256k1 private key
+func checkKey(key []byte) error {
+	k := new(big.Int).SetBytes(key)
+	if k.Sign() == 0 || k.Cmp(crypto.S256().Params().N) >= 0 {
+		return errors.New("invalid private key")
+	}
+
+	return nil
+}
+
+func hmacSHA512(key, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	return sum[:32], sum[32:]
+}
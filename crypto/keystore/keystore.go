@@ -0,0 +1,262 @@
+// Package keystore implements BIP-39 mnemonics, BIP-44 derivation of Minter keys and
+// their storage in JSON files encrypted by password with scrypt, compatible with Web3 Secret Storage version 3.
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Scrypt parameters. Light ones use less memory and CPU, they are suitable for tests and devnets only.
+const (
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+	LightScryptN    = 1 << 12
+	LightScryptP    = 6
+
+	scryptR     = 8
+	scryptDKLen = 32
+
+	// limits of scrypt parameters of decrypted keys, which bound memory and CPU used by decryption of a foreign file
+	maxScryptN = 1 << 20
+	maxScryptR = 8
+	maxScryptP = 16
+
+	version = 3
+)
+
+// ErrDecrypt is returned if key can't be decrypted with given password
+var ErrDecrypt = errors.New("could not decrypt key with given password")
+
+// Key is a private key and its Minter address
+type Key struct {
+	Address    types.Address
+	PrivateKey *ecdsa.PrivateKey
+}
+
+// NewKey returns key of the private key
+func NewKey(privateKey *ecdsa.PrivateKey) *Key {
+	return &Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+}
+
+type encryptedKeyJSON struct {
+	Address string     `json:"address"`
+	Crypto  cryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+type cryptoJSON struct {
+	Cipher       string           `json:"cipher"`
+	CipherText   string           `json:"ciphertext"`
+	CipherParams cipherParamsJSON `json:"cipherparams"`
+	KDF          string           `json:"kdf"`
+	KDFParams    scryptParamsJSON `json:"kdfparams"`
+	MAC          string           `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+type scryptParamsJSON struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// EncryptKey encrypts key with password using scrypt parameters N and P, returns JSON of encrypted key
+func EncryptKey(key *Key, password string, scryptN, scryptP int) ([]byte, error) {
+	salt, err := randomBytes(32)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	iv, err := randomBytes(aes.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := aesCTRXOR(derivedKey[:16], crypto.FromECDSA(key.PrivateKey), iv)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(encryptedKeyJSON{
+		Address: key.Address.String(),
+		Crypto: cryptoJSON{
+			Cipher:       "aes-128-ctr",
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+			KDF:          "scrypt",
+			KDFParams: scryptParamsJSON{
+				N:     scryptN,
+				R:     scryptR,
+				P:     scryptP,
+				DKLen: scryptDKLen,
+				Salt:  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+		},
+		ID:      uuid.New().String(),
+		Version: version,
+	})
+}
+
+// DecryptKey decrypts JSON of encrypted key with password
+func DecryptKey(keyJSON []byte, password string) (*Key, error) {
+	var encrypted encryptedKeyJSON
+	if err := json.Unmarshal(keyJSON, &encrypted); err != nil {
+		return nil, err
+	}
+
+	if encrypted.Version != version {
+		return nil, fmt.Errorf("version %d of keystore is not supported", encrypted.Version)
+	}
+	if encrypted.Crypto.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("cipher %s is not supported", encrypted.Crypto.Cipher)
+	}
+	if encrypted.Crypto.KDF != "scrypt" {
+		return nil, fmt.Errorf("kdf %s is not supported", encrypted.Crypto.KDF)
+	}
+
+	params := encrypted.Crypto.KDFParams
+	if params.DKLen != scryptDKLen {
+		return nil, fmt.Errorf("scrypt dklen %d is not supported", params.DKLen)
+	}
+	if params.N <= 1 || params.N > maxScryptN || params.R <= 0 || params.R > maxScryptR || params.P <= 0 || params.P > maxScryptP {
+		return nil, fmt.Errorf("scrypt parameters n=%d, r=%d, p=%d are out of range", params.N, params.R, params.P)
+	}
+
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := hex.DecodeString(encrypted.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := hex.DecodeString(encrypted.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+	mac, err := hex.DecodeString(encrypted.Crypto.MAC)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(crypto.Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, ErrDecrypt
+	}
+
+	plainText, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.ToECDSA(plainText)
+	if err != nil {
+		return nil, err
+	}
+
+	key := NewKey(privateKey)
+	if encrypted.Address != "" && encrypted.Address != key.Address.String() {
+		return nil, fmt.Errorf("decrypted key address %s doesn't match address %s of keystore", key.Address.String(), encrypted.Address)
+	}
+
+	return key, nil
+}
+
+// StoreKey encrypts key with password and writes it to file, which is readable by owner only
+func StoreKey(file string, key *Key, password string, scryptN, scryptP int) error {
+	keyJSON, err := EncryptKey(key, password, scryptN, scryptP)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(file, keyJSON, 0600)
+}
+
+// LoadKey reads key from file and decrypts it with password
+func LoadKey(file string, password string) (*Key, error) {
+	keyJSON, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecryptKey(keyJSON, password)
+}
+
+// IsEncrypted reports whether content of key file is JSON of encrypted key rather than hex of private key
+func IsEncrypted(content []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(content), []byte("{"))
+}
+
+// Address returns address of encrypted key without decrypting it
+func Address(keyJSON []byte) (types.Address, error) {
+	var encrypted encryptedKeyJSON
+	if err := json.Unmarshal(keyJSON, &encrypted); err != nil {
+		return types.Address{}, err
+	}
+
+	if len(encrypted.Address) != 2+types.AddressLength*2 {
+		return types.Address{}, fmt.Errorf("invalid address %q of keystore", encrypted.Address)
+	}
+
+	return types.HexToAddress(encrypted.Address), nil
+}
+
+func aesCTRXOR(key, in, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
@@ -0,0 +1,168 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/crypto"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestSeedFromMnemonic(t *testing.T) {
+	seed, err := SeedFromMnemonic(testMnemonic, "TREZOR")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hex.EncodeToString(seed) != "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04" {
+		t.Fatalf("unexpected seed %x", seed)
+	}
+
+	if _, err := SeedFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", ""); err != ErrInvalidMnemonic {
+		t.Fatalf("expected invalid checksum error, got %v", err)
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for entropy, words := range map[int]int{MnemonicEntropy12Words: 12, MnemonicEntropy24Words: 24} {
+		mnemonic, err := NewMnemonic(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !IsMnemonicValid(mnemonic) {
+			t.Fatalf("generated mnemonic %q is invalid", mnemonic)
+		}
+
+		if _, err := KeyFromMnemonic(mnemonic, "", DefaultDerivationPath); err != nil {
+			t.Fatal(err)
+		}
+
+		if n := len(strings.Fields(mnemonic)); n != words {
+			t.Fatalf("expected %d words, got %d", words, n)
+		}
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	// BIP-32 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := map[string]string{
+		"m":                      "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+		"m/0'":                   "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		"m/0'/1":                 "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		"m/0h/1/2h/2/1000000000": "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8",
+	}
+
+	for path, want := range tests {
+		key, err := DeriveKey(seed, path)
+		if err != nil {
+			t.Fatalf("%s: %s", path, err)
+		}
+
+		if got := hex.EncodeToString(crypto.FromECDSA(key)); got != want {
+			t.Fatalf("%s: expected key %s, got %s", path, want, got)
+		}
+	}
+
+	for _, path := range []string{"", "44'/60'", "m/x", "m/2147483648"} {
+		if _, err := DeriveKey(seed, path); err == nil {
+			t.Fatalf("expected error for path %q", path)
+		}
+	}
+}
+
+func TestKeyFromMnemonic(t *testing.T) {
+	key, err := KeyFromMnemonic(testMnemonic, "", AccountPath(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if address := crypto.PubkeyToAddress(key.PublicKey).String(); address != "Mx9858effd232b4033e47d90003d41ec34ecaeda94" {
+		t.Fatalf("unexpected address %s", address)
+	}
+
+	next, err := KeyFromMnemonic(testMnemonic, "", AccountPath(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if crypto.PubkeyToAddress(next.PublicKey) == crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatal("keys of different indexes should differ")
+	}
+}
+
+func TestEncryptDecryptKey(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := NewKey(privateKey)
+
+	keyJSON, err := EncryptKey(key, "password", LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsEncrypted(keyJSON) || IsEncrypted([]byte(hex.EncodeToString(crypto.FromECDSA(privateKey)))) {
+		t.Fatal("wrong detection of encrypted key")
+	}
+
+	address, err := Address(keyJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != key.Address {
+		t.Fatalf("expected address %s, got %s", key.Address.String(), address.String())
+	}
+
+	if _, err := DecryptKey(keyJSON, "wrong"); err != ErrDecrypt {
+		t.Fatalf("expected decrypt error, got %v", err)
+	}
+
+	decrypted, err := DecryptKey(keyJSON, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decrypted.Address != key.Address || decrypted.PrivateKey.D.Cmp(privateKey.D) != 0 {
+		t.Fatal("decrypted key differs")
+	}
+}
+
+func TestDecryptKeyWithWrongScryptParams(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyJSON, err := EncryptKey(NewKey(privateKey), "password", LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, modify := range []func(params *scryptParamsJSON){
+		func(params *scryptParamsJSON) { params.DKLen = 16 },
+		func(params *scryptParamsJSON) { params.N = maxScryptN * 2 },
+		func(params *scryptParamsJSON) { params.R = maxScryptR + 1 },
+		func(params *scryptParamsJSON) { params.P = 0 },
+	} {
+		var encrypted encryptedKeyJSON
+		if err := json.Unmarshal(keyJSON, &encrypted); err != nil {
+			t.Fatal(err)
+		}
+		modify(&encrypted.Crypto.KDFParams)
+
+		modified, err := json.Marshal(encrypted)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := DecryptKey(modified, "password"); err == nil || err == ErrDecrypt {
+			t.Fatalf("expected error of scrypt parameters %+v, got %v", encrypted.Crypto.KDFParams, err)
+		}
+	}
+}
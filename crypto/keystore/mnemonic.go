@@ -0,0 +1,58 @@
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+)
+
+// Entropy sizes of mnemonics of 12 and 24 words
+const (
+	MnemonicEntropy12Words = 128
+	MnemonicEntropy24Words = 256
+)
+
+// ErrInvalidMnemonic is returned if mnemonic has unknown words or wrong checksum
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// NewMnemonic generates BIP-39 mnemonic with given bits of entropy
+func NewMnemonic(entropyBits int) (string, error) {
+	entropy, err := bip39.NewEntropy(entropyBits)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// IsMnemonicValid reports whether mnemonic consists of known words and has valid checksum
+func IsMnemonicValid(mnemonic string) bool {
+	_, err := bip39.MnemonicToByteArray(normalizeMnemonic(mnemonic))
+	return err == nil
+}
+
+// SeedFromMnemonic returns BIP-39 seed of mnemonic protected by optional passphrase
+func SeedFromMnemonic(mnemonic string, passphrase string) ([]byte, error) {
+	mnemonic = normalizeMnemonic(mnemonic)
+	if !IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	return bip39.NewSeed(mnemonic, passphrase), nil
+}
+
+// KeyFromMnemonic recovers private key from mnemonic by BIP-32 derivation path
+func KeyFromMnemonic(mnemonic string, passphrase string, path string) (*ecdsa.PrivateKey, error) {
+	seed, err := SeedFromMnemonic(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeriveKey(seed, path)
+}
+
+func normalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(mnemonic), " ")
+}
@@ -6,6 +6,7 @@ require (
 	github.com/MinterTeam/node-grpc-gateway v1.2.1
 	github.com/btcsuite/btcd v0.20.1-beta
 	github.com/c-bata/go-prompt v0.2.3
+	github.com/cosmos/go-bip39 v0.0.0-20180819234021-555e2067c45d
 	github.com/go-kit/kit v0.10.0
 	github.com/golang/protobuf v1.4.3
 	github.com/google/uuid v1.1.2
@@ -8,6 +8,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/core/transaction"
 	"github.com/MinterTeam/minter-go-node/core/types"
 	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/crypto/keystore"
 	"github.com/MinterTeam/minter-go-node/rlp"
 	"github.com/tendermint/go-amino"
 	tmTypes "github.com/tendermint/tendermint/abci/types"
@@ -132,6 +133,19 @@ func CreateAddress() (types.Address, *ecdsa.PrivateKey) {
 	return crypto.PubkeyToAddress(pk.PublicKey), pk
 }
 
+// testMnemonic is BIP-39 mnemonic of deterministic test accounts
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// DeriveAddress returns address and private key of test account with given index, derived from test mnemonic by BIP-44
+func DeriveAddress(index uint32) (types.Address, *ecdsa.PrivateKey) {
+	pk, err := keystore.KeyFromMnemonic(testMnemonic, "", keystore.AccountPath(index))
+	if err != nil {
+		panic(err)
+	}
+
+	return crypto.PubkeyToAddress(pk.PublicKey), pk
+}
+
 // DefaultAppState returns new AppState with some predefined values
 func DefaultAppState() types.AppState {
 	return types.AppState{
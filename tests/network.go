@@ -0,0 +1,553 @@
+package tests
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"github.com/MinterTeam/minter-go-node/config"
+	eventsdb "github.com/MinterTeam/minter-go-node/core/events"
+	"github.com/MinterTeam/minter-go-node/core/minter"
+	"github.com/MinterTeam/minter-go-node/core/state"
+	candidatesState "github.com/MinterTeam/minter-go-node/core/state/candidates"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/core/validators"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/tendermint/go-amino"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	tmCrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmLog "github.com/tendermint/tendermint/libs/log"
+	tmRand "github.com/tendermint/tendermint/libs/rand"
+	mempl "github.com/tendermint/tendermint/mempool"
+	tmNode "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/proxy"
+	rpc "github.com/tendermint/tendermint/rpc/client/local"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmTypes "github.com/tendermint/tendermint/types"
+	"math/big"
+	"net"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stuckTimeout is how long the network may make no progress before a test fails
+const stuckTimeout = 30 * time.Second
+
+// Clock is a mock clock of the network. Validators sign votes of a height with the same time, which is later than time
+// of the previous height by BlockInterval, so time of blocks doesn't depend on how fast the network runs.
+type Clock struct {
+	BlockInterval time.Duration
+
+	mtx     sync.Mutex
+	genesis time.Time
+	times   map[int64]time.Time
+	delay   time.Duration
+}
+
+// NewClock returns a clock started at genesis time
+func NewClock(genesis time.Time, blockInterval time.Duration) *Clock {
+	return &Clock{
+		BlockInterval: blockInterval,
+		genesis:       genesis,
+		times:         map[int64]time.Time{},
+	}
+}
+
+// TimeAt returns time of votes at height, which is time of the block at height+1. Time of the first block is genesis time.
+func (c *Clock) TimeAt(height int64) time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.timeAt(height)
+}
+
+func (c *Clock) timeAt(height int64) time.Time {
+	if height <= 0 {
+		return c.genesis
+	}
+
+	if t, ok := c.times[height]; ok {
+		return t
+	}
+
+	t := c.timeAt(height - 1).Add(c.BlockInterval + c.delay)
+	c.delay = 0
+	c.times[height] = t
+
+	return t
+}
+
+// Advance delays votes of the next height which is not signed yet by d, so the next block looks delayed
+func (c *Clock) Advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.delay += d
+}
+
+// signer is a private validator of a network node, it signs votes and proposals with time of the mock clock.
+// Absent signer refuses to sign, so the validator misses blocks. Byzantine signer signs a conflicting vote once and
+// reports the double signing to its node as evidence, which is gossiped and included into blocks by Tendermint.
+type signer struct {
+	privKey ed25519.PrivKeyEd25519
+	clock   *Clock
+	node    *tmNode.Node
+
+	mtx       sync.Mutex
+	absent    bool
+	byzantine bool
+}
+
+var errAbsent = errors.New("validator is absent")
+
+// GetPubKey implements tmTypes.PrivValidator
+func (s *signer) GetPubKey() (tmCrypto.PubKey, error) {
+	return s.privKey.PubKey(), nil
+}
+
+// SignVote implements tmTypes.PrivValidator
+func (s *signer) SignVote(chainID string, vote *tmTypes.Vote) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.absent {
+		return errAbsent
+	}
+
+	vote.Timestamp = s.clock.TimeAt(vote.Height)
+	if err := s.sign(chainID, vote); err != nil {
+		return err
+	}
+
+	if s.byzantine && vote.Type == tmTypes.PrevoteType {
+		s.byzantine = false
+
+		conflicting := vote.Copy()
+		conflicting.BlockID = tmTypes.BlockID{
+			Hash:        tmRand.Bytes(tmhash.Size),
+			PartsHeader: tmTypes.PartSetHeader{Total: 1, Hash: tmRand.Bytes(tmhash.Size)},
+		}
+		if err := s.sign(chainID, conflicting); err != nil {
+			return err
+		}
+
+		if err := s.node.EvidencePool().AddEvidence(tmTypes.NewDuplicateVoteEvidence(s.privKey.PubKey(), vote, conflicting)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SignProposal implements tmTypes.PrivValidator
+func (s *signer) SignProposal(chainID string, proposal *tmTypes.Proposal) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.absent {
+		return errAbsent
+	}
+
+	proposal.Timestamp = s.clock.TimeAt(proposal.Height)
+	signature, err := s.privKey.Sign(proposal.SignBytes(chainID))
+	if err != nil {
+		return err
+	}
+	proposal.Signature = signature
+
+	return nil
+}
+
+func (s *signer) sign(chainID string, vote *tmTypes.Vote) error {
+	signature, err := s.privKey.Sign(vote.SignBytes(chainID))
+	if err != nil {
+		return err
+	}
+	vote.Signature = signature
+
+	return nil
+}
+
+// haltingApp is Blockchain of a network node, which records height of halt. Tendermint recovers the panic of halted
+// application and stops consensus of the node silently.
+type haltingApp struct {
+	*minter.Blockchain
+
+	halted int64
+}
+
+// BeginBlock records height of halt and passes the panic to Tendermint
+func (app *haltingApp) BeginBlock(req abciTypes.RequestBeginBlock) abciTypes.ResponseBeginBlock {
+	defer func() {
+		if r := recover(); r != nil {
+			if r == fmt.Sprintf("Application halted at height %d", req.Header.Height) {
+				atomic.StoreInt64(&app.halted, req.Header.Height)
+			}
+			panic(r)
+		}
+	}()
+
+	return app.Blockchain.BeginBlock(req)
+}
+
+// Validator is a validator of the network and the owner of its candidate
+type Validator struct {
+	PrivKey   ed25519.PrivKeyEd25519
+	PubKey    types.Pubkey
+	TmAddress types.TmAddress
+
+	Owner    types.Address
+	OwnerKey *ecdsa.PrivateKey
+
+	signer *signer
+}
+
+// Network is a chain run by Tendermint nodes connected over localhost, one node per validator. Nodes reach consensus
+// the way a real network does, time of blocks is taken from the mock Clock shared by signers of validators.
+type Network struct {
+	t testing.TB
+
+	Nodes      []*minter.Blockchain
+	Validators []*Validator
+	Clock      *Clock
+
+	apps    []*haltingApp
+	tmNodes []*tmNode.Node
+	client  *rpc.Local
+}
+
+// NewNetwork starts nodes of a chain with given genesis state, to which validators with given stakes in base coin are added.
+// Owners of validators' candidates are funded with base coin equal to their stakes.
+func NewNetwork(t testing.TB, stakes []*big.Int, genesis types.AppState) *Network {
+	n := &Network{
+		t:     t,
+		Clock: NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), 5*time.Second),
+	}
+
+	if len(stakes) > validators.GetValidatorsCountForBlock(1) {
+		t.Fatalf("too many validators: %d", len(stakes))
+	}
+
+	for _, stake := range stakes {
+		validator := &Validator{PrivKey: ed25519.GenPrivKey()}
+		validator.PubKey = types.Pubkey(validator.PrivKey.PubKey().(ed25519.PubKeyEd25519))
+		copy(validator.TmAddress[:], validator.PrivKey.PubKey().Address().Bytes())
+		validator.Owner, validator.OwnerKey = CreateAddress()
+		validator.signer = &signer{privKey: validator.PrivKey, clock: n.Clock}
+		n.Validators = append(n.Validators, validator)
+
+		genesis.Validators = append(genesis.Validators, types.Validator{
+			TotalBipStake: stake.String(),
+			PubKey:        validator.PubKey,
+			AccumReward:   "0",
+			AbsentTimes:   types.NewBitArray(24),
+		})
+		genesis.Candidates = append(genesis.Candidates, types.Candidate{
+			ID:             uint64(len(genesis.Candidates)) + 1,
+			RewardAddress:  validator.Owner,
+			OwnerAddress:   validator.Owner,
+			ControlAddress: validator.Owner,
+			TotalBipStake:  stake.String(),
+			PubKey:         validator.PubKey,
+			Commission:     10,
+			Stakes: []types.Stake{
+				{
+					Owner:    validator.Owner,
+					Coin:     uint64(types.GetBaseCoinID()),
+					Value:    stake.String(),
+					BipValue: stake.String(),
+				},
+			},
+			Status: candidatesState.CandidateStatusOnline,
+		})
+		genesis.Accounts = append(genesis.Accounts, types.Account{
+			Address: validator.Owner,
+			Balance: []types.Balance{{Coin: uint64(types.GetBaseCoinID()), Value: stake.String()}},
+		})
+	}
+
+	if err := genesis.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	appState, err := amino.MarshalJSON(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appHash := [32]byte{}
+	genesisDoc := &tmTypes.GenesisDoc{
+		GenesisTime: n.Clock.TimeAt(0),
+		ChainID:     "test",
+		AppHash:     appHash[:],
+		AppState:    appState,
+	}
+	if err := genesisDoc.ValidateAndComplete(); err != nil {
+		t.Fatal(err)
+	}
+
+	nodeKeys := make([]*p2p.NodeKey, len(n.Validators))
+	addresses := make([]string, len(n.Validators))
+	for i := range n.Validators {
+		nodeKeys[i] = &p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+		addresses[i] = fmt.Sprintf("127.0.0.1:%d", freePort(t))
+	}
+
+	// nodes are stopped before their directory is removed, cleanups run in reverse order
+	dir := t.TempDir()
+	t.Cleanup(n.stop)
+
+	for i, validator := range n.Validators {
+		var peers []string
+		for j, address := range addresses {
+			if j != i {
+				peers = append(peers, p2p.IDAddressString(nodeKeys[j].ID(), address))
+			}
+		}
+
+		cfg := networkNodeConfig(filepath.Join(dir, fmt.Sprintf("node%d", i)), i, addresses[i], peers)
+		tmConfig := config.GetTmConfig(cfg)
+
+		app := &haltingApp{Blockchain: minter.NewMinterBlockchain(cfg)}
+		n.apps = append(n.apps, app)
+		n.Nodes = append(n.Nodes, app.Blockchain)
+
+		node, err := tmNode.NewNode(
+			tmConfig,
+			validator.signer,
+			nodeKeys[i],
+			proxy.NewLocalClientCreator(app),
+			func() (*tmTypes.GenesisDoc, error) { return genesisDoc, nil },
+			tmNode.DefaultDBProvider,
+			tmNode.DefaultMetricsProvider(tmConfig.Instrumentation),
+			tmLog.NewNopLogger(),
+		)
+		if err != nil {
+			t.Fatalf("failed to create node %d: %s", i, err)
+		}
+		validator.signer.node = node
+
+		if err := node.Start(); err != nil {
+			t.Fatalf("failed to start node %d: %s", i, err)
+		}
+		n.tmNodes = append(n.tmNodes, node)
+		app.SetTmNode(node)
+	}
+
+	n.client = rpc.New(n.tmNodes[0])
+
+	return n
+}
+
+// networkNodeConfig returns config of i-th node, which listens on address and connects to peers.
+// Timeouts of consensus are short, so blocks are produced as fast as nodes exchange votes.
+func networkNodeConfig(root string, i int, address string, peers []string) *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.SetRoot(root)
+	config.EnsureRoot(cfg.RootDir)
+
+	cfg.Moniker = fmt.Sprintf("node%d", i)
+	cfg.FastSync = false
+	cfg.KeepLastStates = 10000
+
+	cfg.P2P.ListenAddress = "tcp://" + address
+	cfg.P2P.PersistentPeers = strings.Join(peers, ",")
+	cfg.P2P.Seeds = ""
+	cfg.P2P.PexReactor = false
+	cfg.P2P.AddrBookStrict = false
+	cfg.P2P.AllowDuplicateIP = true
+	cfg.P2P.AddrBook = "config/addrbook.json"
+	cfg.RPC.ListenAddress = ""
+	cfg.Mempool.CacheSize = 1000
+	cfg.Instrumentation.Prometheus = false
+
+	cfg.Consensus.TimeoutPropose = 500 * time.Millisecond
+	cfg.Consensus.TimeoutProposeDelta = 100 * time.Millisecond
+	cfg.Consensus.TimeoutPrevote = 100 * time.Millisecond
+	cfg.Consensus.TimeoutPrevoteDelta = 50 * time.Millisecond
+	cfg.Consensus.TimeoutPrecommit = 100 * time.Millisecond
+	cfg.Consensus.TimeoutPrecommitDelta = 50 * time.Millisecond
+	cfg.Consensus.TimeoutCommit = 50 * time.Millisecond
+	cfg.Consensus.PeerGossipSleepDuration = 10 * time.Millisecond
+
+	return cfg
+}
+
+func freePort(t testing.TB) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func (n *Network) stop() {
+	for _, node := range n.tmNodes {
+		_ = node.Stop()
+		node.ConsensusState().Wait()
+	}
+
+	for _, app := range n.apps {
+		app.Stop()
+	}
+
+	// every node allocates write buffers of its DBs in advance, return them to OS before the next network starts
+	debug.FreeOSMemory()
+}
+
+// Height returns height of the last block committed by the first node
+func (n *Network) Height() int64 {
+	return n.tmNodes[0].ConsensusState().GetState().LastBlockHeight
+}
+
+// Halted returns height at which nodes refused to begin the block, 0 if the network is running
+func (n *Network) Halted() int64 {
+	for _, app := range n.apps {
+		if halted := atomic.LoadInt64(&app.halted); halted != 0 {
+			return halted
+		}
+	}
+
+	return 0
+}
+
+// WaitHeight waits until every node commits block at height. If nodes halt before, false is returned.
+// The test fails if the network makes no progress for a while.
+func (n *Network) WaitHeight(height int64) bool {
+	progress, lastHeight := time.Now(), int64(-1)
+	for {
+		if n.Halted() != 0 {
+			return false
+		}
+
+		committed := height
+		for _, node := range n.tmNodes {
+			if h := node.ConsensusState().GetState().LastBlockHeight; h < committed {
+				committed = h
+			}
+		}
+
+		if committed >= height {
+			return true
+		}
+
+		if committed != lastHeight {
+			progress, lastHeight = time.Now(), committed
+		} else if time.Since(progress) > stuckTimeout {
+			n.t.Fatalf("network is stuck at height %d", committed)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// StateAt returns state of the first node committed at height with loaded candidates, their stakes and validators
+func (n *Network) StateAt(height int64) *state.CheckState {
+	s, err := n.Nodes[0].GetStateForHeight(uint64(height))
+	if err != nil {
+		n.t.Fatal(err)
+	}
+
+	s.Lock()
+	s.Candidates().LoadCandidates()
+	s.Candidates().LoadStakes()
+	s.Validators().LoadValidators()
+	s.Unlock()
+
+	return s
+}
+
+// Events returns events of the first node at height
+func (n *Network) Events(height int64) eventsdb.Events {
+	return n.Nodes[0].GetEventsDB().LoadEvents(uint32(height))
+}
+
+// ValidatorSet returns validators of Tendermint at height, sorted by voting power
+func (n *Network) ValidatorSet(height int64) []*tmTypes.Validator {
+	result, err := n.client.Validators(&height, 1, 100)
+	if err != nil {
+		n.t.Fatal(err)
+	}
+
+	return result.Validators
+}
+
+// SetAbsent makes signer of validator refuse to sign votes and proposals, or sign them again
+func (n *Network) SetAbsent(validator *Validator, absent bool) {
+	validator.signer.mtx.Lock()
+	defer validator.signer.mtx.Unlock()
+
+	validator.signer.absent = absent
+}
+
+// SetByzantine makes signer of validator sign a conflicting prevote at the next height it votes on
+func (n *Network) SetByzantine(validator *Validator) {
+	validator.signer.mtx.Lock()
+	defer validator.signer.mtx.Unlock()
+
+	validator.signer.byzantine = true
+}
+
+// SubmitTx signs tx of given type by key and adds it to mempool of the first node, which gossips it to the others.
+// Returns response of CheckTx and hash of tx to wait for it with WaitTx.
+func (n *Network) SubmitTx(key *ecdsa.PrivateKey, txType transaction.TxType, data interface{}) (abciTypes.ResponseCheckTx, []byte) {
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		n.t.Fatal(err)
+	}
+
+	tx := transaction.Transaction{
+		Nonce:         n.Nodes[0].CurrentState().Accounts().GetNonce(sender) + 1,
+		ChainID:       types.CurrentChainID,
+		GasPrice:      1,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: transaction.SigTypeSingle,
+	}
+	signedTx := tmTypes.Tx(SignTx(key, tx))
+
+	responses := make(chan *abciTypes.Response, 1)
+	if err := n.tmNodes[0].Mempool().CheckTx(signedTx, func(response *abciTypes.Response) {
+		responses <- response
+	}, mempl.TxInfo{}); err != nil {
+		n.t.Fatal(err)
+	}
+
+	return *(<-responses).GetCheckTx(), signedTx.Hash()
+}
+
+// WaitTx waits until tx with hash is delivered and returns its result with height of the block
+func (n *Network) WaitTx(hash []byte) *ctypes.ResultTx {
+	progress, lastHeight := time.Now(), n.Height()
+	for {
+		if result, err := n.client.Tx(hash, false); err == nil {
+			return result
+		}
+
+		if n.Halted() != 0 {
+			n.t.Fatalf("network is halted at height %d before tx %X is delivered", n.Halted(), hash)
+		}
+
+		if height := n.Height(); height != lastHeight {
+			progress, lastHeight = time.Now(), height
+		} else if time.Since(progress) > stuckTimeout {
+			n.t.Fatalf("network is stuck at height %d", height)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
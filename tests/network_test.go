@@ -0,0 +1,269 @@
+package tests
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	eventsdb "github.com/MinterTeam/minter-go-node/core/events"
+	candidatesState "github.com/MinterTeam/minter-go-node/core/state/candidates"
+	"github.com/MinterTeam/minter-go-node/core/transaction"
+	"github.com/MinterTeam/minter-go-node/core/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmTypes "github.com/tendermint/tendermint/types"
+	"math/big"
+	"testing"
+)
+
+func equalStakes(count int, bip int64) []*big.Int {
+	stakes := make([]*big.Int, count)
+	for i := range stakes {
+		stakes[i] = helpers.BipToPip(big.NewInt(bip))
+	}
+
+	return stakes
+}
+
+func hasValidator(set []*tmTypes.Validator, pubkey types.Pubkey) bool {
+	return votingPower(set, pubkey) != 0
+}
+
+func votingPower(set []*tmTypes.Validator, pubkey types.Pubkey) int64 {
+	for _, validator := range set {
+		if bytes.Equal(validator.Address, ed25519.PubKeyEd25519(pubkey).Address()) {
+			return validator.VotingPower
+		}
+	}
+
+	return 0
+}
+
+// submitTx submits tx to the network and waits until it is delivered successfully, returns height of its block
+func submitTx(t *testing.T, network *Network, key *ecdsa.PrivateKey, txType transaction.TxType, data interface{}) int64 {
+	response, hash := network.SubmitTx(key, txType, data)
+	if response.Code != 0 {
+		t.Fatalf("tx of type %d is rejected: %s", txType, response.Log)
+	}
+
+	result := network.WaitTx(hash)
+	if result.TxResult.Code != 0 {
+		t.Fatalf("tx of type %d is failed: %s", txType, result.TxResult.Log)
+	}
+
+	return result.Height
+}
+
+func TestNetwork_AbsentValidator(t *testing.T) {
+	network := NewNetwork(t, equalStakes(4, 1000), DefaultAppState())
+	absent := network.Validators[3]
+
+	// absent validators are not slashed during grace period after genesis
+	network.WaitHeight(120)
+
+	network.SetAbsent(absent, true)
+	from := network.Height()
+	to := from + 30
+	network.WaitHeight(to)
+
+	candidate := network.StateAt(to).Candidates().GetCandidate(absent.PubKey)
+	if candidate.Status != candidatesState.CandidateStatusOffline {
+		t.Fatalf("absent candidate status is %d, want offline", candidate.Status)
+	}
+
+	if network.StateAt(to).Candidates().GetTotalStake(absent.PubKey).Cmp(helpers.BipToPip(big.NewInt(1000))) != -1 {
+		t.Error("stake of absent validator is not slashed")
+	}
+
+	if hasValidator(network.ValidatorSet(to), absent.PubKey) {
+		t.Error("absent validator is not removed from validator set")
+	}
+
+	var slashed bool
+	for h := from; h <= to; h++ {
+		for _, event := range network.Events(h) {
+			if slash, ok := event.(*eventsdb.SlashEvent); ok && slash.ValidatorPubKey == absent.PubKey {
+				slashed = true
+			}
+		}
+	}
+	if !slashed {
+		t.Error("slash event is not found")
+	}
+
+	for _, validator := range network.Validators[:3] {
+		if !hasValidator(network.ValidatorSet(to), validator.PubKey) {
+			t.Errorf("validator %s is removed from validator set", validator.PubKey)
+		}
+	}
+}
+
+func TestNetwork_ByzantineValidator(t *testing.T) {
+	network := NewNetwork(t, equalStakes(4, 1000), DefaultAppState())
+	byzantine := network.Validators[1]
+
+	network.WaitHeight(5)
+	network.SetByzantine(byzantine)
+	to := network.Height() + 15
+	network.WaitHeight(to)
+
+	if network.StateAt(to).Validators().GetByPublicKey(byzantine.PubKey) != nil {
+		t.Error("byzantine validator is not removed from validators")
+	}
+
+	if stake := network.StateAt(to).Candidates().GetTotalStake(byzantine.PubKey); stake.Sign() != 0 {
+		t.Errorf("stake of byzantine validator is %s, want 0", stake)
+	}
+
+	if hasValidator(network.ValidatorSet(to), byzantine.PubKey) {
+		t.Error("byzantine validator is not removed from validator set")
+	}
+}
+
+func TestNetwork_HaltBlock(t *testing.T) {
+	// three voters have more than 2/3 of stake of validators present in any commit
+	network := NewNetwork(t, append(equalStakes(3, 1000), helpers.BipToPip(big.NewInt(100))), DefaultAppState())
+
+	haltHeight := network.Height() + 15
+	for _, validator := range network.Validators[:3] {
+		if height := submitTx(t, network, validator.OwnerKey, transaction.TypeSetHaltBlock, transaction.SetHaltBlockData{
+			PubKey: validator.PubKey,
+			Height: uint64(haltHeight),
+		}); height >= haltHeight {
+			t.Fatalf("set halt block tx is delivered at height %d, after halt height", height)
+		}
+	}
+
+	if network.WaitHeight(haltHeight + 5) {
+		t.Fatal("network is not halted")
+	}
+
+	if halted := network.Halted(); halted != haltHeight {
+		t.Fatalf("network is halted at height %d, want %d", halted, haltHeight)
+	}
+}
+
+func TestNetwork_HaltBlockWithoutConsensus(t *testing.T) {
+	network := NewNetwork(t, equalStakes(4, 1000), DefaultAppState())
+
+	haltHeight := network.Height() + 15
+	for _, validator := range network.Validators[:2] {
+		submitTx(t, network, validator.OwnerKey, transaction.TypeSetHaltBlock, transaction.SetHaltBlockData{
+			PubKey: validator.PubKey,
+			Height: uint64(haltHeight),
+		})
+	}
+
+	if !network.WaitHeight(haltHeight + 5) {
+		t.Fatalf("network is halted at height %d", network.Halted())
+	}
+}
+
+func TestNetwork_ValidatorRotation(t *testing.T) {
+	owner, ownerKey := CreateAddress()
+	genesis := DefaultAppState()
+	genesis.Accounts = append(genesis.Accounts, types.Account{
+		Address: owner,
+		Balance: []types.Balance{{Coin: uint64(types.GetBaseCoinID()), Value: helpers.BipToPip(big.NewInt(1000000)).String()}},
+	})
+
+	// the new validator has no node, validators of the network keep more than 2/3 of voting power without it
+	network := NewNetwork(t, equalStakes(4, 1000), genesis)
+
+	pubkey := types.Pubkey(ed25519.GenPrivKey().PubKey().(ed25519.PubKeyEd25519))
+
+	submitTx(t, network, ownerKey, transaction.TypeDeclareCandidacy, transaction.DeclareCandidacyData{
+		Address:    owner,
+		PubKey:     pubkey,
+		Commission: 10,
+		Coin:       types.GetBaseCoinID(),
+		Stake:      helpers.BipToPip(big.NewInt(1500)),
+	})
+	if height := submitTx(t, network, ownerKey, transaction.TypeSetCandidateOnline, transaction.SetCandidateOnData{PubKey: pubkey}); height >= 120 {
+		t.Fatalf("set candidate online tx is delivered at height %d, after stakes recalculation", height)
+	}
+
+	network.WaitHeight(121)
+	if network.StateAt(120).Validators().GetByPublicKey(pubkey) == nil {
+		t.Fatal("candidate is not a validator after stakes recalculation")
+	}
+
+	if hasValidator(network.ValidatorSet(121), pubkey) {
+		t.Fatal("validator update is applied before two blocks passed")
+	}
+
+	set := network.ValidatorSet(122)
+	if !hasValidator(set, pubkey) {
+		t.Fatal("candidate is not in validator set")
+	}
+
+	for _, validator := range set {
+		if !bytes.Equal(validator.Address, ed25519.PubKeyEd25519(pubkey).Address()) && validator.VotingPower >= votingPower(set, pubkey) {
+			t.Error("candidate with the biggest stake doesn't have the biggest voting power")
+		}
+	}
+}
+
+func TestNetwork_StakeKick(t *testing.T) {
+	genesis := DefaultAppState()
+
+	pubkey := types.Pubkey(ed25519.GenPrivKey().PubKey().(ed25519.PubKeyEd25519))
+
+	// the candidate is offline, an online one would become a validator without a node and be dropped for absence
+	stake := helpers.BipToPip(big.NewInt(1))
+	candidate := types.Candidate{
+		ID:             1,
+		TotalBipStake:  big.NewInt(0).Mul(stake, big.NewInt(candidatesState.MaxDelegatorsPerCandidate)).String(),
+		PubKey:         pubkey,
+		Commission:     10,
+		Status:         candidatesState.CandidateStatusOffline,
+		RewardAddress:  types.Address{1},
+		OwnerAddress:   types.Address{1},
+		ControlAddress: types.Address{1},
+	}
+	for i := 0; i < candidatesState.MaxDelegatorsPerCandidate; i++ {
+		address, _ := CreateAddress()
+		candidate.Stakes = append(candidate.Stakes, types.Stake{
+			Owner:    address,
+			Coin:     uint64(types.GetBaseCoinID()),
+			Value:    stake.String(),
+			BipValue: stake.String(),
+		})
+	}
+	kicked := candidate.Stakes[0].Owner
+	genesis.Candidates = append(genesis.Candidates, candidate)
+
+	delegator, delegatorKey := CreateAddress()
+	genesis.Accounts = append(genesis.Accounts, types.Account{
+		Address: delegator,
+		Balance: []types.Balance{{Coin: uint64(types.GetBaseCoinID()), Value: helpers.BipToPip(big.NewInt(100)).String()}},
+	})
+
+	network := NewNetwork(t, equalStakes(2, 1000000), genesis)
+
+	if height := submitTx(t, network, delegatorKey, transaction.TypeDelegate, transaction.DelegateData{
+		PubKey: pubkey,
+		Coin:   types.GetBaseCoinID(),
+		Value:  helpers.BipToPip(big.NewInt(10)),
+	}); height >= 120 {
+		t.Fatalf("delegate tx is delivered at height %d, after stakes recalculation", height)
+	}
+
+	network.WaitHeight(120)
+
+	if network.StateAt(120).WaitList().Get(kicked, pubkey, types.GetBaseCoinID()) == nil {
+		t.Error("the smallest stake is not moved to wait list")
+	}
+
+	if network.StateAt(120).Candidates().GetStakeOfAddress(pubkey, delegator, types.GetBaseCoinID()) == nil {
+		t.Error("stake of delegator is not added to candidate")
+	}
+
+	var kickEvent bool
+	for _, event := range network.Events(120) {
+		if kick, ok := event.(*eventsdb.StakeKickEvent); ok && kick.Address == kicked {
+			kickEvent = true
+		}
+	}
+	if !kickEvent {
+		t.Error("stake kick event is not found")
+	}
+}
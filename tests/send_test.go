@@ -65,3 +65,45 @@ func TestSend(t *testing.T) {
 		}
 	}
 }
+
+func TestSendFromDerivedAccount(t *testing.T) {
+	address, pk := DeriveAddress(0) // derive account for test from test mnemonic
+
+	state := DefaultAppState() // generate default state
+
+	// add address to genesis state
+	state.Accounts = append(state.Accounts, types.Account{
+		Address: address,
+		Balance: []types.Balance{
+			{
+				Coin:  uint64(types.GetBaseCoinID()),
+				Value: helpers.BipToPip(big.NewInt(1)).String(),
+			},
+		},
+	})
+
+	app := CreateApp(state) // create application
+	SendBeginBlock(app)     // send BeginBlock
+
+	recipient, _ := DeriveAddress(1) // derive recipient
+	value := big.NewInt(1)
+
+	tx := CreateTx(app, address, transaction.TypeSend, transaction.SendData{
+		Coin:  types.GetBaseCoinID(),
+		To:    recipient,
+		Value: value,
+	})
+
+	response := SendTx(app, SignTx(pk, tx)) // compose and send tx
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not OK: %s, %d", response.Log, response.Code)
+	}
+
+	SendEndBlock(app) // send EndBlock
+	SendCommit(app)   // send Commit
+
+	balance := app.CurrentState().Accounts().GetBalance(recipient, types.GetBaseCoinID())
+	if balance.Cmp(value) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %s, got %s", value, balance)
+	}
+}
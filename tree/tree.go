@@ -12,6 +12,7 @@ type ReadOnlyTree interface {
 	Version() int64
 	Hash() []byte
 	Iterate(fn func(key []byte, value []byte) bool) (stopped bool)
+	IterateRange(start, end []byte, ascending bool, fn func(key []byte, value []byte) bool) (stopped bool)
 	AvailableVersions() []int
 }
 
@@ -23,6 +24,7 @@ type MTree interface {
 	LoadVersion(targetVersion int64) (int64, error)
 	LazyLoadVersion(targetVersion int64) (int64, error)
 	SaveVersion() ([]byte, int64, error)
+	WorkingHash() []byte
 	DeleteVersionIfExists(version int64) error
 	DeleteVersionsRange(fromVersion, toVersion int64) error
 	GetImmutable() *ImmutableTree
@@ -88,6 +90,13 @@ func (t *mutableTree) Iterate(fn func(key []byte, value []byte) bool) (stopped b
 	return t.tree.Iterate(fn)
 }
 
+func (t *mutableTree) IterateRange(start, end []byte, ascending bool, fn func(key []byte, value []byte) bool) (stopped bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.tree.IterateRange(start, end, ascending, fn)
+}
+
 func (t *mutableTree) Hash() []byte {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
@@ -95,6 +104,14 @@ func (t *mutableTree) Hash() []byte {
 	return t.tree.Hash()
 }
 
+// WorkingHash returns hash of the tree with changes which are not saved yet
+func (t *mutableTree) WorkingHash() []byte {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.tree.WorkingHash()
+}
+
 func (t *mutableTree) Version() int64 {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
@@ -208,6 +225,12 @@ func (t *ImmutableTree) Iterate(fn func(key []byte, value []byte) bool) (stopped
 	return t.tree.Iterate(fn)
 }
 
+// IterateRange iterates over keys of the tree from start inclusive to end exclusive, in order. The keys and values
+// must not be modified, since they may point to data stored within IAVL.
+func (t *ImmutableTree) IterateRange(start, end []byte, ascending bool, fn func(key []byte, value []byte) bool) (stopped bool) {
+	return t.tree.IterateRange(start, end, ascending, fn)
+}
+
 // Hash returns the root hash.
 func (t *ImmutableTree) Hash() []byte {
 	return t.tree.Hash()
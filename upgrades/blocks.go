@@ -2,8 +2,12 @@ package upgrades
 
 const UpgradeBlock1 = 1185600
 
+// UpgradeBlock2 enables expiration of used checks and new types of transactions.
+// Checks used before it have no due block stored and never expire.
+const UpgradeBlock2 = 2000000
+
 func IsUpgradeBlock(height uint64) bool {
-	upgradeBlocks := []uint64{UpgradeBlock1}
+	upgradeBlocks := []uint64{UpgradeBlock1, UpgradeBlock2}
 
 	for _, block := range upgradeBlocks {
 		if height == block {
@@ -3,6 +3,7 @@ package upgrades
 var gracePeriods = []*gracePeriod{
 	newGracePeriod(1, 120),
 	newGracePeriod(UpgradeBlock1, UpgradeBlock1+120),
+	newGracePeriod(UpgradeBlock2, UpgradeBlock2+120),
 }
 
 func IsGraceBlock(block uint64) bool {